@@ -24,6 +24,7 @@ import (
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
 	"github.com/gorilla/handlers"
 )
 
@@ -37,7 +38,7 @@ type enveronment struct {
 	addr   string
 }
 
-func newEnveronment(filename string, addr string) (e *enveronment) {
+func newEnveronment(filename string, addr string, resume bool, check bool) (e *enveronment) {
 	e = &enveronment{}
 	var buf []byte
 	buf, e.err = ioutil.ReadFile(filename)
@@ -48,6 +49,16 @@ func newEnveronment(filename string, addr string) (e *enveronment) {
 	if e.err != nil {
 		return e
 	}
+	if resume {
+		if e.err = e.config.Set(coreconst.DataxCoreContainerJobResume, true); e.err != nil {
+			return e
+		}
+	}
+	if check {
+		if e.err = e.config.Set(coreconst.DataxCoreContainerJobCheck, true); e.err != nil {
+			return e
+		}
+	}
 	e.ctx, e.cancel = context.WithCancel(context.Background())
 	e.addr = addr
 	return e
@@ -74,6 +85,7 @@ func (e *enveronment) initServer() *enveronment {
 		r := http.NewServeMux()
 		recoverHandler := handlers.RecoveryHandler(handlers.PrintRecoveryStack(true))
 		r.Handle("/metrics", newMetricHandler(e.engine))
+		r.Handle("/metrics/prometheus", newPrometheusHandler(e.engine))
 		r.HandleFunc("/debug/pprof/", pprof.Index)
 		r.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
 		r.HandleFunc("/debug/pprof/profile", pprof.Profile)
@@ -125,6 +137,20 @@ func (e *enveronment) startEngine() *enveronment {
 	return e
 }
 
+// printReport 打印预检查报告或者工作结束时的统计报告
+func (e *enveronment) printReport() {
+	if e.engine == nil {
+		return
+	}
+	if r := e.engine.CheckReport(); r != nil {
+		fmt.Printf("\n%v", r)
+		return
+	}
+	if r := e.engine.Report(); r != nil {
+		fmt.Printf("\n%v", r)
+	}
+}
+
 func (e *enveronment) close() {
 	if e.server != nil {
 		e.server.Shutdown(e.ctx)
@@ -0,0 +1,29 @@
+create table source_mytable (
+    t_integer integer primary key,
+    t_bool boolean,
+    t_bigint bigint,
+    t_real real,
+    t_decimal decimal(20,6),
+    t_date date,
+    t_datetime datetime,
+    t_varchar varchar(100),
+    t_text text,
+    t_blob blob
+);
+
+create table dest_mytable (
+    t_integer integer primary key,
+    t_bool boolean,
+    t_bigint bigint,
+    t_real real,
+    t_decimal decimal(20,6),
+    t_date date,
+    t_datetime datetime,
+    t_varchar varchar(100),
+    t_text text,
+    t_blob blob
+);
+
+insert into source_mytable values(1, 1, 1234567890123, 1.5, 12345.123456, '2022-01-02', '2022-01-02 03:04:05', 'abc', 'hello', x'0102');
+insert into source_mytable values(2, 0, -1, -2.25, -0.5, '2022-02-03', '2022-02-03 04:05:06', 'def', 'world', x'0304');
+insert into source_mytable values(3, null, null, null, null, null, null, null, null, null);
@@ -37,3 +37,21 @@ func (h *metricHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
 	}
 	w.Write([]byte(h.engine.Metrics().JSON().String()))
 }
+
+type prometheusHandler struct {
+	engine *datax.Engine
+}
+
+func newPrometheusHandler(engine *datax.Engine) *prometheusHandler {
+	return &prometheusHandler{
+		engine: engine,
+	}
+}
+
+func (h *prometheusHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", datax.PrometheusContentType)
+	w.WriteHeader(http.StatusOK)
+	if err := h.engine.WritePrometheus(w); err != nil {
+		log.Errorf("WritePrometheus fail. err: %v", err)
+	}
+}
@@ -27,6 +27,8 @@ func main() {
 	var configFile = flag.String("c", "config.json", "config")
 	var wizardFile = flag.String("w", "", "wizard")
 	var httpAddr = flag.String("http", "", "http")
+	var resume = flag.Bool("resume", false, "resume")
+	var check = flag.Bool("check", false, "check")
 	flag.Parse()
 	if *wizardFile != "" {
 		if err := tools.NewWizard(*configFile, *wizardFile).GenerateConfigsAndScripts(); err != nil {
@@ -39,9 +41,19 @@ func main() {
 
 	log.Infof("config: %v\n", *configFile)
 
-	e := newEnveronment(*configFile, *httpAddr)
+	e := newEnveronment(*configFile, *httpAddr, *resume, *check)
 	defer e.close()
-	if err := e.build(); err != nil {
+	err := e.build()
+	e.printReport()
+	if *check {
+		if err != nil {
+			fmt.Printf("check fail. err: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("check success\n")
+		return
+	}
+	if err != nil {
 		fmt.Printf("run fail. err: %v\n", err)
 		os.Exit(1)
 	}
@@ -103,13 +103,13 @@ func (w *Wizard) GenerateConfigsAndScripts() (err error) {
 				".xlsxs.0.path", record[0]); err != nil {
 				return err
 			}
-		case "csvreader":
+		case "csvreader", "jsonlreader":
 			if err = cloneDataSource.Set(coreconst.DataxJobContentReaderParameter+
 				".path.0", record[0]); err != nil {
 				return err
 			}
 		case "db2reader", "mysqlreader", "oraclereader",
-			"postgresreader", "sqlserverreader":
+			"postgresreader", "sqlserverreader", "sqlitereader":
 			if err = cloneDataSource.Set(coreconst.DataxJobContentReaderParameter+
 				".connection.table.name", record[0]); err != nil {
 				return err
@@ -124,13 +124,13 @@ func (w *Wizard) GenerateConfigsAndScripts() (err error) {
 				".xlsxs.0.path", record[0]); err != nil {
 				return err
 			}
-		case "csvwriter":
+		case "csvwriter", "jsonlwriter":
 			if err = cloneDataSource.Set(coreconst.DataxJobContentWriterParameter+
 				".path.0", record[1]); err != nil {
 				return err
 			}
 		case "db2writer", "mysqlwriter", "oraclewriter",
-			"postgreswriter", "sqlserverwriter":
+			"postgreswriter", "sqlserverwriter", "sqlitewriter":
 			if err = cloneDataSource.Set(coreconst.DataxJobContentWriterParameter+
 				".connection.table.name", record[1]); err != nil {
 				return err
@@ -25,10 +25,13 @@ var (
 	DataxCoreContainerJobReportinterval               = "core.container.job.reportInterval"
 	DataxCoreContainerJobSleepinterval                = "core.container.job.sleepInterval"
 	DataxCoreContainerJobMaxWorkerNumber              = "core.container.job.maxWorkerNumber"
+	DataxCoreContainerJobResume                       = "core.container.job.resume"
+	DataxCoreContainerJobCheck                        = "core.container.job.check"
 	DataxCoreContainerTaskGroupID                     = "core.container.taskGroup.id"
 	DataxCoreContainerTaskGroupSleepinterval          = "core.container.taskGroup.sleepInterval"
 	DataxCoreContainerTaskGroupReportinterval         = "core.container.taskGroup.reportInterval"
 	DataxCoreContainerTaskGroupMaxWorkerNumber        = "core.container.taskGroup.maxWorkerNumber"
+	DataxCoreContainerTaskFailover                    = "core.container.task.failover"
 	DataxCoreContainerTaskFailoverMaxretrytimes       = "core.container.task.failover.maxRetryTimes"
 	DataxCoreContainerTaskFailoverRetryintervalinmsec = "core.container.task.failover.retryIntervalInMsec"
 	DataxCoreContainerTaskFailoverMaxwaitinmsec       = "core.container.task.failover.maxWaitInMsec"
@@ -64,7 +67,10 @@ var (
 	DataxJobSettingErrorlimit                         = "job.setting.errorLimit"
 	DataxJobSettingErrorlimitRecord                   = "job.setting.errorLimit.record"
 	DataxJobSettingErrorlimitPercent                  = "job.setting.errorLimit.percentage"
+	DataxJobSettingDirtyRecord                        = "job.setting.dirtyRecord"
 	DataxJobSettingDryrun                             = "job.setting.dryRun"
+	DataxJobSettingCheckpointPath                     = "job.setting.checkpoint.path"
+	DataxJobSettingReportPath                         = "job.setting.report.path"
 	DataxJobPreHandlerPluginType                      = "job.preHandler.pluginType"
 	DataxJobPreHandlerPluginName                      = "job.preHandler.pluginName"
 	DataxJobPostHandlerPluginType                     = "job.postHandler.pluginType"
@@ -0,0 +1,41 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import "github.com/Breeze0806/go-etl/element"
+
+// CheckColumn 预检查时读取或者写入的列
+type CheckColumn struct {
+	Name string             `json:"name"` //列名
+	Type element.ColumnType `json:"type"` //列类型
+}
+
+// ColumnChecker 列检查器，是工作的可选功能，在预检查PreCheck成功后返回读取或者写入的列，
+// 用于检查读取器和写入器之间列的个数以及类型是否兼容，无法获取列时返回空
+type ColumnChecker interface {
+	CheckColumns() []CheckColumn
+}
+
+// ColumnTypeCompatible 列类型为src的列能否写入列类型为dst的列，只有在转化必然失败时返回false，
+// 如时间和数值之间，布尔和时间之间，任意一方类型未知时返回true
+func ColumnTypeCompatible(src, dst element.ColumnType) bool {
+	switch src {
+	case element.TypeBool, element.TypeBigInt, element.TypeDecimal:
+		return dst != element.TypeTime
+	case element.TypeTime:
+		return dst != element.TypeBool && dst != element.TypeBigInt && dst != element.TypeDecimal
+	}
+	return true
+}
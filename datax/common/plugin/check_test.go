@@ -0,0 +1,98 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestColumnTypeCompatible(t *testing.T) {
+	tests := []struct {
+		name string
+		src  element.ColumnType
+		dst  element.ColumnType
+		want bool
+	}{
+		{
+			name: "1",
+			src:  element.TypeBigInt,
+			dst:  element.TypeDecimal,
+			want: true,
+		},
+		{
+			name: "2",
+			src:  element.TypeBigInt,
+			dst:  element.TypeTime,
+			want: false,
+		},
+		{
+			name: "3",
+			src:  element.TypeBool,
+			dst:  element.TypeTime,
+			want: false,
+		},
+		{
+			name: "4",
+			src:  element.TypeDecimal,
+			dst:  element.TypeString,
+			want: true,
+		},
+		{
+			name: "5",
+			src:  element.TypeTime,
+			dst:  element.TypeBigInt,
+			want: false,
+		},
+		{
+			name: "6",
+			src:  element.TypeTime,
+			dst:  element.TypeString,
+			want: true,
+		},
+		{
+			name: "7",
+			src:  element.TypeString,
+			dst:  element.TypeTime,
+			want: true,
+		},
+		{
+			name: "8",
+			src:  element.TypeBytes,
+			dst:  element.TypeBigInt,
+			want: true,
+		},
+		{
+			name: "9",
+			src:  element.TypeUnknown,
+			dst:  element.TypeTime,
+			want: true,
+		},
+		{
+			name: "10",
+			src:  element.TypeTime,
+			dst:  element.TypeUnknown,
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ColumnTypeCompatible(tt.src, tt.dst); got != tt.want {
+				t.Errorf("ColumnTypeCompatible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+// TableProvider 表提供者，是读取器工作的可选功能，用于在自动建表时提供源表的列以及列类别
+type TableProvider interface {
+	SourceColumns(ctx context.Context) ([]database.TableColumn, error)
+}
+
+// TableCreator 表创建者，是写入器工作的可选功能，开启自动建表时在准备Prepare前根据源表的列创建目标表
+type TableCreator interface {
+	AutoCreateTable() bool                                                 //是否开启自动建表
+	CreateTable(ctx context.Context, columns []database.TableColumn) error //根据源表的列columns创建目标表，目标表存在时不创建
+}
+
+// TableExpander 多表展开者，是读取器工作的可选功能，用于将配置的多张表或者表名模式展开为每张表的读取配置，
+// 工作容器会为每张表分别生成读取器和写入器工作
+type TableExpander interface {
+	ExpandTables(ctx context.Context) ([]ExpandedTable, error) //获取每张表的读取配置，只配置单张表时为空
+}
+
+// ExpandedTable 展开后的单张表
+type ExpandedTable struct {
+	Name string       //表名
+	Conf *config.JSON //单张表的读取配置
+}
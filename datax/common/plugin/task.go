@@ -24,9 +24,9 @@ import (
 type Task interface {
 	Plugin
 
-	//任务信息收集器，todo 未使用
+	//任务信息收集器，用于收集脏数据
 	TaskCollector() TaskCollector
-	//设置任务信息收集器，todo 未使用
+	//设置任务信息收集器
 	SetTaskCollector(collector TaskCollector)
 
 	//工作ID
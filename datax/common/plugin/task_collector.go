@@ -14,13 +14,29 @@
 
 package plugin
 
-import "github.com/Breeze0806/go-etl/element"
+import (
+	"time"
 
-// TaskCollector 任务收集器
-// todo 当前未使用
+	"github.com/Breeze0806/go-etl/element"
+)
+
+// TaskCollector 任务收集器，写入器和转化器通过它上报无法处理的单条记录（脏数据），
+// 脏数据的个数会被工作容器用于检查是否超过错误限制
 type TaskCollector interface {
+	//收集脏数据record以及错误err
 	CollectDirtyRecordWithError(record element.Record, err error)
+	//收集脏数据record以及错误信息msgErr
 	CollectDirtyRecordWithMsg(record element.Record, msgErr string)
+	//收集脏数据record以及错误err和错误信息msgErr
 	CollectDirtyRecord(record element.Record, err error, msgErr string)
+	//收集关键字为key，值为value的消息
 	CollectMessage(key string, value string)
 }
+
+// MetricCollector 指标收集器，任务收集器实现该接口时，插件可以通过它上报批量写入耗时和重试次数等指标
+type MetricCollector interface {
+	//收集一次批量写入的耗时latency
+	CollectBatchWriteLatency(latency time.Duration)
+	//收集n次重试
+	CollectRetry(n int)
+}
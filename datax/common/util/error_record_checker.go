@@ -14,6 +14,60 @@
 
 package util
 
-// ErrorRecordChecker 错误检查器 todo未使用
+import (
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/pingcap/errors"
+)
+
+// ErrorRecordChecker 错误记录检查器，检查脏数据的个数以及比例是否超过
+// 工作配置job.setting.errorLimit中的限制，没有配置的限制不检查
 type ErrorRecordChecker struct {
+	recordLimit     int64
+	hasRecordLimit  bool
+	percentageLimit float64
+	hasPercentLimit bool
+}
+
+// NewErrorRecordChecker 通过JSON配置conf中的job.setting.errorLimit.record
+// 和job.setting.errorLimit.percentage生成错误记录检查器
+func NewErrorRecordChecker(conf *config.JSON) *ErrorRecordChecker {
+	e := &ErrorRecordChecker{}
+	if conf == nil {
+		return e
+	}
+	if v, err := conf.GetInt64(coreconst.DataxJobSettingErrorlimitRecord); err == nil {
+		e.recordLimit = v
+		e.hasRecordLimit = true
+	}
+	if v, err := conf.GetFloat64(coreconst.DataxJobSettingErrorlimitPercent); err == nil {
+		e.percentageLimit = v
+		e.hasPercentLimit = true
+	}
+	return e
+}
+
+// CheckRecordLimit 检查脏数据个数errorRecords是否超过脏数据个数限制
+func (e *ErrorRecordChecker) CheckRecordLimit(errorRecords int64) error {
+	if !e.hasRecordLimit {
+		return nil
+	}
+	if errorRecords > e.recordLimit {
+		return errors.Errorf("dirty records(%v) exceed the limit(%v) of %v",
+			errorRecords, e.recordLimit, coreconst.DataxJobSettingErrorlimitRecord)
+	}
+	return nil
+}
+
+// CheckPercentageLimit 检查脏数据个数errorRecords占总记录数totalRecords的比例
+// 是否超过脏数据比例限制
+func (e *ErrorRecordChecker) CheckPercentageLimit(totalRecords, errorRecords int64) error {
+	if !e.hasPercentLimit || totalRecords <= 0 {
+		return nil
+	}
+	if percentage := float64(errorRecords) / float64(totalRecords); percentage > e.percentageLimit {
+		return errors.Errorf("dirty records percentage(%v) exceeds the limit(%v) of %v",
+			percentage, e.percentageLimit, coreconst.DataxJobSettingErrorlimitPercent)
+	}
+	return nil
 }
@@ -0,0 +1,122 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	conf, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func TestErrorRecordChecker_CheckRecordLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		conf         *config.JSON
+		errorRecords int64
+		wantErr      bool
+	}{
+		{
+			name:         "1",
+			conf:         testJSONFromString(`{}`),
+			errorRecords: 100,
+		},
+		{
+			name:         "2",
+			conf:         nil,
+			errorRecords: 100,
+		},
+		{
+			name:         "3",
+			conf:         testJSONFromString(`{"job":{"setting":{"errorLimit":{"record":0}}}}`),
+			errorRecords: 0,
+		},
+		{
+			name:         "4",
+			conf:         testJSONFromString(`{"job":{"setting":{"errorLimit":{"record":0}}}}`),
+			errorRecords: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "5",
+			conf:         testJSONFromString(`{"job":{"setting":{"errorLimit":{"record":10}}}}`),
+			errorRecords: 10,
+		},
+		{
+			name:         "6",
+			conf:         testJSONFromString(`{"job":{"setting":{"errorLimit":{"record":"10"}}}}`),
+			errorRecords: 11,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewErrorRecordChecker(tt.conf)
+			if err := e.CheckRecordLimit(tt.errorRecords); (err != nil) != tt.wantErr {
+				t.Errorf("ErrorRecordChecker.CheckRecordLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestErrorRecordChecker_CheckPercentageLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		conf         *config.JSON
+		totalRecords int64
+		errorRecords int64
+		wantErr      bool
+	}{
+		{
+			name:         "1",
+			conf:         testJSONFromString(`{}`),
+			totalRecords: 100,
+			errorRecords: 100,
+		},
+		{
+			name:         "2",
+			conf:         testJSONFromString(`{"job":{"setting":{"errorLimit":{"percentage":0.02}}}}`),
+			totalRecords: 100,
+			errorRecords: 2,
+		},
+		{
+			name:         "3",
+			conf:         testJSONFromString(`{"job":{"setting":{"errorLimit":{"percentage":0.02}}}}`),
+			totalRecords: 100,
+			errorRecords: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "4",
+			conf:         testJSONFromString(`{"job":{"setting":{"errorLimit":{"percentage":0.02}}}}`),
+			totalRecords: 0,
+			errorRecords: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewErrorRecordChecker(tt.conf)
+			if err := e.CheckPercentageLimit(tt.totalRecords, tt.errorRecords); (err != nil) != tt.wantErr {
+				t.Errorf("ErrorRecordChecker.CheckPercentageLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
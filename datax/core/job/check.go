@@ -0,0 +1,158 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/pingcap/errors"
+)
+
+// CheckReport 预检查报告
+type CheckReport struct {
+	JobID  int64       `json:"jobID"`  //工作编号
+	Passed bool        `json:"passed"` //是否通过
+	Items  []CheckItem `json:"items"`  //检查项
+}
+
+// CheckItem 预检查项
+type CheckItem struct {
+	Name  string `json:"name"`            //检查项名
+	Error string `json:"error,omitempty"` //检查失败的原因
+}
+
+// newCheckReport 通过工作编号jobID生成预检查报告
+func newCheckReport(jobID int64) *CheckReport {
+	return &CheckReport{
+		JobID:  jobID,
+		Passed: true,
+	}
+}
+
+// add 添加名为name的检查项，检查错误为err
+func (r *CheckReport) add(name string, err error) {
+	item := CheckItem{
+		Name: name,
+	}
+	if err != nil {
+		item.Error = err.Error()
+		r.Passed = false
+	}
+	r.Items = append(r.Items, item)
+}
+
+// err 预检查未通过时返回错误
+func (r *CheckReport) err() error {
+	if r.Passed {
+		return nil
+	}
+	return errors.NewNoStackError("pre-check failed")
+}
+
+// String 打印预检查报告
+func (r *CheckReport) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "DataX job %v check report\n", r.JobID)
+	w := tabwriter.NewWriter(b, 0, 0, 1, ' ', 0)
+	for _, v := range r.Items {
+		if v.Error == "" {
+			fmt.Fprintf(w, "%v\t: ok\n", v.Name)
+		} else {
+			fmt.Fprintf(w, "%v\t: fail. %v\n", v.Name, v.Error)
+		}
+	}
+	if r.Passed {
+		fmt.Fprintf(w, "result\t: passed\n")
+	} else {
+		fmt.Fprintf(w, "result\t: failed\n")
+	}
+	w.Flush()
+	return b.String()
+}
+
+// checkColumns 按照位置检查读取的列src和写入的列dst的个数以及类型是否兼容
+func checkColumns(src, dst []plugin.CheckColumn) error {
+	if len(src) != len(dst) {
+		return errors.Errorf("reader has %v columns but writer has %v columns", len(src), len(dst))
+	}
+	var msgs []string
+	for i := range src {
+		if !plugin.ColumnTypeCompatible(src[i].Type, dst[i].Type) {
+			msgs = append(msgs, fmt.Sprintf("reader column %v(%v) is incompatible with writer column %v(%v)",
+				src[i].Name, src[i].Type, dst[i].Name, dst[i].Type))
+		}
+	}
+	if len(msgs) != 0 {
+		return errors.NewNoStackError(strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// preCheck 初始化并预检查读取器和写入器工作，生成预检查报告，不会执行准备以及调度任务
+func (c *Container) preCheck() (err error) {
+	c.checkReport = newCheckReport(c.jobID)
+	defer func() {
+		log.Infof("DataX jobContainer %v %v", c.jobID, c.checkReport)
+	}()
+	if err = c.init(); err != nil {
+		c.checkReport.add("init", err)
+		return c.checkReport.err()
+	}
+	c.checkReport.add("init", nil)
+
+	for _, job := range c.jobs {
+		c.preCheckTableJob(job)
+	}
+	return c.checkReport.err()
+}
+
+// preCheckTableJob 预检查单张表的读取器和写入器工作，两者都通过且未配置转化器时再检查读取的列和写入的列是否兼容
+func (c *Container) preCheckTableJob(job *tableJob) {
+	rerr := job.reader.PreCheck(c.ctx)
+	c.checkReport.add("reader "+c.readerPluginName+job.String(), rerr)
+	werr := job.writer.PreCheck(c.ctx)
+	c.checkReport.add("writer "+c.writerPluginName+job.String(), werr)
+	if rerr != nil || werr != nil {
+		return
+	}
+
+	src, ok := job.reader.(plugin.ColumnChecker)
+	if !ok {
+		return
+	}
+	dst, ok := job.writer.(plugin.ColumnChecker)
+	if !ok {
+		return
+	}
+	//转化器可能增删或者改变列的类型，此时读取的列和写入的列无法按照位置比较
+	if transformConfs, _ := c.Config().GetConfigArray(coreconst.DataxJobContentTransformer); len(transformConfs) != 0 {
+		log.Infof("DataX jobContainer %v skip checking columns%v with transformers", c.jobID, job)
+		return
+	}
+	srcColumns, dstColumns := src.CheckColumns(), dst.CheckColumns()
+	if len(srcColumns) == 0 || len(dstColumns) == 0 {
+		return
+	}
+	c.checkReport.add("columns"+job.String(), checkColumns(srcColumns, dstColumns))
+}
+
+// CheckReport 获取预检查报告，不是预检查模式或者未预检查时为nil
+func (c *Container) CheckReport() *CheckReport {
+	return c.checkReport
+}
@@ -0,0 +1,306 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/datax/common/spi/reader"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+type mockCheckReaderJob struct {
+	*mockReaderJob
+
+	preCheckErr error
+	columns     []plugin.CheckColumn
+}
+
+func (m *mockCheckReaderJob) PreCheck(ctx context.Context) error {
+	return m.preCheckErr
+}
+
+func (m *mockCheckReaderJob) CheckColumns() []plugin.CheckColumn {
+	return m.columns
+}
+
+type mockCheckReader struct {
+	*mockReader
+
+	preCheckErr error
+	columns     []plugin.CheckColumn
+}
+
+func (m *mockCheckReader) Job() reader.Job {
+	return &mockCheckReaderJob{
+		mockReaderJob: newMockReaderJob(m.errs, m.confs),
+		preCheckErr:   m.preCheckErr,
+		columns:       m.columns,
+	}
+}
+
+type mockCheckWriterJob struct {
+	*mockWriterJob
+
+	preCheckErr error
+	columns     []plugin.CheckColumn
+}
+
+func (m *mockCheckWriterJob) PreCheck(ctx context.Context) error {
+	return m.preCheckErr
+}
+
+func (m *mockCheckWriterJob) CheckColumns() []plugin.CheckColumn {
+	return m.columns
+}
+
+type mockCheckWriter struct {
+	*mockWriter
+
+	preCheckErr error
+	columns     []plugin.CheckColumn
+}
+
+func (m *mockCheckWriter) Job() writer.Job {
+	return &mockCheckWriterJob{
+		mockWriterJob: newMockWriterJob(m.errs, m.confs),
+		preCheckErr:   m.preCheckErr,
+		columns:       m.columns,
+	}
+}
+
+func Test_checkColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     []plugin.CheckColumn
+		dst     []plugin.CheckColumn
+		wantErr bool
+	}{
+		{
+			name: "1",
+			src: []plugin.CheckColumn{
+				{Name: "a", Type: element.TypeBigInt},
+				{Name: "b", Type: element.TypeTime},
+			},
+			dst: []plugin.CheckColumn{
+				{Name: "a", Type: element.TypeDecimal},
+				{Name: "b", Type: element.TypeString},
+			},
+		},
+		{
+			name: "2",
+			src: []plugin.CheckColumn{
+				{Name: "a", Type: element.TypeBigInt},
+			},
+			dst: []plugin.CheckColumn{
+				{Name: "a", Type: element.TypeDecimal},
+				{Name: "b", Type: element.TypeString},
+			},
+			wantErr: true,
+		},
+		{
+			name: "3",
+			src: []plugin.CheckColumn{
+				{Name: "a", Type: element.TypeBigInt},
+				{Name: "b", Type: element.TypeTime},
+			},
+			dst: []plugin.CheckColumn{
+				{Name: "a", Type: element.TypeTime},
+				{Name: "b", Type: element.TypeString},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkColumns(tt.src, tt.dst); (err != nil) != tt.wantErr {
+				t.Errorf("checkColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckReport_String(t *testing.T) {
+	r := newCheckReport(1)
+	r.add("init", nil)
+	if err := r.err(); err != nil {
+		t.Errorf("err() = %v", err)
+	}
+	r.add("reader mock", errors.New("mock error"))
+	if err := r.err(); err == nil {
+		t.Errorf("err() = nil")
+	}
+	got := r.String()
+	for _, want := range []string{"check report", "init", ": ok", "reader mock", "mock error", "failed"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %v, want contains %v", got, want)
+		}
+	}
+}
+
+func TestContainer_PreCheck(t *testing.T) {
+	conf := func(transformer string) *config.JSON {
+		return testJSONFromString(`{
+			"core": {
+				"container": {
+					"job": {
+						"id": 1,
+						"check": true
+					}
+				}
+			},
+			"job": {
+				"content": [{
+					"reader": {
+						"name": "mock",
+						"parameter": {}
+					},
+					"writer": {
+						"name": "mock",
+						"parameter": {}
+					},
+					"transformer": ` + transformer + `
+				}]
+			}
+		}`)
+	}
+	columns := []plugin.CheckColumn{
+		{Name: "a", Type: element.TypeBigInt},
+		{Name: "b", Type: element.TypeTime},
+	}
+	//准备和切分失败时不会影响预检查
+	errs := []error{nil, errors.New("mock prepare error"), errors.New("mock split error"), nil, nil}
+	tests := []struct {
+		name        string
+		reader      *mockCheckReader
+		writer      *mockCheckWriter
+		transformer string
+		wantItems   []string
+		wantErr     bool
+	}{
+		{
+			name: "1",
+			reader: &mockCheckReader{
+				mockReader: newMockReader(errs, nil),
+				columns:    columns,
+			},
+			writer: &mockCheckWriter{
+				mockWriter: newMockWriter(errs, nil),
+				columns:    columns,
+			},
+			wantItems: []string{"init", "reader mock", "writer mock", "columns"},
+		},
+		{
+			name: "2",
+			reader: &mockCheckReader{
+				mockReader: newMockReader(errs, nil),
+			},
+			writer: &mockCheckWriter{
+				mockWriter: newMockWriter(errs, nil),
+				columns:    columns,
+			},
+			wantItems: []string{"init", "reader mock", "writer mock"},
+		},
+		{
+			name: "3",
+			reader: &mockCheckReader{
+				mockReader:  newMockReader(errs, nil),
+				preCheckErr: errors.New("mock error"),
+				columns:     columns,
+			},
+			writer: &mockCheckWriter{
+				mockWriter: newMockWriter(errs, nil),
+				columns:    columns,
+			},
+			wantItems: []string{"init", "reader mock", "writer mock"},
+			wantErr:   true,
+		},
+		{
+			name: "4",
+			reader: &mockCheckReader{
+				mockReader: newMockReader(errs, nil),
+				columns:    columns,
+			},
+			writer: &mockCheckWriter{
+				mockWriter: newMockWriter(errs, nil),
+				columns:    columns[:1],
+			},
+			wantItems: []string{"init", "reader mock", "writer mock", "columns"},
+			wantErr:   true,
+		},
+		{
+			name: "5",
+			reader: &mockCheckReader{
+				mockReader: newMockReader([]error{errors.New("mock init error"), nil, nil, nil, nil}, nil),
+			},
+			writer: &mockCheckWriter{
+				mockWriter: newMockWriter(errs, nil),
+			},
+			wantItems: []string{"init"},
+			wantErr:   true,
+		},
+		{
+			name: "6",
+			reader: &mockCheckReader{
+				mockReader: newMockReader(errs, nil),
+				columns:    columns,
+			},
+			writer: &mockCheckWriter{
+				mockWriter: newMockWriter(errs, nil),
+				columns:    columns[:1],
+			},
+			transformer: `[{"name":"dx_replace","parameter":{"columnIndex":0,"paras":["1","1","x"]}}]`,
+			wantItems:   []string{"init", "reader mock", "writer mock"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetLoader()
+			loader.RegisterReader("mock", tt.reader)
+			loader.RegisterWriter("mock", tt.writer)
+			if tt.transformer == "" {
+				tt.transformer = "[]"
+			}
+			c := testContainer(conf(tt.transformer))
+			if err := c.Start(); (err != nil) != tt.wantErr {
+				t.Errorf("Start() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if c.Report() != nil {
+				t.Errorf("Report() = %v, want nil", c.Report())
+			}
+			r := c.CheckReport()
+			if r == nil {
+				t.Fatalf("CheckReport() = nil")
+			}
+			var items []string
+			for _, v := range r.Items {
+				items = append(items, v.Name)
+			}
+			if strings.Join(items, ",") != strings.Join(tt.wantItems, ",") {
+				t.Errorf("CheckReport() items = %v, want %v", items, tt.wantItems)
+			}
+			if r.Passed == tt.wantErr {
+				t.Errorf("CheckReport() passed = %v, wantErr %v", r.Passed, tt.wantErr)
+			}
+		})
+	}
+}
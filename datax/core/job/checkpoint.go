@@ -0,0 +1,115 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/pingcap/errors"
+)
+
+// jobReaderParameterSplitRange 切分后读取任务的切分范围
+const jobReaderParameterSplitRange = "reader.parameter.split.range"
+
+// checkpoint 断点，记录工作切分后已经成功完成的任务，用于中断后续跑
+type checkpoint struct {
+	JobID int64            `json:"jobID"` //工作编号
+	Tasks []checkpointTask `json:"tasks"` //已经成功完成的任务
+}
+
+// checkpointTask 已经成功完成的任务
+type checkpointTask struct {
+	TaskID int64           `json:"taskID"`          //任务编号
+	Digest string          `json:"digest"`          //任务配置摘要
+	Range  json.RawMessage `json:"range,omitempty"` //切分范围
+}
+
+// loadCheckpoint 从断点文件filename中读取断点，文件不存在时返回nil
+func loadCheckpoint(filename string) (cp *checkpoint, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(filename); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	cp = &checkpoint{}
+	if err = json.Unmarshal(data, cp); err != nil {
+		return nil, errors.Wrapf(err, "checkpoint file %v is invalid", filename)
+	}
+	return
+}
+
+// save 将断点保存到断点文件filename中，先写入临时文件再重命名，
+// 避免写入中断导致断点文件损坏
+func (cp *checkpoint) save(filename string) (err error) {
+	sort.Slice(cp.Tasks, func(i, j int) bool {
+		return cp.Tasks[i].TaskID < cp.Tasks[j].TaskID
+	})
+	var data []byte
+	if data, err = json.Marshal(cp); err != nil {
+		return
+	}
+	if dir := filepath.Dir(filename); dir != "" {
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return
+		}
+	}
+	tmp := filename + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	return os.Rename(tmp, filename)
+}
+
+// finished 任务编号taskID，任务配置摘要digest对应的任务是否已经成功完成
+func (cp *checkpoint) finished(taskID int64, digest string) bool {
+	for _, v := range cp.Tasks {
+		if v.TaskID == taskID && v.Digest == digest {
+			return true
+		}
+	}
+	return false
+}
+
+// newCheckpointTask 通过任务配置conf生成已经成功完成的任务
+func newCheckpointTask(conf *config.JSON) (task checkpointTask, err error) {
+	if task.TaskID, err = conf.GetInt64(coreconst.TaskID); err != nil {
+		return
+	}
+	task.Digest = taskDigest(conf)
+	task.Range = taskRange(conf)
+	return
+}
+
+// taskDigest 任务配置conf的摘要，读取和写入参数不变时摘要不变
+func taskDigest(conf *config.JSON) string {
+	h := sha256.New()
+	for _, path := range []string{coreconst.JobReader, coreconst.JobWriter, coreconst.JobTransformer} {
+		if c, err := conf.GetConfig(path); err == nil {
+			h.Write([]byte(c.String()))
+		}
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,369 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/datax/common/spi/reader"
+	"github.com/pingcap/errors"
+)
+
+func Test_checkpoint(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filename := filepath.Join(tmpDir, "a", "checkpoint.json")
+
+	cp, err := loadCheckpoint(filename)
+	if err != nil || cp != nil {
+		t.Fatalf("loadCheckpoint() = %v %v, want nil", cp, err)
+	}
+
+	task1, err := newCheckpointTask(testJSONFromString(`{
+		"taskId":1,
+		"reader":{"name":"mock","parameter":{"split":{"range":{"type":"bigInt","left":"1","right":"10"}}}},
+		"writer":{"name":"mock","parameter":{}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(task1.Range) != `{"type":"bigInt","left":"1","right":"10"}` {
+		t.Errorf("newCheckpointTask() Range = %s", task1.Range)
+	}
+	task0, err := newCheckpointTask(testJSONFromString(`{
+		"taskId":0,
+		"reader":{"name":"mock","parameter":{}},
+		"writer":{"name":"mock","parameter":{}}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task0.Range != nil {
+		t.Errorf("newCheckpointTask() Range = %s", task0.Range)
+	}
+	if _, err = newCheckpointTask(testJSONFromString(`{}`)); err == nil {
+		t.Errorf("newCheckpointTask() error = nil")
+	}
+
+	want := &checkpoint{
+		JobID: 1,
+		Tasks: []checkpointTask{task1, task0},
+	}
+	if err = want.save(filename); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+	if cp, err = loadCheckpoint(filename); err != nil {
+		t.Fatalf("loadCheckpoint() error = %v", err)
+	}
+	if !reflect.DeepEqual(cp, want) {
+		t.Errorf("loadCheckpoint() = %+v, want %+v", cp, want)
+	}
+	if cp.Tasks[0].TaskID != 0 {
+		t.Errorf("save() tasks are not sorted")
+	}
+	if !cp.finished(1, task1.Digest) {
+		t.Errorf("finished() = false, want true")
+	}
+	if cp.finished(1, task0.Digest) {
+		t.Errorf("finished() = true, want false")
+	}
+	if cp.finished(2, task1.Digest) {
+		t.Errorf("finished() = true, want false")
+	}
+
+	if err = ioutil.WriteFile(filename, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = loadCheckpoint(filename); err == nil {
+		t.Errorf("loadCheckpoint() error = nil")
+	}
+}
+
+func Test_taskDigest(t *testing.T) {
+	tests := []struct {
+		name  string
+		conf1 *config.JSON
+		conf2 *config.JSON
+		want  bool
+	}{
+		{
+			name:  "1",
+			conf1: testJSONFromString(`{"taskId":1,"reader":{"parameter":{"where":"id < 10"}},"writer":{"parameter":{}}}`),
+			conf2: testJSONFromString(`{"taskId":2,"reader":{"parameter":{"where":"id < 10"}},"writer":{"parameter":{}}}`),
+			want:  true,
+		},
+		{
+			name:  "2",
+			conf1: testJSONFromString(`{"taskId":1,"reader":{"parameter":{"where":"id < 10"}},"writer":{"parameter":{}}}`),
+			conf2: testJSONFromString(`{"taskId":1,"reader":{"parameter":{"where":"id < 11"}},"writer":{"parameter":{}}}`),
+			want:  false,
+		},
+		{
+			name:  "3",
+			conf1: testJSONFromString(`{"taskId":1,"reader":{"parameter":{}},"writer":{"parameter":{}}}`),
+			conf2: testJSONFromString(`{"taskId":1,"reader":{"parameter":{}},"writer":{"parameter":{}},"transformer":[{"name":"dx_replace"}]}`),
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taskDigest(tt.conf1) == taskDigest(tt.conf2); got != tt.want {
+				t.Errorf("taskDigest() equal = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type mockCheckpointReader struct {
+	*mockReader
+
+	read sync.Map
+}
+
+func (m *mockCheckpointReader) Task() reader.Task {
+	return &mockCheckpointReaderTask{
+		mockReaderTask: newMockReaderTask(),
+		read:           &m.read,
+	}
+}
+
+func (m *mockCheckpointReader) readIDs() (ids []int64) {
+	m.read.Range(func(key, value interface{}) bool {
+		ids = append(ids, key.(int64))
+		return true
+	})
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return
+}
+
+type mockCheckpointReaderTask struct {
+	*mockReaderTask
+
+	read *sync.Map
+}
+
+func (m *mockCheckpointReaderTask) StartRead(ctx context.Context, sender plugin.RecordSender) error {
+	if m.PluginJobConf().GetBoolOrDefaullt("fail", false) {
+		return errors.New("mock test error")
+	}
+	m.read.Store(m.PluginJobConf().GetInt64OrDefaullt("id", -1), true)
+	return nil
+}
+
+func TestContainer_Resume(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filename := filepath.Join(tmpDir, "checkpoint.json")
+
+	errs := []error{nil, nil, nil, nil, nil}
+	writerConfs := []*config.JSON{
+		testJSONFromString(`{}`),
+		testJSONFromString(`{}`),
+		testJSONFromString(`{}`),
+	}
+	conf := func(resume bool) *config.JSON {
+		c := testJSONFromString(`{
+			"core": {
+				"container": {
+					"job": {
+						"id": 1
+					},
+					"taskGroup": {
+						"channel": 1
+					}
+				}
+			},
+			"job": {
+				"setting": {
+					"speed": {
+						"channel": 3
+					}
+				},
+				"content": [{
+					"reader": {
+						"name": "mock",
+						"parameter": {}
+					},
+					"writer": {
+						"name": "mock",
+						"parameter": {}
+					},
+					"transformer": []
+				}]
+			}
+		}`)
+		c.Set("job.setting.checkpoint.path", filename)
+		c.Set("core.container.job.resume", resume)
+		return c
+	}
+
+	resetLoader()
+	failed := &mockCheckpointReader{
+		mockReader: newMockReader(errs, []*config.JSON{
+			testJSONFromString(`{"id":1}`),
+			testJSONFromString(`{"id":2,"fail":true}`),
+			testJSONFromString(`{"id":3}`),
+		}),
+	}
+	loader.RegisterReader("mock", failed)
+	loader.RegisterWriter("mock", newMockWriter(errs, writerConfs))
+	if err = testContainer(conf(false)).Start(); err == nil {
+		t.Fatalf("Start() error = nil")
+	}
+	if got := failed.readIDs(); !reflect.DeepEqual(got, []int64{1, 3}) {
+		t.Errorf("read = %v, want %v", got, []int64{1, 3})
+	}
+	cp, err := loadCheckpoint(filename)
+	if err != nil || cp == nil {
+		t.Fatalf("loadCheckpoint() = %v %v", cp, err)
+	}
+	var finished []int64
+	for _, v := range cp.Tasks {
+		finished = append(finished, v.TaskID)
+	}
+	if !reflect.DeepEqual(finished, []int64{0, 2}) {
+		t.Errorf("checkpoint tasks = %v, want %v", finished, []int64{0, 2})
+	}
+
+	resetLoader()
+	resumed := &mockCheckpointReader{
+		mockReader: newMockReader(errs, []*config.JSON{
+			testJSONFromString(`{"id":1}`),
+			testJSONFromString(`{"id":2}`),
+			testJSONFromString(`{"id":3}`),
+		}),
+	}
+	loader.RegisterReader("mock", resumed)
+	loader.RegisterWriter("mock", newMockWriter(errs, writerConfs))
+	if err = testContainer(conf(true)).Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if got := resumed.readIDs(); !reflect.DeepEqual(got, []int64{2}) {
+		t.Errorf("read = %v, want %v", got, []int64{2})
+	}
+	if _, err = os.Stat(filename); !os.IsNotExist(err) {
+		t.Errorf("checkpoint is not removed. err: %v", err)
+	}
+}
+
+func TestContainer_ResumeAllFinished(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filename := filepath.Join(tmpDir, "checkpoint.json")
+
+	conf := func(resume bool) *config.JSON {
+		c := testJSONFromString(`{
+			"core": {
+				"container": {
+					"job": {
+						"id": 1
+					}
+				}
+			},
+			"job": {
+				"setting": {
+					"speed": {
+						"channel": 1
+					}
+				},
+				"content": [{
+					"reader": {
+						"name": "mock",
+						"parameter": {}
+					},
+					"writer": {
+						"name": "mock",
+						"parameter": {}
+					},
+					"transformer": []
+				}]
+			}
+		}`)
+		c.Set("job.setting.checkpoint.path", filename)
+		c.Set("core.container.job.resume", resume)
+		return c
+	}
+	readerConfs := []*config.JSON{
+		testJSONFromString(`{"id":1}`),
+		testJSONFromString(`{"id":2}`),
+	}
+	writerConfs := []*config.JSON{
+		testJSONFromString(`{}`),
+		testJSONFromString(`{}`),
+	}
+
+	//所有任务都成功，但是后置通知失败
+	resetLoader()
+	r := &mockCheckpointReader{
+		mockReader: newMockReader([]error{nil, nil, nil, errors.New("mock test error"), nil}, readerConfs),
+	}
+	loader.RegisterReader("mock", r)
+	loader.RegisterWriter("mock", newMockWriter([]error{nil, nil, nil, nil, nil}, writerConfs))
+	if err = testContainer(conf(false)).Start(); err == nil {
+		t.Fatalf("Start() error = nil")
+	}
+	if got := r.readIDs(); !reflect.DeepEqual(got, []int64{1, 2}) {
+		t.Errorf("read = %v, want %v", got, []int64{1, 2})
+	}
+
+	resetLoader()
+	r = &mockCheckpointReader{
+		mockReader: newMockReader([]error{nil, nil, nil, nil, nil}, readerConfs),
+	}
+	loader.RegisterReader("mock", r)
+	loader.RegisterWriter("mock", newMockWriter([]error{nil, nil, nil, nil, nil}, writerConfs))
+	if err = testContainer(conf(true)).Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if got := r.readIDs(); len(got) != 0 {
+		t.Errorf("read = %v, want empty", got)
+	}
+}
+
+func TestNewContainer_Resume(t *testing.T) {
+	if _, err := NewContainer(context.TODO(), testJSONFromString(`{
+		"core": {
+			"container": {
+				"job": {
+					"id": 1,
+					"resume": true
+				}
+			}
+		}
+	}`)); err == nil {
+		t.Errorf("NewContainer() error = nil")
+	}
+}
@@ -16,7 +16,9 @@ package job
 
 import (
 	"context"
+	"encoding/json"
 	"math"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -34,7 +36,9 @@ import (
 	"github.com/Breeze0806/go-etl/datax/core/statistics/container"
 	statplugin "github.com/Breeze0806/go-etl/datax/core/statistics/container/plugin"
 	"github.com/Breeze0806/go-etl/datax/core/taskgroup"
+	"github.com/Breeze0806/go-etl/datax/core/transport/channel"
 	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/pingcap/errors"
 )
 
@@ -45,8 +49,7 @@ type Container struct {
 	jobID                  int64
 	readerPluginName       string
 	writerPluginName       string
-	jobReader              reader.Job
-	jobWriter              writer.Job
+	jobs                   []*tableJob //单表同步时只有一个，多表同步时每张表对应一个
 	userConf               *config.JSON
 	startTimestamp         int64
 	endTimestamp           int64
@@ -55,10 +58,29 @@ type Container struct {
 	needChannelNumber      int64
 	totalStage             int
 	reportInterval         time.Duration
-	//todo ErrorRecordChecker未使用
-	errorLimit   util.ErrorRecordChecker
-	taskSchduler *schedule.TaskSchduler
-	wg           sync.WaitGroup
+	errorLimit             *util.ErrorRecordChecker //错误记录检查器
+	taskSchduler           *schedule.TaskSchduler
+	wg                     sync.WaitGroup
+	taskGroupStats         sync.Map //任务组序号对应的记录统计信息
+	resume                 bool     //是否从断点续跑
+	checkpointPath         string   //断点文件路径
+	checkpoint             *checkpoint
+	checkpointTasks        map[int64]checkpointTask //未完成任务编号对应的断点任务
+	checkpointMutex        sync.Mutex
+	reportPath             string                    //统计报告文件路径
+	taskRanges             map[int64]json.RawMessage //任务编号对应的切分范围
+	taskTables             map[int64]string          //多表同步时任务编号对应的表名
+	report                 *Report
+	check                  bool //是否只进行预检查
+	checkReport            *CheckReport
+}
+
+// recordStats 记录统计信息
+type recordStats struct {
+	totalRecord int64             //读取的总记录数
+	errorRecord int64             //脏数据个数
+	tasks       []taskgroup.Stats //各个任务的统计信息
+	finished    []int64           //成功完成的任务编号
 }
 
 // NewContainer 通过上下文ctx和JSON配置conf生成工作容器环境
@@ -75,6 +97,14 @@ func NewContainer(ctx context.Context, conf *config.JSON) (c *Container, err err
 		return nil, errors.New("container job id is invalid")
 	}
 	c.reportInterval = time.Duration(c.Config().GetFloat64OrDefaullt(coreconst.DataxCoreContainerJobReportinterval, 1)) * time.Second
+	c.errorLimit = util.NewErrorRecordChecker(c.Config())
+	c.resume = c.Config().GetBoolOrDefaullt(coreconst.DataxCoreContainerJobResume, false)
+	c.checkpointPath = c.Config().GetStringOrDefaullt(coreconst.DataxJobSettingCheckpointPath, "")
+	if c.resume && c.checkpointPath == "" {
+		return nil, errors.Errorf("%v should be setted when resume", coreconst.DataxJobSettingCheckpointPath)
+	}
+	c.reportPath = c.Config().GetStringOrDefaullt(coreconst.DataxJobSettingReportPath, "")
+	c.check = c.Config().GetBoolOrDefaullt(coreconst.DataxCoreContainerJobCheck, false)
 	c.Metrics().Set("jobID", c.jobID)
 	return
 }
@@ -82,6 +112,11 @@ func NewContainer(ctx context.Context, conf *config.JSON) (c *Container, err err
 // Start 工作容器开始工作
 func (c *Container) Start() (err error) {
 	log.Infof("DataX jobContainer %v starts job.", c.jobID)
+	c.startTimestamp = time.Now().UnixNano()
+	defer func() {
+		c.endTimestamp = time.Now().UnixNano()
+		c.generateReport(err)
+	}()
 	defer c.destroy()
 	c.userConf = c.Config().CloneConfig()
 
@@ -91,6 +126,14 @@ func (c *Container) Start() (err error) {
 		return
 	}
 
+	if c.check {
+		log.Infof("DataX jobContainer %v starts to preCheck.", c.jobID)
+		if err = c.preCheck(); err != nil {
+			log.Errorf("DataX jobContainer %v preCheck failed. err: %v", c.jobID, err)
+		}
+		return
+	}
+
 	log.Infof("DataX jobContainer %v starts to init.", c.jobID)
 	if err = c.init(); err != nil {
 		log.Errorf("DataX jobContainer %v init failed. err: %v", c.jobID, err)
@@ -106,16 +149,22 @@ func (c *Container) Start() (err error) {
 		log.Errorf("DataX jobContainer %v split failed. err: %v", c.jobID, err)
 		return
 	}
-	log.Infof("DataX jobContainer %v starts to schedule. err: %v", c.jobID)
-	if err = c.schedule(); err != nil {
-		log.Errorf("DataX jobContainer %v schedule failed. err: %v", c.jobID, err)
-		return
+	//续跑时所有任务都已经在断点中成功完成
+	if c.totalStage == 0 {
+		log.Infof("DataX jobContainer %v all tasks have finished in checkpoint.", c.jobID)
+	} else {
+		log.Infof("DataX jobContainer %v starts to schedule. err: %v", c.jobID)
+		if err = c.schedule(); err != nil {
+			log.Errorf("DataX jobContainer %v schedule failed. err: %v", c.jobID, err)
+			return
+		}
 	}
 	log.Infof("DataX jobContainer %v starts to post.", c.jobID)
 	if err = c.post(); err != nil {
 		log.Errorf("DataX jobContainer %v post failed. err: %v", c.jobID, err)
 		return
 	}
+	c.removeCheckpoint()
 	log.Debugf("DataX jobContainer %v starts to postHandle.", c.jobID)
 	if err = c.postHandle(); err != nil {
 		log.Errorf("DataX jobContainer %v postHandle failed. err: %v", c.jobID, err)
@@ -125,22 +174,24 @@ func (c *Container) Start() (err error) {
 	return nil
 }
 
-// destroy 销毁，在jobReader不为空时进行销毁
-// 在jobWriter不为空时进行销毁
+// destroy 销毁，逐个销毁每张表的读取工作和写入工作，在读取工作不为空时进行销毁
+// 在写入工作不为空时进行销毁
 func (c *Container) destroy() (err error) {
-	if c.jobReader != nil {
-		if rerr := c.jobReader.Destroy(c.ctx); rerr != nil {
-			log.Errorf("DataX jobContainer %v jobReader %s destroy error: %v",
-				c.jobID, c.readerPluginName, rerr)
-			err = rerr
+	for _, job := range c.jobs {
+		if job.reader != nil {
+			if rerr := job.reader.Destroy(c.ctx); rerr != nil {
+				log.Errorf("DataX jobContainer %v jobReader %s%s destroy error: %v",
+					c.jobID, c.readerPluginName, job, rerr)
+				err = rerr
+			}
 		}
-	}
 
-	if c.jobWriter != nil {
-		if werr := c.jobWriter.Destroy(c.ctx); werr != nil {
-			log.Errorf("DataX jobContainer %v jobWriter %s destroy error: %v",
-				c.jobID, c.writerPluginName, werr)
-			err = werr
+		if job.writer != nil {
+			if werr := job.writer.Destroy(c.ctx); werr != nil {
+				log.Errorf("DataX jobContainer %v jobWriter %s%s destroy error: %v",
+					c.jobID, c.writerPluginName, job, werr)
+				err = werr
+			}
 		}
 	}
 	return
@@ -183,12 +234,23 @@ func (c *Container) init() (err error) {
 	writerConfig.Set(coreconst.DataxJobSetting, jobSettingConf)
 
 	collector := statplugin.NewDefaultJobCollector(c.Metrics())
-	c.jobReader, err = c.initReaderJob(collector, readerConfig, writerConfig)
+	job := &tableJob{}
+	c.jobs = []*tableJob{job}
+	job.reader, err = c.initReaderJob(collector, readerConfig, writerConfig)
 	if err != nil {
 		return
 	}
 	log.Infof("DataX jobContainer %v reader %v inited", c.jobID, c.readerPluginName)
-	c.jobWriter, err = c.initWriterJob(collector, readerConfig, writerConfig)
+
+	var tables []plugin.ExpandedTable
+	if tables, err = c.expandTables(job.reader); err != nil {
+		return
+	}
+	if len(tables) != 0 {
+		return c.initTableJobs(collector, tables, writerConfig)
+	}
+
+	job.writer, err = c.initWriterJob(collector, readerConfig, writerConfig)
 	if err != nil {
 		return
 	}
@@ -196,28 +258,55 @@ func (c *Container) init() (err error) {
 	return
 }
 
-// prepare 准备读取器和写入器工作
+// prepare 逐个准备每张表的读取器和写入器工作
 // 如果读取器和写入器工作准备失败就会报错
 func (c *Container) prepare() (err error) {
-	if err = c.prepareReaderJob(); err != nil {
-		return err
-	}
-	log.Infof("DataX jobContainer %v reader %v prepared", c.jobID, c.readerPluginName)
-	if err = c.prepareWriterJob(); err != nil {
-		return err
+	for _, job := range c.jobs {
+		if err = c.createTable(job); err != nil {
+			return err
+		}
+		if err = c.prepareReaderJob(job); err != nil {
+			return err
+		}
+		log.Infof("DataX jobContainer %v reader %v%v prepared", c.jobID, c.readerPluginName, job)
+		if err = c.prepareWriterJob(job); err != nil {
+			return err
+		}
+		log.Infof("DataX jobContainer %v writer %v%v prepared", c.jobID, c.writerPluginName, job)
 	}
-	log.Infof("DataX jobContainer %v writer %v prepared", c.jobID, c.writerPluginName)
 	return
 }
 
 // prepareReaderJob 准备读取工作
-func (c *Container) prepareReaderJob() error {
-	return c.jobReader.Prepare(c.ctx)
+func (c *Container) prepareReaderJob(job *tableJob) error {
+	return job.reader.Prepare(c.ctx)
 }
 
 // prepareReaderJob 准备写入工作
-func (c *Container) prepareWriterJob() error {
-	return c.jobWriter.Prepare(c.ctx)
+func (c *Container) prepareWriterJob(job *tableJob) error {
+	return job.writer.Prepare(c.ctx)
+}
+
+// createTable 写入器开启自动建表时，根据读取器提供的源表结构创建目标表
+func (c *Container) createTable(job *tableJob) (err error) {
+	creator, ok := job.writer.(plugin.TableCreator)
+	if !ok || !creator.AutoCreateTable() {
+		return nil
+	}
+	provider, ok := job.reader.(plugin.TableProvider)
+	if !ok {
+		return errors.Errorf("reader %v does not provide source table for writer %v to create table",
+			c.readerPluginName, c.writerPluginName)
+	}
+	var columns []database.TableColumn
+	if columns, err = provider.SourceColumns(c.ctx); err != nil {
+		return errors.Wrapf(err, "reader %v%v SourceColumns fail", c.readerPluginName, job)
+	}
+	if err = creator.CreateTable(c.ctx, columns); err != nil {
+		return errors.Wrapf(err, "writer %v%v CreateTable fail", c.writerPluginName, job)
+	}
+	log.Infof("DataX jobContainer %v writer %v%v create table end", c.jobID, c.writerPluginName, job)
+	return
 }
 
 // split 切分读取器和写入器工作
@@ -232,8 +321,45 @@ func (c *Container) split() (err error) {
 	if c.needChannelNumber <= 0 {
 		c.needChannelNumber = 1
 	}
-	var readerConfs, writerConfs, tasksConfigs []*config.JSON
-	readerConfs, err = c.jobReader.Split(c.ctx, int(c.needChannelNumber))
+	var tasksConfigs []*config.JSON
+	c.taskTables = make(map[int64]string)
+	for _, job := range c.jobs {
+		var confs []*config.JSON
+		if confs, err = c.splitTableJob(job); err != nil {
+			return
+		}
+		//多表同步时任务编号在所有表的任务中依次递增
+		for _, conf := range confs {
+			taskID := int64(len(tasksConfigs))
+			conf.Set(coreconst.TaskID, taskID)
+			if job.table != "" {
+				c.taskTables[taskID] = job.table
+			}
+			tasksConfigs = append(tasksConfigs, conf)
+		}
+	}
+
+	tasksConfigs, err = c.restoreCheckpoint(tasksConfigs)
+	if err != nil {
+		return
+	}
+
+	c.taskRanges = make(map[int64]json.RawMessage)
+	for _, conf := range tasksConfigs {
+		if r := taskRange(conf); r != nil {
+			c.taskRanges[conf.GetInt64OrDefaullt(coreconst.TaskID, 0)] = r
+		}
+	}
+	c.Config().Set(coreconst.DataxJobContent, tasksConfigs)
+
+	c.totalStage = len(tasksConfigs)
+	return nil
+}
+
+// splitTableJob 切分单张表的读取器和写入器工作，并组合成完整任务
+func (c *Container) splitTableJob(job *tableJob) (tasksConfigs []*config.JSON, err error) {
+	var readerConfs, writerConfs []*config.JSON
+	readerConfs, err = job.reader.Split(c.ctx, int(c.needChannelNumber))
 	if err != nil {
 		return
 	}
@@ -244,8 +370,8 @@ func (c *Container) split() (err error) {
 	}
 
 	taskNumber := len(readerConfs)
-	log.Infof("DataX jobContainer %v reader %v split %v tasks", c.jobID, c.readerPluginName, taskNumber)
-	writerConfs, err = c.jobWriter.Split(c.ctx, taskNumber)
+	log.Infof("DataX jobContainer %v reader %v%v split %v tasks", c.jobID, c.readerPluginName, job, taskNumber)
+	writerConfs, err = job.writer.Split(c.ctx, taskNumber)
 	if err != nil {
 		return
 	}
@@ -254,20 +380,14 @@ func (c *Container) split() (err error) {
 		err = errors.New("writer split fail, config is empty")
 		return
 	}
-	log.Infof("DataX jobContainer %v writer %v split %v tasks", c.jobID, c.readerPluginName, len(writerConfs))
-
-	tasksConfigs, err = c.mergeTaskConfigs(readerConfs, writerConfs)
-	if err != nil {
-		return
-	}
-
-	c.Config().Set(coreconst.DataxJobContent, tasksConfigs)
+	log.Infof("DataX jobContainer %v writer %v%v split %v tasks", c.jobID, c.writerPluginName, job, len(writerConfs))
 
-	c.totalStage = len(tasksConfigs)
-	return nil
+	return c.mergeTaskConfigs(readerConfs, writerConfs)
 }
 
 // schedule 使用调度器将任务组进行调度，进入执行队列中
+// 在执行过程中脏数据个数超过限制时会停止所有任务组，执行结束后脏数据个数
+// 或者比例超过限制时也会报错
 func (c *Container) schedule() (err error) {
 	var tasksConfigs []*config.JSON
 	tasksConfigs, err = c.distributeTaskIntoTaskGroup()
@@ -280,9 +400,14 @@ func (c *Container) schedule() (err error) {
 	defer c.taskSchduler.Stop()
 	var taskGroups []*taskgroup.Container
 
+	ctx, cancel := context.WithCancel(c.ctx)
+	defer cancel()
+	ctx = channel.WithLimiter(ctx, c.newLimiter())
+	var limitErr error
+	var limitOnce sync.Once
 	for i := range tasksConfigs {
 		var taskGroup *taskgroup.Container
-		taskGroup, err = taskgroup.NewContainer(c.ctx, tasksConfigs[i])
+		taskGroup, err = taskgroup.NewContainer(ctx, tasksConfigs[i])
 		if err != nil {
 			goto End
 		}
@@ -305,18 +430,29 @@ func (c *Container) schedule() (err error) {
 				case taskGroup.Err = <-errChan:
 					c.setStats(taskGroup, i)
 					return
-				case <-c.ctx.Done():
+				case <-ctx.Done():
 					c.setStats(taskGroup, i)
 					return
 				case <-statsTimer.C:
 					c.setStats(taskGroup, i)
 				}
-
+				//脏数据个数超过限制时停止所有任务组
+				if _, errorRecord := c.totalRecordStats(); errorRecord > 0 {
+					if err := c.errorLimit.CheckRecordLimit(errorRecord); err != nil {
+						limitOnce.Do(func() {
+							limitErr = err
+							cancel()
+						})
+					}
+				}
 			}
 		}(taskGroup, i)
 	}
 End:
 	c.wg.Wait()
+	if limitErr != nil {
+		return limitErr
+	}
 
 	b := &strings.Builder{}
 	for _, t := range taskGroups {
@@ -328,24 +464,176 @@ End:
 	if b.Len() != 0 {
 		return errors.NewNoStackError(b.String())
 	}
-	return
+	if err != nil {
+		return
+	}
+	return c.checkErrorLimit()
 }
 
 func (c *Container) setStats(taskGroup *taskgroup.Container, i int) {
 	stats := taskGroup.Metrics().JSON().Clone()
 	c.Metrics().Set("metrics."+strconv.Itoa(i), stats)
+
+	totalRecord, errorRecord := taskGroup.RecordStats()
+	finished := taskGroup.FinishedTaskIDs()
+	c.taskGroupStats.Store(i, recordStats{
+		totalRecord: totalRecord,
+		errorRecord: errorRecord,
+		tasks:       taskGroup.TaskStats(),
+		finished:    finished,
+	})
+	c.recordCheckpoint(finished)
 }
 
-// post 后置通知
-func (c *Container) post() (err error) {
-	if err = c.jobReader.Post(c.ctx); err != nil {
-		return err
+// Report 获取工作结束时的统计报告，工作未结束时为nil
+func (c *Container) Report() *Report {
+	return c.report
+}
+
+// generateReport 根据工作错误err生成统计报告，打印到日志并在设置了统计报告文件路径时保存
+func (c *Container) generateReport(err error) {
+	if c.check {
+		return
+	}
+	c.report = newReport(c.jobID, time.Unix(0, c.startTimestamp),
+		time.Unix(0, c.endTimestamp), err)
+	var groups []int
+	c.taskGroupStats.Range(func(key, value interface{}) bool {
+		groups = append(groups, key.(int))
+		return true
+	})
+	sort.Ints(groups)
+	for _, i := range groups {
+		value, _ := c.taskGroupStats.Load(i)
+		stats := value.(recordStats)
+		c.report.addTaskGroup(int64(i), stats.tasks, stats.finished, c.taskRanges, c.taskTables)
+	}
+	log.Infof("DataX jobContainer %v %v", c.jobID, c.report)
+	if c.reportPath == "" {
+		return
+	}
+	if rerr := c.report.save(c.reportPath); rerr != nil {
+		log.Errorf("DataX jobContainer %v save report %v fail. err: %v", c.jobID, c.reportPath, rerr)
 	}
-	log.Infof("DataX jobContainer %v reader %v posted", c.jobID, c.readerPluginName)
-	if err = c.jobWriter.Post(c.ctx); err != nil {
+}
+
+// restoreCheckpoint 初始化断点，续跑时从断点文件中读取已经成功完成的任务，
+// 返回任务配置tasksConfigs中去掉这些任务后需要执行的任务配置
+// 任务编号相同但任务配置摘要不同的任务，例如切分范围发生变化，仍然需要执行
+func (c *Container) restoreCheckpoint(tasksConfigs []*config.JSON) (remains []*config.JSON, err error) {
+	if c.checkpointPath == "" {
+		return tasksConfigs, nil
+	}
+	c.checkpoint = &checkpoint{
+		JobID: c.jobID,
+	}
+	c.checkpointTasks = make(map[int64]checkpointTask)
+
+	var last *checkpoint
+	if c.resume {
+		if last, err = loadCheckpoint(c.checkpointPath); err != nil {
+			return nil, err
+		}
+		if last == nil {
+			log.Infof("DataX jobContainer %v checkpoint %v does not exist, run all tasks", c.jobID, c.checkpointPath)
+		} else if last.JobID != c.jobID {
+			return nil, errors.Errorf("checkpoint %v belongs to job %v, not job %v",
+				c.checkpointPath, last.JobID, c.jobID)
+		}
+	}
+
+	for _, conf := range tasksConfigs {
+		var task checkpointTask
+		if task, err = newCheckpointTask(conf); err != nil {
+			return nil, err
+		}
+		if last != nil && last.finished(task.TaskID, task.Digest) {
+			c.checkpoint.Tasks = append(c.checkpoint.Tasks, task)
+			continue
+		}
+		c.checkpointTasks[task.TaskID] = task
+		remains = append(remains, conf)
+	}
+	if c.resume {
+		log.Infof("DataX jobContainer %v resume from checkpoint %v, %v tasks finished, %v tasks remain",
+			c.jobID, c.checkpointPath, len(c.checkpoint.Tasks), len(remains))
+	}
+
+	if err = c.checkpoint.save(c.checkpointPath); err != nil {
+		return nil, errors.Wrapf(err, "save checkpoint %v fail", c.checkpointPath)
+	}
+	return
+}
+
+// recordCheckpoint 将已经成功完成的任务编号taskIDs记录到断点文件中
+func (c *Container) recordCheckpoint(taskIDs []int64) {
+	if c.checkpoint == nil {
+		return
+	}
+	c.checkpointMutex.Lock()
+	defer c.checkpointMutex.Unlock()
+	changed := false
+	for _, id := range taskIDs {
+		if task, ok := c.checkpointTasks[id]; ok {
+			c.checkpoint.Tasks = append(c.checkpoint.Tasks, task)
+			delete(c.checkpointTasks, id)
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	if err := c.checkpoint.save(c.checkpointPath); err != nil {
+		log.Errorf("DataX jobContainer %v save checkpoint %v fail. err: %v", c.jobID, c.checkpointPath, err)
+	}
+}
+
+// removeCheckpoint 工作成功后删除断点文件
+func (c *Container) removeCheckpoint() {
+	if c.checkpoint == nil {
+		return
+	}
+	if err := os.Remove(c.checkpointPath); err != nil && !os.IsNotExist(err) {
+		log.Errorf("DataX jobContainer %v remove checkpoint %v fail. err: %v", c.jobID, c.checkpointPath, err)
+	}
+}
+
+// totalRecordStats 获取所有任务组读取的总记录数totalRecord和脏数据个数errorRecord
+func (c *Container) totalRecordStats() (totalRecord, errorRecord int64) {
+	c.taskGroupStats.Range(func(key, value interface{}) bool {
+		stats := value.(recordStats)
+		totalRecord += stats.totalRecord
+		errorRecord += stats.errorRecord
+		return true
+	})
+	return
+}
+
+// checkErrorLimit 检查脏数据个数以及比例是否超过限制
+func (c *Container) checkErrorLimit() (err error) {
+	totalRecord, errorRecord := c.totalRecordStats()
+	if errorRecord > 0 {
+		log.Infof("DataX jobContainer %v total records: %v dirty records: %v",
+			c.jobID, totalRecord, errorRecord)
+	}
+	if err = c.errorLimit.CheckRecordLimit(errorRecord); err != nil {
 		return err
 	}
-	log.Infof("DataX jobContainer %v writer %v posted", c.jobID, c.writerPluginName)
+	return c.errorLimit.CheckPercentageLimit(totalRecord, errorRecord)
+}
+
+// post 逐个后置通知每张表的读取器和写入器工作
+func (c *Container) post() (err error) {
+	for _, job := range c.jobs {
+		if err = job.reader.Post(c.ctx); err != nil {
+			return err
+		}
+		log.Infof("DataX jobContainer %v reader %v%v posted", c.jobID, c.readerPluginName, job)
+		if err = job.writer.Post(c.ctx); err != nil {
+			return err
+		}
+		log.Infof("DataX jobContainer %v writer %v%v posted", c.jobID, c.writerPluginName, job)
+	}
 	return
 }
 
@@ -400,14 +688,14 @@ func (c *Container) distributeTaskIntoTaskGroup() (confs []*config.JSON, err err
 		return
 	}
 
+	//工作限速由所有通道共享的工作限速器控制，每个通道只使用单个通道限速
 	var speed *config.JSON
-	speed, err = c.Config().GetConfig(coreconst.DataxJobSettingSpeed)
+	speed, err = c.Config().GetConfig(coreconst.DataxCoreTransportChannelSpeed)
 	if err != nil {
 		speed, _ = config.NewJSONFromString("{}")
 		err = nil
 	}
 
-	speed.Remove("channel")
 	channelsPerTaskGroup := c.Config().GetInt64OrDefaullt(coreconst.DataxCoreContainerTaskgroupChannel, 5)
 	channelNumber := c.needChannelNumber
 	if channelNumber > int64(len(tasksConfigs)) {
@@ -435,49 +723,24 @@ func (c *Container) distributeTaskIntoTaskGroup() (confs []*config.JSON, err err
 }
 
 // adjustChannelNumber 自适应化通道数量
-// 依次根据字节流大小，记录数大小以及通道数大小生成通道数量
+// 配置了工作的字节数或者记录数限速时，根据工作限速和单个通道限速计算通道数量，取两者中较小的值，
+// 否则使用配置的通道数量
 func (c *Container) adjustChannelNumber() error {
-	var needChannelNumberByByte int64 = math.MaxInt32
-	var needChannelNumberByRecord int64 = math.MaxInt32
+	channelNumber := c.Config().GetInt64OrDefaullt(coreconst.DataxJobSettingSpeedChannel, 1)
+	isChannelLimit := channelNumber > 0
 
-	if isChannelLimit := c.Config().GetInt64OrDefaullt(coreconst.DataxJobSettingSpeedChannel, 1) > 0; isChannelLimit {
-		c.needChannelNumber = c.Config().GetInt64OrDefaullt(coreconst.DataxJobSettingSpeedChannel, 1)
-		log.Infof("DataX jobContainer %v set Channel-Number to %v channels.", c.jobID, c.needChannelNumber)
-		return nil
+	needChannelNumberByByte, err := c.channelNumberBySpeed(coreconst.DataxJobSettingSpeedByte,
+		coreconst.DataxCoreTransportChannelSpeedByte, isChannelLimit)
+	if err != nil {
+		return err
 	}
 
-	if isByteLimit := c.Config().GetInt64OrDefaullt(coreconst.DataxJobSettingSpeedByte, 0) > 0; isByteLimit {
-		globalLimitedByteSpeed := c.Config().GetInt64OrDefaullt(coreconst.DataxJobSettingSpeedByte, 10*1024*1024)
-		channelLimitedByteSpeed, err := c.Config().GetInt64(coreconst.DataxCoreTransportChannelSpeedByte)
-		if err != nil {
-			return err
-		}
-		if channelLimitedByteSpeed <= 0 {
-			return errors.Errorf("%v should be positive", coreconst.DataxCoreTransportChannelSpeedByte)
-		}
-		needChannelNumberByByte = globalLimitedByteSpeed / channelLimitedByteSpeed
-		if needChannelNumberByByte < 1 {
-			needChannelNumberByByte = 1
-		}
-		log.Infof("DataX jobContainer %v set Max-Byte-Speed to %v bytes", c.jobID, globalLimitedByteSpeed)
+	needChannelNumberByRecord, err := c.channelNumberBySpeed(coreconst.DataxJobSettingSpeedRecord,
+		coreconst.DataxCoreTransportChannelSpeedRecord, isChannelLimit)
+	if err != nil {
+		return err
 	}
 
-	if isRecordLimit := c.Config().GetInt64OrDefaullt(coreconst.DataxJobSettingSpeedRecord, 0) > 0; isRecordLimit {
-		globalLimitedRecordSpeed := c.Config().GetInt64OrDefaullt(coreconst.DataxJobSettingSpeedRecord, 10*1024*1024)
-		channelLimitedRecordSpeed, err := c.Config().GetInt64(coreconst.DataxCoreTransportChannelSpeedRecord)
-		if err != nil {
-			return err
-		}
-		if channelLimitedRecordSpeed <= 0 {
-			return errors.Errorf("%v should be positive", coreconst.DataxCoreTransportChannelSpeedByte)
-		}
-
-		needChannelNumberByRecord = globalLimitedRecordSpeed / channelLimitedRecordSpeed
-		if needChannelNumberByRecord < 1 {
-			needChannelNumberByRecord = 1
-		}
-		log.Infof("DataX jobContainer %v  set Max-Record-Speed to %v records", c.jobID, globalLimitedRecordSpeed)
-	}
 	if needChannelNumberByByte > needChannelNumberByRecord {
 		c.needChannelNumber = needChannelNumberByRecord
 	} else {
@@ -485,18 +748,53 @@ func (c *Container) adjustChannelNumber() error {
 	}
 
 	if c.needChannelNumber < math.MaxInt32 {
+		log.Infof("DataX jobContainer %v set Channel-Number to %v channels by speed.", c.jobID, c.needChannelNumber)
 		return nil
 	}
 
-	// if isChannelLimit := c.Config().GetInt64OrDefaullt(coreconst.DataxJobSettingSpeedChannel, 0) > 0; isChannelLimit {
-	// 	//此时 DataxJobSettingSpeedChannel必然存在
-	// 	c.needChannelNumber, _ = c.Config().GetInt64(coreconst.DataxJobSettingSpeedChannel)
-	// 	log.Infof("DataX jobContainer %v set Channel-Number to %v channels.", c.jobID, c.needChannelNumber)
-	// 	return nil
-	// }
+	if isChannelLimit {
+		c.needChannelNumber = channelNumber
+		log.Infof("DataX jobContainer %v set Channel-Number to %v channels.", c.jobID, c.needChannelNumber)
+		return nil
+	}
 	return errors.New("job speed should be setted")
 }
 
+// channelNumberBySpeed 根据工作限速路径globalPath和单个通道限速路径channelPath计算通道数量
+// 工作未限速时返回math.MaxInt32，单个通道限速未配置或者不为正数时，如果允许使用通道数量allowChannel，
+// 也返回math.MaxInt32，否则报错
+func (c *Container) channelNumberBySpeed(globalPath, channelPath string, allowChannel bool) (int64, error) {
+	globalLimitedSpeed := c.Config().GetInt64OrDefaullt(globalPath, 0)
+	if globalLimitedSpeed <= 0 {
+		return math.MaxInt32, nil
+	}
+	log.Infof("DataX jobContainer %v set %v to %v", c.jobID, globalPath, globalLimitedSpeed)
+
+	channelLimitedSpeed, err := c.Config().GetInt64(channelPath)
+	if err == nil && channelLimitedSpeed <= 0 {
+		err = errors.Errorf("%v should be positive", channelPath)
+	}
+	if err != nil {
+		if allowChannel {
+			return math.MaxInt32, nil
+		}
+		return 0, err
+	}
+
+	needChannelNumber := globalLimitedSpeed / channelLimitedSpeed
+	if needChannelNumber < 1 {
+		needChannelNumber = 1
+	}
+	return needChannelNumber, nil
+}
+
+// newLimiter 根据工作的字节数和记录数限速创建所有通道共享的工作限速器，未限速时返回nil
+func (c *Container) newLimiter() *channel.Limiter {
+	return channel.NewLimiter(
+		c.Config().GetFloat64OrDefaullt(coreconst.DataxJobSettingSpeedByte, 0),
+		c.Config().GetFloat64OrDefaullt(coreconst.DataxJobSettingSpeedRecord, 0))
+}
+
 // initReaderJob 初始化读取工作
 // 当读取插件名找不到读取工作或者初始化失败就会报错
 func (c *Container) initReaderJob(collector plugin.JobCollector, readerConfig, writerConfig *config.JSON) (job reader.Job, err error) {
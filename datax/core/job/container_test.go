@@ -24,6 +24,9 @@ import (
 	"github.com/Breeze0806/go-etl/config"
 	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
 	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/datax/common/spi/reader"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/storage/database"
 )
 
 func TestNewContainer(t *testing.T) {
@@ -1439,6 +1442,78 @@ func TestContainer_adjustChannelNumber(t *testing.T) {
 			}`)),
 			wantErr: true,
 		},
+		{
+			name: "10",
+			c: testContainer(testJSONFromString(`{
+				"core":{
+					"container": {
+						"job":{
+							"id": 1
+						}
+					}
+				},
+				"job":{
+					"setting":{
+						"speed":{
+							"byte":1000,
+							"record":100,
+							"channel":3
+						}
+					}
+				}
+			}`)),
+			wantErr:               false,
+			wantNeedChannelNumber: 3,
+		},
+		{
+			name: "11",
+			c: testContainer(testJSONFromString(`{
+				"core":{
+					"container": {
+						"job":{
+							"id": 1
+						}
+					},
+					"transport":{
+						"channel":{
+							"speed":{
+								"byte": 100
+							}
+						}
+					}
+				},
+				"job":{
+					"setting":{
+						"speed":{
+							"byte":1000,
+							"channel":2
+						}
+					}
+				}
+			}`)),
+			wantErr:               false,
+			wantNeedChannelNumber: 10,
+		},
+		{
+			name: "12",
+			c: testContainer(testJSONFromString(`{
+				"core":{
+					"container": {
+						"job":{
+							"id": 1
+						}
+					}
+				},
+				"job":{
+					"setting":{
+						"speed":{
+						}
+					}
+				}
+			}`)),
+			wantErr:               false,
+			wantNeedChannelNumber: 1,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -3075,7 +3150,8 @@ func TestContainer_distributeTaskIntoTaskGroup(t *testing.T) {
 						"taskGroup":{
 							"channel":2
 						}
-					}
+					},
+					"transport":{"channel":{"speed":{"byte":100,"record":100}}}
 				},
 				"job":{
 					"setting":{
@@ -3213,7 +3289,8 @@ func TestContainer_distributeTaskIntoTaskGroup(t *testing.T) {
 								"id": 0,
 								"channel":2
 							}
-						}
+						},
+						"transport":{"channel":{"speed":{"byte":100,"record":100}}}
 					},
 					"job":{
 						"setting":{
@@ -3285,7 +3362,8 @@ func TestContainer_distributeTaskIntoTaskGroup(t *testing.T) {
 								"id": 1,
 								"channel":2
 							}
-						}
+						},
+						"transport":{"channel":{"speed":{"byte":100,"record":100}}}
 					},
 					"job":{
 						"setting":{
@@ -3357,7 +3435,8 @@ func TestContainer_distributeTaskIntoTaskGroup(t *testing.T) {
 								"id": 2,
 								"channel":2
 							}
-						}
+						},
+						"transport":{"channel":{"speed":{"byte":100,"record":100}}}
 					},
 					"job":{
 						"setting":{
@@ -3429,7 +3508,8 @@ func TestContainer_distributeTaskIntoTaskGroup(t *testing.T) {
 								"id": 3,
 								"channel":2
 							}
-						}
+						},
+						"transport":{"channel":{"speed":{"byte":100,"record":100}}}
 					},
 					"job":{
 						"setting":{
@@ -3505,7 +3585,8 @@ func TestContainer_distributeTaskIntoTaskGroup(t *testing.T) {
 						"taskGroup":{
 							"channel":2
 						}
-					}
+					},
+					"transport":{"channel":{"speed":{"byte":100,"record":100}}}
 				},
 				"job":{
 					"setting":{
@@ -3642,7 +3723,7 @@ func TestContainer_Start(t *testing.T) {
 							"name": "mock",
 							"parameter": {}
 						},
-						"transformer": ["1", "2"]
+						"transformer": []
 					}]
 				}
 			}`)),
@@ -3686,7 +3767,7 @@ func TestContainer_Start(t *testing.T) {
 							"name": "mock",
 							"parameter": {}
 						},
-						"transformer": ["1", "2"]
+						"transformer": []
 					}]
 				}
 			}`)),
@@ -3731,7 +3812,7 @@ func TestContainer_Start(t *testing.T) {
 							"name": "mock",
 							"parameter": {}
 						},
-						"transformer": ["1", "2"]
+						"transformer": []
 					}]
 				}
 			}`)),
@@ -3776,7 +3857,7 @@ func TestContainer_Start(t *testing.T) {
 							"name": "mock",
 							"parameter": {}
 						},
-						"transformer": ["1", "2"]
+						"transformer": []
 					}]
 				}
 			}`)),
@@ -3820,7 +3901,7 @@ func TestContainer_Start(t *testing.T) {
 							"name": "mock",
 							"parameter": {}
 						},
-						"transformer": ["1", "2"]
+						"transformer": []
 					}]
 				}
 			}`)),
@@ -3864,7 +3945,7 @@ func TestContainer_Start(t *testing.T) {
 							"name": "mock",
 							"parameter": {}
 						},
-						"transformer": ["1", "2"]
+						"transformer": []
 					}]
 				}
 			}`)),
@@ -3908,7 +3989,7 @@ func TestContainer_Start(t *testing.T) {
 							"name": "mock",
 							"parameter": {}
 						},
-						"transformer": ["1", "2"]
+						"transformer": []
 					}]
 				}
 			}`)),
@@ -3954,7 +4035,7 @@ func TestContainer_Start(t *testing.T) {
 							"name": "mock",
 							"parameter": {}
 						},
-						"transformer": ["1", "2"]
+						"transformer": []
 					}]
 				}
 			}`)),
@@ -3969,3 +4050,282 @@ func TestContainer_Start(t *testing.T) {
 		})
 	}
 }
+
+func TestContainer_checkErrorLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		stats   []recordStats
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{
+				"core" : {
+					"container": {
+						"job":{
+							"id": 1
+						}
+					}
+				}
+			}`),
+			stats: []recordStats{
+				{totalRecord: 100, errorRecord: 100},
+			},
+		},
+		{
+			name: "2",
+			conf: testJSONFromString(`{
+				"core" : {
+					"container": {
+						"job":{
+							"id": 1
+						}
+					}
+				},
+				"job":{
+					"setting":{
+						"errorLimit":{
+							"record": 10
+						}
+					}
+				}
+			}`),
+			stats: []recordStats{
+				{totalRecord: 100, errorRecord: 5},
+				{totalRecord: 100, errorRecord: 5},
+			},
+		},
+		{
+			name: "3",
+			conf: testJSONFromString(`{
+				"core" : {
+					"container": {
+						"job":{
+							"id": 1
+						}
+					}
+				},
+				"job":{
+					"setting":{
+						"errorLimit":{
+							"record": 10
+						}
+					}
+				}
+			}`),
+			stats: []recordStats{
+				{totalRecord: 100, errorRecord: 5},
+				{totalRecord: 100, errorRecord: 6},
+			},
+			wantErr: true,
+		},
+		{
+			name: "4",
+			conf: testJSONFromString(`{
+				"core" : {
+					"container": {
+						"job":{
+							"id": 1
+						}
+					}
+				},
+				"job":{
+					"setting":{
+						"errorLimit":{
+							"percentage": 0.05
+						}
+					}
+				}
+			}`),
+			stats: []recordStats{
+				{totalRecord: 100, errorRecord: 5},
+				{totalRecord: 100, errorRecord: 6},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewContainer(context.TODO(), tt.conf)
+			if err != nil {
+				t.Fatalf("NewContainer() error = %v", err)
+			}
+			for i, v := range tt.stats {
+				c.taskGroupStats.Store(i, v)
+			}
+			if err := c.checkErrorLimit(); (err != nil) != tt.wantErr {
+				t.Errorf("checkErrorLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContainer_newLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       *Container
+		wantNil bool
+	}{
+		{
+			name: "1",
+			c: testContainer(testJSONFromString(`{
+				"core":{
+					"container": {
+						"job":{
+							"id": 1
+						}
+					}
+				},
+				"job":{
+					"setting":{
+						"speed":{
+							"channel":4
+						}
+					}
+				}
+			}`)),
+			wantNil: true,
+		},
+		{
+			name: "2",
+			c: testContainer(testJSONFromString(`{
+				"core":{
+					"container": {
+						"job":{
+							"id": 1
+						}
+					}
+				},
+				"job":{
+					"setting":{
+						"speed":{
+							"byte":1000,
+							"channel":4
+						}
+					}
+				}
+			}`)),
+		},
+		{
+			name: "3",
+			c: testContainer(testJSONFromString(`{
+				"core":{
+					"container": {
+						"job":{
+							"id": 1
+						}
+					}
+				},
+				"job":{
+					"setting":{
+						"speed":{
+							"record":1000
+						}
+					}
+				}
+			}`)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.newLimiter(); (got == nil) != tt.wantNil {
+				t.Errorf("Container.newLimiter() = %v, wantNil %v", got, tt.wantNil)
+			}
+		})
+	}
+}
+
+type mockTableProviderJob struct {
+	*mockReaderJob
+
+	columns []database.TableColumn
+	err     error
+}
+
+func (m *mockTableProviderJob) SourceColumns(ctx context.Context) ([]database.TableColumn, error) {
+	return m.columns, m.err
+}
+
+type mockTableCreatorJob struct {
+	*mockWriterJob
+
+	autoCreate bool
+	columns    []database.TableColumn
+	err        error
+}
+
+func (m *mockTableCreatorJob) AutoCreateTable() bool {
+	return m.autoCreate
+}
+
+func (m *mockTableCreatorJob) CreateTable(ctx context.Context, columns []database.TableColumn) error {
+	m.columns = columns
+	return m.err
+}
+
+func TestContainer_createTable(t *testing.T) {
+	noErrs := []error{nil, nil, nil, nil, nil}
+	columns := []database.TableColumn{
+		{Name: "id", Kind: database.ColumnKindBigInt},
+	}
+	tests := []struct {
+		name        string
+		reader      reader.Job
+		writer      writer.Job
+		wantErr     bool
+		wantColumns []database.TableColumn
+	}{
+		{
+			name:   "1",
+			reader: newMockReaderJob(noErrs, nil),
+			writer: newMockWriterJob(noErrs, nil),
+		},
+		{
+			name:   "2",
+			reader: newMockReaderJob(noErrs, nil),
+			writer: &mockTableCreatorJob{mockWriterJob: newMockWriterJob(noErrs, nil)},
+		},
+		{
+			name:    "3",
+			reader:  newMockReaderJob(noErrs, nil),
+			writer:  &mockTableCreatorJob{mockWriterJob: newMockWriterJob(noErrs, nil), autoCreate: true},
+			wantErr: true,
+		},
+		{
+			name: "4",
+			reader: &mockTableProviderJob{mockReaderJob: newMockReaderJob(noErrs, nil),
+				err: errors.New("mock error")},
+			writer:  &mockTableCreatorJob{mockWriterJob: newMockWriterJob(noErrs, nil), autoCreate: true},
+			wantErr: true,
+		},
+		{
+			name: "5",
+			reader: &mockTableProviderJob{mockReaderJob: newMockReaderJob(noErrs, nil),
+				columns: columns},
+			writer: &mockTableCreatorJob{mockWriterJob: newMockWriterJob(noErrs, nil), autoCreate: true,
+				err: errors.New("mock error")},
+			wantErr:     true,
+			wantColumns: columns,
+		},
+		{
+			name: "6",
+			reader: &mockTableProviderJob{mockReaderJob: newMockReaderJob(noErrs, nil),
+				columns: columns},
+			writer:      &mockTableCreatorJob{mockWriterJob: newMockWriterJob(noErrs, nil), autoCreate: true},
+			wantColumns: columns,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testContainer(testJSONFromString(`{"core":{"container":{"job":{"id":1}}}}`))
+			job := &tableJob{reader: tt.reader, writer: tt.writer}
+			if err := c.createTable(job); (err != nil) != tt.wantErr {
+				t.Errorf("Container.createTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if creator, ok := tt.writer.(*mockTableCreatorJob); ok && !reflect.DeepEqual(creator.columns, tt.wantColumns) {
+				t.Errorf("Container.createTable() columns = %v, want %v", creator.columns, tt.wantColumns)
+			}
+		})
+	}
+}
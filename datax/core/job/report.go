@@ -0,0 +1,192 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/core/taskgroup"
+)
+
+// 工作状态
+const (
+	ReportStatusSuccess = "success" //成功
+	ReportStatusFailure = "failure" //失败
+)
+
+// Report 工作结束时的统计报告
+type Report struct {
+	JobID          int64        `json:"jobID"`           //工作编号
+	Status         string       `json:"status"`          //工作状态，success或者failure
+	Error          string       `json:"error,omitempty"` //失败原因
+	StartTime      time.Time    `json:"startTime"`       //开始时间
+	EndTime        time.Time    `json:"endTime"`         //结束时间
+	ElapsedSeconds float64      `json:"elapsedSeconds"`  //耗时，单位秒
+	TotalRecord    int64        `json:"totalRecord"`     //读取的总记录数
+	TotalByte      int64        `json:"totalByte"`       //读取的总字节数
+	WrittenRecord  int64        `json:"writtenRecord"`   //交给写入器的记录数
+	FilteredRecord int64        `json:"filteredRecord"`  //被转化器过滤的记录数
+	ErrorRecord    int64        `json:"errorRecord"`     //脏数据个数
+	RecordSpeed    float64      `json:"recordSpeed"`     //平均每秒读取的记录数
+	ByteSpeed      float64      `json:"byteSpeed"`       //平均每秒读取的字节数
+	Tasks          []ReportTask `json:"tasks"`           //各个任务的统计信息
+}
+
+// ReportTask 任务的统计信息
+type ReportTask struct {
+	TaskGroupID    int64           `json:"taskGroupID"`     //任务组编号
+	TaskID         int64           `json:"taskID"`          //任务编号
+	Table          string          `json:"table,omitempty"` //多表同步时任务对应的表名
+	Finished       bool            `json:"finished"`        //是否成功完成
+	TotalRecord    int64           `json:"totalRecord"`     //读取的总记录数
+	TotalByte      int64           `json:"totalByte"`       //读取的总字节数
+	WrittenRecord  int64           `json:"writtenRecord"`   //交给写入器的记录数
+	FilteredRecord int64           `json:"filteredRecord"`  //被转化器过滤的记录数
+	ErrorRecord    int64           `json:"errorRecord"`     //脏数据个数
+	RetryCount     int64           `json:"retryCount"`      //重试次数
+	Range          json.RawMessage `json:"range,omitempty"` //切分范围
+}
+
+// newReport 通过工作编号jobID，开始时间start，结束时间end以及工作错误err生成统计报告
+func newReport(jobID int64, start, end time.Time, err error) *Report {
+	r := &Report{
+		JobID:          jobID,
+		Status:         ReportStatusSuccess,
+		StartTime:      start,
+		EndTime:        end,
+		ElapsedSeconds: end.Sub(start).Seconds(),
+		Tasks:          []ReportTask{},
+	}
+	if err != nil {
+		r.Status = ReportStatusFailure
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// addTaskGroup 添加任务组编号为taskGroupID的统计信息stats，其中成功完成的任务编号为finished，
+// 各个任务编号对应的切分范围为ranges，多表同步时各个任务编号对应的表名为tables
+func (r *Report) addTaskGroup(taskGroupID int64, stats []taskgroup.Stats,
+	finished []int64, ranges map[int64]json.RawMessage, tables map[int64]string) {
+	done := make(map[int64]bool)
+	for _, v := range finished {
+		done[v] = true
+	}
+	for _, v := range stats {
+		r.Tasks = append(r.Tasks, ReportTask{
+			TaskGroupID:    taskGroupID,
+			TaskID:         v.TaskID,
+			Table:          tables[v.TaskID],
+			Finished:       done[v.TaskID],
+			TotalRecord:    v.Channel.TotalRecord,
+			TotalByte:      v.Channel.TotalByte,
+			WrittenRecord:  v.Exchange.WrittenRecord,
+			FilteredRecord: v.Exchange.FilteredRecord,
+			ErrorRecord:    v.ErrorRecord,
+			RetryCount:     v.RetryCount,
+			Range:          ranges[v.TaskID],
+		})
+		r.TotalRecord += v.Channel.TotalRecord
+		r.TotalByte += v.Channel.TotalByte
+		r.WrittenRecord += v.Exchange.WrittenRecord
+		r.FilteredRecord += v.Exchange.FilteredRecord
+		r.ErrorRecord += v.ErrorRecord
+	}
+	sort.Slice(r.Tasks, func(i, j int) bool {
+		return r.Tasks[i].TaskID < r.Tasks[j].TaskID
+	})
+	if r.ElapsedSeconds > 0 {
+		r.RecordSpeed = float64(r.TotalRecord) / r.ElapsedSeconds
+		r.ByteSpeed = float64(r.TotalByte) / r.ElapsedSeconds
+	}
+}
+
+// String 打印统计报告
+func (r *Report) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "DataX job %v report\n", r.JobID)
+	w := tabwriter.NewWriter(b, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "status\t: %v\n", r.Status)
+	if r.Error != "" {
+		fmt.Fprintf(w, "error\t: %v\n", r.Error)
+	}
+	fmt.Fprintf(w, "start time\t: %v\n", r.StartTime.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "end time\t: %v\n", r.EndTime.Format("2006-01-02 15:04:05"))
+	fmt.Fprintf(w, "elapsed time\t: %.3fs\n", r.ElapsedSeconds)
+	fmt.Fprintf(w, "total records\t: %v\n", r.TotalRecord)
+	fmt.Fprintf(w, "total bytes\t: %v\n", r.TotalByte)
+	fmt.Fprintf(w, "written records\t: %v\n", r.WrittenRecord)
+	fmt.Fprintf(w, "filtered records\t: %v\n", r.FilteredRecord)
+	fmt.Fprintf(w, "error records\t: %v\n", r.ErrorRecord)
+	fmt.Fprintf(w, "record speed\t: %.2f records/s\n", r.RecordSpeed)
+	fmt.Fprintf(w, "byte speed\t: %.2f bytes/s\n", r.ByteSpeed)
+	w.Flush()
+	if len(r.Tasks) == 0 {
+		return b.String()
+	}
+	//多表同步时在任务编号后打印表名
+	withTable := false
+	for _, t := range r.Tasks {
+		if t.Table != "" {
+			withTable = true
+			break
+		}
+	}
+	w = tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+	if withTable {
+		fmt.Fprint(w, "taskGroup\ttask\ttable\t")
+	} else {
+		fmt.Fprint(w, "taskGroup\ttask\t")
+	}
+	fmt.Fprintln(w, "finished\trecords\tbytes\twritten\tfiltered\terrors\tretries\trange")
+	for _, t := range r.Tasks {
+		splitRange := "-"
+		if len(t.Range) != 0 {
+			splitRange = string(t.Range)
+		}
+		fmt.Fprintf(w, "%v\t%v\t", t.TaskGroupID, t.TaskID)
+		if withTable {
+			fmt.Fprintf(w, "%v\t", t.Table)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n", t.Finished,
+			t.TotalRecord, t.TotalByte, t.WrittenRecord, t.FilteredRecord, t.ErrorRecord, t.RetryCount, splitRange)
+	}
+	w.Flush()
+	return b.String()
+}
+
+// save 将统计报告以JSON格式保存到文件filename中
+func (r *Report) save(filename string) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, data, 0644)
+}
+
+// taskRange 任务配置conf中读取任务的切分范围，不存在或者为null时为nil
+func taskRange(conf *config.JSON) json.RawMessage {
+	if r, err := conf.GetConfig(jobReaderParameterSplitRange); err == nil && r.String() != "null" {
+		return json.RawMessage(r.String())
+	}
+	return nil
+}
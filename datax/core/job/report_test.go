@@ -0,0 +1,348 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/datax/core/taskgroup"
+	"github.com/Breeze0806/go-etl/datax/core/transport/channel"
+	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/pingcap/errors"
+)
+
+func testReportStats(taskID, record int64) taskgroup.Stats {
+	return taskgroup.Stats{
+		TaskID: taskID,
+		Channel: channel.StatsJSON{
+			TotalRecord: record,
+			TotalByte:   record * 10,
+		},
+		Exchange: exchange.StatsJSON{
+			WrittenRecord:  record - 1,
+			FilteredRecord: 1,
+		},
+		ErrorRecord: taskID,
+		RetryCount:  taskID + 1,
+	}
+}
+
+func TestReport_addTaskGroup(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)
+	end := start.Add(2 * time.Second)
+	ranges := map[int64]json.RawMessage{
+		1: json.RawMessage(`{"left":1}`),
+	}
+	tables := map[int64]string{
+		0: "db.a",
+		1: "db.b",
+	}
+	tests := []struct {
+		name string
+		err  error
+		want *Report
+	}{
+		{
+			name: "1",
+			want: &Report{
+				JobID:          1,
+				Status:         ReportStatusSuccess,
+				StartTime:      start,
+				EndTime:        end,
+				ElapsedSeconds: 2,
+				TotalRecord:    30,
+				TotalByte:      300,
+				WrittenRecord:  28,
+				FilteredRecord: 2,
+				ErrorRecord:    1,
+				RecordSpeed:    15,
+				ByteSpeed:      150,
+				Tasks: []ReportTask{
+					{
+						TaskGroupID:    1,
+						TaskID:         0,
+						Table:          "db.a",
+						TotalRecord:    20,
+						TotalByte:      200,
+						WrittenRecord:  19,
+						FilteredRecord: 1,
+						ErrorRecord:    0,
+						RetryCount:     1,
+					},
+					{
+						TaskGroupID:    0,
+						TaskID:         1,
+						Table:          "db.b",
+						Finished:       true,
+						TotalRecord:    10,
+						TotalByte:      100,
+						WrittenRecord:  9,
+						FilteredRecord: 1,
+						ErrorRecord:    1,
+						RetryCount:     2,
+						Range:          json.RawMessage(`{"left":1}`),
+					},
+				},
+			},
+		},
+		{
+			name: "2",
+			err:  errors.New("mock error"),
+			want: &Report{
+				JobID:          1,
+				Status:         ReportStatusFailure,
+				Error:          "mock error",
+				StartTime:      start,
+				EndTime:        end,
+				ElapsedSeconds: 2,
+				TotalRecord:    30,
+				TotalByte:      300,
+				WrittenRecord:  28,
+				FilteredRecord: 2,
+				ErrorRecord:    1,
+				RecordSpeed:    15,
+				ByteSpeed:      150,
+				Tasks: []ReportTask{
+					{
+						TaskGroupID:    1,
+						TaskID:         0,
+						Table:          "db.a",
+						TotalRecord:    20,
+						TotalByte:      200,
+						WrittenRecord:  19,
+						FilteredRecord: 1,
+						ErrorRecord:    0,
+						RetryCount:     1,
+					},
+					{
+						TaskGroupID:    0,
+						TaskID:         1,
+						Table:          "db.b",
+						Finished:       true,
+						TotalRecord:    10,
+						TotalByte:      100,
+						WrittenRecord:  9,
+						FilteredRecord: 1,
+						ErrorRecord:    1,
+						RetryCount:     2,
+						Range:          json.RawMessage(`{"left":1}`),
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newReport(1, start, end, tt.err)
+			r.addTaskGroup(0, []taskgroup.Stats{testReportStats(1, 10)}, []int64{1}, ranges, tables)
+			r.addTaskGroup(1, []taskgroup.Stats{testReportStats(0, 20)}, nil, ranges, tables)
+			if !reflect.DeepEqual(r, tt.want) {
+				t.Errorf("addTaskGroup() = %+v, want %+v", r, tt.want)
+			}
+		})
+	}
+}
+
+func TestReport_String(t *testing.T) {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.Local)
+	r := newReport(1, start, start.Add(time.Second), errors.New("mock error"))
+	got := r.String()
+	for _, want := range []string{"status", "failure", "mock error", "2022-01-01 00:00:00", "elapsed time"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %v, want contains %v", got, want)
+		}
+	}
+	if strings.Contains(got, "taskGroup") {
+		t.Errorf("String() = %v, want no tasks", got)
+	}
+
+	r.addTaskGroup(0, []taskgroup.Stats{testReportStats(1, 10)}, []int64{1},
+		map[int64]json.RawMessage{1: json.RawMessage(`{"left":1}`)}, nil)
+	r.addTaskGroup(1, []taskgroup.Stats{testReportStats(2, 10)}, nil, nil, nil)
+	got = r.String()
+	for _, want := range []string{"taskGroup", "range", `{"left":1}`, "-"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %v, want contains %v", got, want)
+		}
+	}
+	if strings.Contains(got, "table") {
+		t.Errorf("String() = %v, want no table", got)
+	}
+
+	r.addTaskGroup(2, []taskgroup.Stats{testReportStats(3, 10)}, nil, nil, map[int64]string{3: "db.orders"})
+	got = r.String()
+	for _, want := range []string{"table", "db.orders"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("String() = %v, want contains %v", got, want)
+		}
+	}
+}
+
+func TestReport_save(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	want := newReport(1, start, start.Add(time.Second), nil)
+	want.addTaskGroup(0, []taskgroup.Stats{testReportStats(1, 10)}, []int64{1},
+		map[int64]json.RawMessage{1: json.RawMessage(`{"left":1}`)}, map[int64]string{1: "db.a"})
+
+	filename := filepath.Join(tmpDir, "report.json")
+	if err = want.save(filename); err != nil {
+		t.Fatalf("save() error = %v", err)
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &Report{}
+	if err = json.Unmarshal(data, got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("save() = %+v, want %+v", got, want)
+	}
+
+	if err = want.save(filepath.Join(tmpDir, "none", "report.json")); err == nil {
+		t.Errorf("save() error = nil")
+	}
+}
+
+func TestContainer_Report(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filename := filepath.Join(tmpDir, "report.json")
+
+	errs := []error{nil, nil, nil, nil, nil}
+	conf := testJSONFromString(`{
+		"core": {
+			"container": {
+				"job": {
+					"id": 1
+				},
+				"taskGroup": {
+					"channel": 1
+				}
+			}
+		},
+		"job": {
+			"setting": {
+				"speed": {
+					"channel": 3
+				}
+			},
+			"content": [{
+				"reader": {
+					"name": "mock",
+					"parameter": {}
+				},
+				"writer": {
+					"name": "mock",
+					"parameter": {}
+				},
+				"transformer": []
+			}]
+		}
+	}`)
+	conf.Set("job.setting.report.path", filename)
+
+	resetLoader()
+	loader.RegisterReader("mock", &mockCheckpointReader{
+		mockReader: newMockReader(errs, []*config.JSON{
+			testJSONFromString(`{"id":1,"split":{"range":{"left":1}}}`),
+			testJSONFromString(`{"id":2,"fail":true}`),
+			testJSONFromString(`{"id":3}`),
+		}),
+	})
+	loader.RegisterWriter("mock", newMockWriter(errs, []*config.JSON{
+		testJSONFromString(`{}`),
+		testJSONFromString(`{}`),
+		testJSONFromString(`{}`),
+	}))
+	c := testContainer(conf)
+	if err = c.Start(); err == nil {
+		t.Fatalf("Start() error = nil")
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("report is not saved. err: %v", err)
+	}
+	got := &Report{}
+	if err = json.Unmarshal(data, got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if got.Status != ReportStatusFailure || got.Error == "" {
+		t.Errorf("Status = %v Error = %v", got.Status, got.Error)
+	}
+	if got.EndTime.Before(got.StartTime) {
+		t.Errorf("StartTime = %v EndTime = %v", got.StartTime, got.EndTime)
+	}
+	var finished []bool
+	for _, v := range got.Tasks {
+		finished = append(finished, v.Finished)
+	}
+	if !reflect.DeepEqual(finished, []bool{true, false, true}) {
+		t.Errorf("Tasks finished = %v, want %v", finished, []bool{true, false, true})
+	}
+	if string(got.Tasks[0].Range) != `{"left":1}` || got.Tasks[1].Range != nil {
+		t.Errorf("Tasks range = %s %s", got.Tasks[0].Range, got.Tasks[1].Range)
+	}
+	if c.Report() == nil || c.Report().Status != got.Status {
+		t.Errorf("Report() = %v", c.Report())
+	}
+}
+
+func Test_taskRange(t *testing.T) {
+	tests := []struct {
+		name string
+		conf *config.JSON
+		want json.RawMessage
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{"reader":{"parameter":{"split":{"range":{"type":"bigInt","left":"1","right":"10"}}}}}`),
+			want: json.RawMessage(`{"type":"bigInt","left":"1","right":"10"}`),
+		},
+		{
+			name: "2",
+			conf: testJSONFromString(`{"reader":{"parameter":{"split":{"range":null}}}}`),
+		},
+		{
+			name: "3",
+			conf: testJSONFromString(`{"reader":{"parameter":{}}}`),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := taskRange(tt.conf); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("taskRange() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
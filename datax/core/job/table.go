@@ -0,0 +1,78 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/spi/reader"
+	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+)
+
+// tableJob 单表工作，由同一张表的读取工作和写入工作组成
+type tableJob struct {
+	table  string     //表名，单表同步时为空
+	reader reader.Job //读取工作
+	writer writer.Job //写入工作
+}
+
+// String 用于日志中打印表名，单表同步时为空
+func (t *tableJob) String() string {
+	if t.table == "" {
+		return ""
+	}
+	return " table " + t.table
+}
+
+// expandTables 读取工作配置了多张表时，获取展开后每张表的读取配置，并销毁用于展开的读取工作，
+// 单表同步时为空
+func (c *Container) expandTables(job reader.Job) (tables []plugin.ExpandedTable, err error) {
+	expander, ok := job.(plugin.TableExpander)
+	if !ok {
+		return nil, nil
+	}
+	if tables, err = expander.ExpandTables(c.ctx); err != nil || len(tables) == 0 {
+		return
+	}
+	log.Infof("DataX jobContainer %v reader %v expands %v tables", c.jobID, c.readerPluginName, len(tables))
+	c.jobs = nil
+	if derr := job.Destroy(c.ctx); derr != nil {
+		log.Errorf("DataX jobContainer %v jobReader %s destroy error: %v",
+			c.jobID, c.readerPluginName, derr)
+	}
+	return
+}
+
+// initTableJobs 多表同步时通过每张表的读取配置和写入配置writerConfig初始化每张表的读取工作和写入工作
+func (c *Container) initTableJobs(collector plugin.JobCollector,
+	tables []plugin.ExpandedTable, writerConfig *config.JSON) (err error) {
+	for _, t := range tables {
+		job := &tableJob{
+			table: t.Name,
+		}
+		c.jobs = append(c.jobs, job)
+		readerConfig := t.Conf
+		tableWriterConfig := writerConfig.CloneConfig()
+		if job.reader, err = c.initReaderJob(collector, readerConfig, tableWriterConfig); err != nil {
+			return
+		}
+		log.Infof("DataX jobContainer %v reader %v%v inited", c.jobID, c.readerPluginName, job)
+		if job.writer, err = c.initWriterJob(collector, readerConfig, tableWriterConfig); err != nil {
+			return
+		}
+		log.Infof("DataX jobContainer %v writer %v%v inited", c.jobID, c.writerPluginName, job)
+	}
+	return
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/datax/common/spi/reader"
+)
+
+type mockExpandReaderJob struct {
+	*mockReaderJob
+
+	tables []string
+	err    error
+}
+
+func (m *mockExpandReaderJob) ExpandTables(ctx context.Context) (tables []plugin.ExpandedTable, err error) {
+	for _, v := range m.tables {
+		tables = append(tables, plugin.ExpandedTable{
+			Name: v,
+			Conf: testJSONFromString(fmt.Sprintf(`{"table":%q}`, v)),
+		})
+	}
+	return tables, m.err
+}
+
+type mockExpandReader struct {
+	*mockReader
+
+	tables []string
+	err    error
+}
+
+func (m *mockExpandReader) Job() reader.Job {
+	return &mockExpandReaderJob{
+		mockReaderJob: newMockReaderJob(m.errs, m.confs),
+		tables:        m.tables,
+		err:           m.err,
+	}
+}
+
+func testTableContainer(readerName, writerName string) *Container {
+	return testContainer(testJSONFromString(fmt.Sprintf(`{
+		"core": {
+			"container": {
+				"job": {
+					"id": 1
+				}
+			}
+		},
+		"job": {
+			"content": [{
+				"reader": {
+					"name": %q,
+					"parameter": {}
+				},
+				"writer": {
+					"name": %q,
+					"parameter": {"table":"*"}
+				},
+				"transformer": []
+			}]
+		}
+	}`, readerName, writerName)))
+}
+
+func registerTableMock() {
+	resetLoader()
+	noErrs := []error{nil, nil, nil, nil, nil}
+	confs := []*config.JSON{
+		testJSONFromString(`{"id":1}`),
+		testJSONFromString(`{"id":2}`),
+	}
+	loader.RegisterReader("mock", &mockExpandReader{
+		mockReader: newMockReader(noErrs, confs),
+	})
+	loader.RegisterReader("mockTables", &mockExpandReader{
+		mockReader: newMockReader(noErrs, confs),
+		tables:     []string{"db.a", "db.b"},
+	})
+	loader.RegisterReader("mockExpandErr", &mockExpandReader{
+		mockReader: newMockReader(noErrs, confs),
+		err:        errors.New("mock error"),
+	})
+	loader.RegisterWriter("mock", newMockWriter(noErrs, confs))
+	loader.RegisterWriter("mockErr", newMockWriter([]error{errors.New("mock error"), nil, nil, nil, nil}, confs))
+}
+
+func TestContainer_initTableJobs(t *testing.T) {
+	registerTableMock()
+	tests := []struct {
+		name       string
+		c          *Container
+		wantTables []string
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			c:          testTableContainer("mock", "mock"),
+			wantTables: []string{""},
+		},
+		{
+			name:       "2",
+			c:          testTableContainer("mockTables", "mock"),
+			wantTables: []string{"db.a", "db.b"},
+		},
+		{
+			name:    "3",
+			c:       testTableContainer("mockExpandErr", "mock"),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			c:       testTableContainer("mockTables", "mockErr"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.c.destroy()
+			err := tt.c.init()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Container.init() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			var tables []string
+			for _, job := range tt.c.jobs {
+				tables = append(tables, job.table)
+				if job.table == "" {
+					continue
+				}
+				//每张表的读取配置同时作为写入工作的对应工作配置
+				if got := job.reader.PluginJobConf().GetStringOrDefaullt("table", ""); got != job.table {
+					t.Errorf("reader table = %v, want %v", got, job.table)
+				}
+				if got := job.writer.PeerPluginJobConf().GetStringOrDefaullt("table", ""); got != job.table {
+					t.Errorf("writer peer table = %v, want %v", got, job.table)
+				}
+				if got := job.writer.PluginJobConf().GetStringOrDefaullt("table", ""); got != "*" {
+					t.Errorf("writer table = %v, want *", got)
+				}
+			}
+			if !reflect.DeepEqual(tables, tt.wantTables) {
+				t.Errorf("Container.init() tables = %v, want %v", tables, tt.wantTables)
+			}
+		})
+	}
+}
+
+func TestContainer_splitTables(t *testing.T) {
+	registerTableMock()
+	tests := []struct {
+		name           string
+		c              *Container
+		wantTaskIDs    []int64
+		wantTaskTables map[int64]string
+	}{
+		{
+			name:           "1",
+			c:              testTableContainer("mock", "mock"),
+			wantTaskIDs:    []int64{0, 1},
+			wantTaskTables: map[int64]string{},
+		},
+		{
+			name:        "2",
+			c:           testTableContainer("mockTables", "mock"),
+			wantTaskIDs: []int64{0, 1, 2, 3},
+			wantTaskTables: map[int64]string{
+				0: "db.a",
+				1: "db.a",
+				2: "db.b",
+				3: "db.b",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.c.destroy()
+			if err := tt.c.init(); err != nil {
+				t.Fatalf("Container.init() error = %v", err)
+			}
+			if err := tt.c.split(); err != nil {
+				t.Fatalf("Container.split() error = %v", err)
+			}
+			confs, err := tt.c.Config().GetConfigArray(coreconst.DataxJobContent)
+			if err != nil {
+				t.Fatalf("GetConfigArray() error = %v", err)
+			}
+			var taskIDs []int64
+			for _, conf := range confs {
+				taskIDs = append(taskIDs, conf.GetInt64OrDefaullt(coreconst.TaskID, -1))
+			}
+			if !reflect.DeepEqual(taskIDs, tt.wantTaskIDs) {
+				t.Errorf("Container.split() taskIDs = %v, want %v", taskIDs, tt.wantTaskIDs)
+			}
+			if !reflect.DeepEqual(tt.c.taskTables, tt.wantTaskTables) {
+				t.Errorf("Container.split() taskTables = %v, want %v", tt.c.taskTables, tt.wantTaskTables)
+			}
+			if tt.c.totalStage != len(tt.wantTaskIDs) {
+				t.Errorf("Container.split() totalStage = %v, want %v", tt.c.totalStage, len(tt.wantTaskIDs))
+			}
+		})
+	}
+}
+
+func TestContainer_preCheckTables(t *testing.T) {
+	registerTableMock()
+	c := testTableContainer("mockTables", "mock")
+	defer c.destroy()
+	if err := c.preCheck(); err != nil {
+		t.Fatalf("Container.preCheck() error = %v", err)
+	}
+	var items []string
+	for _, v := range c.CheckReport().Items {
+		items = append(items, v.Name)
+	}
+	want := []string{"init", "reader mockTables table db.a", "writer mock table db.a",
+		"reader mockTables table db.b", "writer mock table db.b"}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("Container.preCheck() items = %v, want %v", items, want)
+	}
+}
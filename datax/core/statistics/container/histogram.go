@@ -0,0 +1,78 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets 默认的耗时直方图桶上界，单位秒，与Prometheus的默认桶相同
+var DefaultLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Histogram 耗时直方图，用于统计批量写入等操作的耗时分布
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []float64 //桶上界，单位秒
+	counts  []int64   //落在各个桶中的个数，最后一个为超过所有上界的个数
+	count   int64
+	sum     float64
+}
+
+// HistogramJSON 直方图的JSON统计信息，Counts为小于等于对应桶上界的累计个数
+type HistogramJSON struct {
+	Buckets []float64 `json:"buckets"`
+	Counts  []int64   `json:"counts"`
+	Count   int64     `json:"count"`
+	Sum     float64   `json:"sum"`
+}
+
+// NewHistogram 通过升序的桶上界buckets创建耗时直方图
+func NewHistogram(buckets []float64) *Histogram {
+	return &Histogram{
+		buckets: buckets,
+		counts:  make([]int64, len(buckets)+1),
+	}
+}
+
+// Observe 统计一次耗时d
+func (h *Histogram) Observe(d time.Duration) {
+	v := d.Seconds()
+	i := sort.SearchFloat64s(h.buckets, v)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[i]++
+	h.count++
+	h.sum += v
+}
+
+// JSON 获取直方图的JSON统计信息
+func (h *Histogram) JSON() HistogramJSON {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	j := HistogramJSON{
+		Buckets: append([]float64(nil), h.buckets...),
+		Counts:  make([]int64, len(h.buckets)),
+		Count:   h.count,
+		Sum:     h.sum,
+	}
+	var cumulative int64
+	for i := range h.buckets {
+		cumulative += h.counts[i]
+		j.Counts[i] = cumulative
+	}
+	return j
+}
@@ -0,0 +1,71 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		name      string
+		buckets   []float64
+		latencies []time.Duration
+		want      HistogramJSON
+	}{
+		{
+			name:    "1",
+			buckets: []float64{0.1, 1},
+			want: HistogramJSON{
+				Buckets: []float64{0.1, 1},
+				Counts:  []int64{0, 0},
+			},
+		},
+		{
+			name:    "2",
+			buckets: []float64{0.1, 1},
+			latencies: []time.Duration{
+				50 * time.Millisecond,
+				100 * time.Millisecond,
+				500 * time.Millisecond,
+				2 * time.Second,
+			},
+			want: HistogramJSON{
+				Buckets: []float64{0.1, 1},
+				Counts:  []int64{2, 3},
+				Count:   4,
+				Sum:     2.65,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHistogram(tt.buckets)
+			for _, l := range tt.latencies {
+				h.Observe(l)
+			}
+			got := h.JSON()
+			if got.Sum-tt.want.Sum > 1e-9 || tt.want.Sum-got.Sum > 1e-9 {
+				t.Errorf("Histogram.JSON().Sum = %v, want %v", got.Sum, tt.want.Sum)
+			}
+			got.Sum = tt.want.Sum
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Histogram.JSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/core/statistics/container"
+	"github.com/Breeze0806/go-etl/element"
+	"go.uber.org/atomic"
+)
+
+// DefaultTaskCollector 默认任务收集器，统计脏数据的个数，
+// 并且在日志中记录前maxDirtyNumber条脏数据，设置脏数据写入器后脏数据还会写入文件，
+// 另外还会统计批量写入耗时和重试次数
+type DefaultTaskCollector struct {
+	key            string
+	maxDirtyNumber int64
+	errorRecord    *atomic.Int64
+	dirtyWriter    *DirtyRecordWriter
+	latency        *container.Histogram
+	retry          *atomic.Int64
+
+	mu       sync.RWMutex
+	messages map[string]string
+}
+
+// NewDefaultTaskCollector 通过任务关键字key和日志中记录的最大脏数据条数maxDirtyNumber
+// 创建默认任务收集器
+func NewDefaultTaskCollector(key string, maxDirtyNumber int64) *DefaultTaskCollector {
+	return &DefaultTaskCollector{
+		key:            key,
+		maxDirtyNumber: maxDirtyNumber,
+		errorRecord:    atomic.NewInt64(0),
+		latency:        container.NewHistogram(container.DefaultLatencyBuckets),
+		retry:          atomic.NewInt64(0),
+		messages:       make(map[string]string),
+	}
+}
+
+// SetDirtyRecordWriter 设置脏数据写入器w
+func (d *DefaultTaskCollector) SetDirtyRecordWriter(w *DirtyRecordWriter) {
+	d.dirtyWriter = w
+}
+
+// CollectDirtyRecordWithError 收集脏数据record以及错误err
+func (d *DefaultTaskCollector) CollectDirtyRecordWithError(record element.Record, err error) {
+	d.CollectDirtyRecord(record, err, "")
+}
+
+// CollectDirtyRecordWithMsg 收集脏数据record以及错误信息msgErr
+func (d *DefaultTaskCollector) CollectDirtyRecordWithMsg(record element.Record, msgErr string) {
+	d.CollectDirtyRecord(record, nil, msgErr)
+}
+
+// CollectDirtyRecord 收集脏数据record以及错误err和错误信息msgErr
+func (d *DefaultTaskCollector) CollectDirtyRecord(record element.Record, err error, msgErr string) {
+	n := d.errorRecord.Inc()
+	if n <= d.maxDirtyNumber {
+		log.Errorf("task(%v) dirty record: %v err: %v msg: %v", d.key, record, err, msgErr)
+	}
+	if d.dirtyWriter == nil {
+		return
+	}
+	errMsg := msgErr
+	if err != nil {
+		if errMsg != "" {
+			errMsg += ": "
+		}
+		errMsg += err.Error()
+	}
+	if werr := d.dirtyWriter.Write(record, errMsg); werr != nil {
+		log.Errorf("task(%v) write dirty record fail. err: %v", d.key, werr)
+	}
+}
+
+// CollectMessage 收集关键字为key，值为value的消息
+func (d *DefaultTaskCollector) CollectMessage(key string, value string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.messages[key] = value
+}
+
+// CollectBatchWriteLatency 收集一次批量写入的耗时latency
+func (d *DefaultTaskCollector) CollectBatchWriteLatency(latency time.Duration) {
+	d.latency.Observe(latency)
+}
+
+// CollectRetry 收集n次重试
+func (d *DefaultTaskCollector) CollectRetry(n int) {
+	d.retry.Add(int64(n))
+}
+
+// Message 获取关键字为key的消息
+func (d *DefaultTaskCollector) Message(key string) (value string, ok bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	value, ok = d.messages[key]
+	return
+}
+
+// Close 关闭脏数据写入器
+func (d *DefaultTaskCollector) Close() error {
+	if d.dirtyWriter == nil {
+		return nil
+	}
+	return d.dirtyWriter.Close()
+}
+
+// ErrorRecord 脏数据个数
+func (d *DefaultTaskCollector) ErrorRecord() int64 {
+	return d.errorRecord.Load()
+}
+
+// BatchWriteLatency 批量写入耗时直方图
+func (d *DefaultTaskCollector) BatchWriteLatency() container.HistogramJSON {
+	return d.latency.JSON()
+}
+
+// RetryCount 重试次数
+func (d *DefaultTaskCollector) RetryCount() int64 {
+	return d.retry.Load()
+}
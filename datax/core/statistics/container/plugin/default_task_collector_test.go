@@ -0,0 +1,79 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestDefaultTaskCollector_CollectDirtyRecord(t *testing.T) {
+	d := NewDefaultTaskCollector("1-1-1", 1)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.CollectDirtyRecordWithError(element.NewDefaultRecord(), errors.New("mock error"))
+			d.CollectDirtyRecordWithMsg(element.NewDefaultRecord(), "mock msg")
+			d.CollectDirtyRecord(element.NewDefaultRecord(), errors.New("mock error"), "mock msg")
+		}()
+	}
+	wg.Wait()
+	if got := d.ErrorRecord(); got != 30 {
+		t.Errorf("DefaultTaskCollector.ErrorRecord() = %v, want %v", got, 30)
+	}
+}
+
+func TestDefaultTaskCollector_CollectMessage(t *testing.T) {
+	d := NewDefaultTaskCollector("1-1-1", 0)
+	d.CollectMessage("key", "value")
+	if got, ok := d.Message("key"); !ok || got != "value" {
+		t.Errorf("DefaultTaskCollector.Message() = %v %v, want %v", got, ok, "value")
+	}
+	if _, ok := d.Message("none"); ok {
+		t.Errorf("DefaultTaskCollector.Message() ok = %v, want %v", ok, false)
+	}
+}
+
+func TestDefaultTaskCollector_CollectMetric(t *testing.T) {
+	var d plugin.MetricCollector = NewDefaultTaskCollector("1-1-1", 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.CollectBatchWriteLatency(20 * time.Millisecond)
+			d.CollectRetry(2)
+		}()
+	}
+	wg.Wait()
+	c := d.(*DefaultTaskCollector)
+	if got := c.RetryCount(); got != 20 {
+		t.Errorf("DefaultTaskCollector.RetryCount() = %v, want %v", got, 20)
+	}
+	latency := c.BatchWriteLatency()
+	if latency.Count != 10 {
+		t.Errorf("DefaultTaskCollector.BatchWriteLatency().Count = %v, want %v", latency.Count, 10)
+	}
+	if got := latency.Counts[len(latency.Counts)-1]; got != 10 {
+		t.Errorf("DefaultTaskCollector.BatchWriteLatency().Counts = %v, want %v", got, 10)
+	}
+}
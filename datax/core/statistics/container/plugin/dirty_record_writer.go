@@ -0,0 +1,184 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/pingcap/errors"
+
+	//csv和jsonl输出流
+	_ "github.com/Breeze0806/go-etl/storage/stream/file/csv"
+	_ "github.com/Breeze0806/go-etl/storage/stream/file/jsonl"
+)
+
+// 脏数据文件中追加的列名
+const (
+	DirtyColumnError  = "dirty_error"   //错误信息
+	DirtyColumnTaskID = "dirty_task_id" //任务编号
+	DirtyColumnReader = "dirty_reader"  //读取器名
+	DirtyColumnWriter = "dirty_writer"  //写入器名
+)
+
+// DirtyRecordConfig 脏数据文件配置，其余配置会传递给对应类型的输出流写入器
+type DirtyRecordConfig struct {
+	Path string `json:"path"` //脏数据文件所在目录
+	Type string `json:"type"` //文件类型，为输出流创建器名，如csv或者jsonl，默认为csv
+}
+
+// NewDirtyRecordConfig 通过JSON配置conf获取脏数据文件配置
+func NewDirtyRecordConfig(conf *config.JSON) (c *DirtyRecordConfig, err error) {
+	c = &DirtyRecordConfig{}
+	if err = json.Unmarshal([]byte(conf.String()), c); err != nil {
+		return nil, err
+	}
+	if c.Path == "" {
+		return nil, errors.New("path is empty")
+	}
+	if c.Type == "" {
+		c.Type = "csv"
+	}
+	return
+}
+
+// DirtyRecordWriter 脏数据写入器，将脏数据连同错误信息，任务编号，读取器名
+// 以及写入器名写入文件，以便修复后重新导入，文件在第一条脏数据写入时创建
+type DirtyRecordWriter struct {
+	conf       *config.JSON
+	filename   string
+	typ        string
+	taskID     int64
+	readerName string
+	writerName string
+
+	mu       sync.Mutex
+	streamer *file.OutStreamer
+	writer   file.StreamWriter
+	closed   bool
+}
+
+// NewDirtyRecordWriter 通过JSON配置conf，工作编号jobID，任务组编号taskGroupID，
+// 任务编号taskID，重试次数attempt，读取器名readerName以及写入器名writerName创建脏数据写入器，
+// 脏数据文件名为dirty_{jobID}_{taskGroupID}_{taskID}.{type}，任务故障转移重试时
+// 文件名为dirty_{jobID}_{taskGroupID}_{taskID}_{attempt}.{type}，避免覆盖之前执行的脏数据
+func NewDirtyRecordWriter(conf *config.JSON, jobID, taskGroupID, taskID int64, attempt int,
+	readerName, writerName string) (d *DirtyRecordWriter, err error) {
+	var c *DirtyRecordConfig
+	if c, err = NewDirtyRecordConfig(conf); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("dirty_%v_%v_%v", jobID, taskGroupID, taskID)
+	if attempt > 0 {
+		name = fmt.Sprintf("%v_%v", name, attempt)
+	}
+	return &DirtyRecordWriter{
+		conf:       conf,
+		filename:   filepath.Join(c.Path, name+"."+c.Type),
+		typ:        c.Type,
+		taskID:     taskID,
+		readerName: readerName,
+		writerName: writerName,
+	}, nil
+}
+
+// Filename 脏数据文件名
+func (d *DirtyRecordWriter) Filename() string {
+	return d.filename
+}
+
+// Write 将脏数据record以及错误信息errMsg写入文件
+func (d *DirtyRecordWriter) Write(record element.Record, errMsg string) (err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return errors.New("dirty record writer is closed")
+	}
+
+	if d.writer == nil {
+		if err = os.MkdirAll(filepath.Dir(d.filename), 0755); err != nil {
+			return errors.Wrapf(err, "MkdirAll(%v) fail", filepath.Dir(d.filename))
+		}
+		if d.streamer, err = file.NewOutStreamer(d.typ, d.filename); err != nil {
+			return errors.Wrapf(err, "NewOutStreamer(%v) fail", d.filename)
+		}
+		if d.writer, err = d.streamer.Writer(d.conf); err != nil {
+			d.streamer.Close()
+			d.streamer = nil
+			return errors.Wrapf(err, "Writer(%v) fail", d.filename)
+		}
+	}
+
+	var r element.Record
+	if r, err = d.dirtyRecord(record, errMsg); err != nil {
+		return err
+	}
+	if err = d.writer.Write(r); err != nil {
+		return errors.Wrapf(err, "Write(%v) fail", d.filename)
+	}
+	return d.writer.Flush()
+}
+
+// dirtyRecord 在脏数据record的列之后追加错误信息，任务编号，读取器名以及写入器名列
+func (d *DirtyRecordWriter) dirtyRecord(record element.Record, errMsg string) (element.Record, error) {
+	r := element.NewDefaultRecord()
+	if record != nil {
+		for i := 0; i < record.ColumnNumber(); i++ {
+			col, err := record.GetByIndex(i)
+			if err != nil {
+				return nil, err
+			}
+			if err = r.Add(col); err != nil {
+				return nil, errors.Wrapf(err, "column %v", col.Name())
+			}
+		}
+	}
+	for _, col := range []element.Column{
+		element.NewDefaultColumn(element.NewStringColumnValue(errMsg), DirtyColumnError, 0),
+		element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(d.taskID), DirtyColumnTaskID, 0),
+		element.NewDefaultColumn(element.NewStringColumnValue(d.readerName), DirtyColumnReader, 0),
+		element.NewDefaultColumn(element.NewStringColumnValue(d.writerName), DirtyColumnWriter, 0),
+	} {
+		if err := r.Add(col); err != nil {
+			return nil, errors.Wrapf(err, "column %v", col.Name())
+		}
+	}
+	return r, nil
+}
+
+// Close 关闭脏数据文件
+func (d *DirtyRecordWriter) Close() (err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	if d.writer != nil {
+		err = d.writer.Close()
+	}
+	if d.streamer != nil {
+		if cerr := d.streamer.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return
+}
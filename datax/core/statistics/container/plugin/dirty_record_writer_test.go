@@ -0,0 +1,199 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plugin
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	conf, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func testDirtyRecord() element.Record {
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0))
+	r.Add(element.NewDefaultColumn(element.NewStringColumnValue("abc"), "name", 0))
+	return r
+}
+
+func TestNewDirtyRecordConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     *config.JSON
+		wantType string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			conf:     testJSONFromString(`{"path":"dirty"}`),
+			wantType: "csv",
+		},
+		{
+			name:     "2",
+			conf:     testJSONFromString(`{"path":"dirty","type":"jsonl"}`),
+			wantType: "jsonl",
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"type":"jsonl"}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"path":1}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewDirtyRecordConfig(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewDirtyRecordConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && c.Type != tt.wantType {
+				t.Errorf("NewDirtyRecordConfig() Type = %v, want %v", c.Type, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestDirtyRecordWriter_Write(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "dirty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tests := []struct {
+		name     string
+		conf     string
+		attempt  int
+		records  []element.Record
+		wantFile string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			conf:     `{"type":"csv","hasHeader":true}`,
+			records:  []element.Record{testDirtyRecord(), testDirtyRecord()},
+			wantFile: "dirty_1_2_3.csv",
+			want: "id,name,dirty_error,dirty_task_id,dirty_reader,dirty_writer\n" +
+				"1,abc,mock error,3,mockreader,mockwriter\n" +
+				"1,abc,mock error,3,mockreader,mockwriter\n",
+		},
+		{
+			name:     "2",
+			conf:     `{"type":"jsonl"}`,
+			records:  []element.Record{testDirtyRecord(), nil},
+			wantFile: "dirty_1_2_3.jsonl",
+			want: `{"id":1,"name":"abc","dirty_error":"mock error","dirty_task_id":3,"dirty_reader":"mockreader","dirty_writer":"mockwriter"}` + "\n" +
+				`{"dirty_error":"mock error","dirty_task_id":3,"dirty_reader":"mockreader","dirty_writer":"mockwriter"}` + "\n",
+		},
+		{
+			name:    "3",
+			conf:    `{"type":"unknown"}`,
+			records: []element.Record{testDirtyRecord()},
+			wantErr: true,
+		},
+		{
+			name:     "4",
+			conf:     `{"type":"jsonl"}`,
+			attempt:  2,
+			records:  []element.Record{testDirtyRecord()},
+			wantFile: "dirty_1_2_3_2.jsonl",
+			want:     `{"id":1,"name":"abc","dirty_error":"mock error","dirty_task_id":3,"dirty_reader":"mockreader","dirty_writer":"mockwriter"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := testJSONFromString(tt.conf)
+			conf.Set("path", filepath.Join(tmpDir, tt.name))
+			d, err := NewDirtyRecordWriter(conf, 1, 2, 3, tt.attempt, "mockreader", "mockwriter")
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range tt.records {
+				if err = d.Write(r, "mock error"); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Write() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err = d.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr {
+				return
+			}
+			if err = d.Write(testDirtyRecord(), "mock error"); err == nil {
+				t.Errorf("Write() after Close() error = %v", err)
+			}
+			if filepath.Base(d.Filename()) != tt.wantFile {
+				t.Errorf("Filename() = %v, want %v", filepath.Base(d.Filename()), tt.wantFile)
+			}
+			got, err := ioutil.ReadFile(d.Filename())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Write() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultTaskCollector_DirtyRecordWriter(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "dirty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	conf := testJSONFromString(`{"type":"jsonl"}`)
+	conf.Set("path", tmpDir)
+	d, err := NewDirtyRecordWriter(conf, 1, 1, 1, 0, "mockreader", "mockwriter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := NewDefaultTaskCollector("1-1-1", 0)
+	c.SetDirtyRecordWriter(d)
+	c.CollectDirtyRecord(testDirtyRecord(), errors.New("mock error"), "mock msg")
+	if err = c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadFile(filepath.Join(tmpDir, "dirty_1_1_1.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":1,"name":"abc","dirty_error":"mock msg: mock error","dirty_task_id":1,"dirty_reader":"mockreader","dirty_writer":"mockwriter"}` + "\n"
+	if string(got) != want {
+		t.Errorf("CollectDirtyRecord() = %v, want %v", string(got), want)
+	}
+}
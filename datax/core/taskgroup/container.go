@@ -16,6 +16,7 @@ package taskgroup
 
 import (
 	"context"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -45,6 +46,13 @@ type Container struct {
 	retryInterval  time.Duration
 	sleepInterval  time.Duration
 	retryMaxCount  int32
+	retryStrategy  schedule.RetryStrategy //任务故障转移的重试策略
+	retried        chan struct{}          //故障转移的任务重新加入待执行队列的通知
+
+	statsMutex sync.RWMutex
+	stats      map[int64]Stats //任务编号对应的统计信息
+	finished   []int64         //成功完成的任务编号
+	errs       map[int64]error //任务编号对应的最终错误
 }
 
 // NewContainer 根据JSON配置conf创建任务组容器
@@ -54,6 +62,9 @@ func NewContainer(ctx context.Context, conf *config.JSON) (c *Container, err err
 		BaseCotainer: core.NewBaseCotainer(),
 		tasks:        newTaskManager(),
 		ctx:          ctx,
+		stats:        make(map[int64]Stats),
+		errs:         make(map[int64]error),
+		retried:      make(chan struct{}, 1),
 	}
 	c.SetConfig(conf)
 	c.SetMetrics(container.NewMetrics())
@@ -65,6 +76,7 @@ func NewContainer(ctx context.Context, conf *config.JSON) (c *Container, err err
 	if err != nil {
 		return nil, err
 	}
+	c.Metrics().Set("jobID", c.jobID)
 	c.Metrics().Set("taskGroupID", c.taskGroupID)
 	c.reportInterval = time.Duration(
 		c.Config().GetInt64OrDefaullt(coreconst.DataxCoreContainerTaskGroupReportinterval, 1)) * time.Second
@@ -73,6 +85,9 @@ func NewContainer(ctx context.Context, conf *config.JSON) (c *Container, err err
 	c.retryInterval = time.Duration(
 		c.Config().GetInt64OrDefaullt(coreconst.DataxCoreContainerTaskFailoverRetryintervalinmsec, 1000)) * time.Millisecond
 	c.retryMaxCount = int32(c.Config().GetInt64OrDefaullt(coreconst.DataxCoreContainerTaskFailoverMaxretrytimes, 1))
+	if c.retryStrategy, err = newFailoverStrategy(c.Config(), c.retryMaxCount, c.retryInterval); err != nil {
+		return nil, errors.Wrapf(err, "newFailoverStrategy fail")
+	}
 	log.Infof("datax job(%v) taskgruop(%v) reportInterval: %v retryInterval: %v retryMaxCount: %v config: %v",
 		c.jobID, c.taskGroupID, c.reportInterval, c.retryInterval, c.retryMaxCount, conf)
 	return
@@ -116,14 +131,12 @@ func (c *Container) Start() (err error) {
 		c.tasks.pushRemain(taskExecer)
 	}
 	log.Infof("datax job(%v) taskgruop(%v) start tasks", c.jobID, c.taskGroupID)
-	var tasks []*taskExecer
 	for i := 0; i < len(taskConfigs); i++ {
 		//从待执行队列加入运行队列
 		te, ok := c.tasks.popRemainAndAddRun()
 		if !ok {
 			continue
 		}
-		tasks = append(tasks, te)
 		//开始运行
 		if err = c.startTaskExecer(te); err != nil {
 			return
@@ -133,8 +146,8 @@ func (c *Container) Start() (err error) {
 	ticker := time.NewTicker(c.sleepInterval)
 	defer ticker.Stop()
 QueueLoop:
-	//任务队列不为空
-	for !c.tasks.isRunsEmpty() {
+	//任务队列不为空，故障转移的任务会从运行队列移回待执行队列
+	for !c.tasks.isEmpty() {
 		for !c.tasks.isEmpty() {
 			select {
 			case <-c.ctx.Done():
@@ -146,6 +159,7 @@ QueueLoop:
 			if !ok {
 				select {
 				case <-ticker.C:
+				case <-c.retried:
 				case <-c.ctx.Done():
 					break QueueLoop
 				}
@@ -159,6 +173,7 @@ QueueLoop:
 		case <-c.ctx.Done():
 			break QueueLoop
 		case <-ticker.C:
+		case <-c.retried:
 		}
 	}
 	log.Infof("datax job(%v) taskgruop(%v) wait tasks end", c.jobID, c.taskGroupID)
@@ -169,11 +184,16 @@ QueueLoop:
 	}
 
 	b := &strings.Builder{}
-	for _, t := range tasks {
-		if t.Err != nil {
-			b.WriteString(t.Err.Error())
-			b.WriteByte(' ')
-		}
+	var taskIDs []int64
+	for taskID := range c.errs {
+		taskIDs = append(taskIDs, taskID)
+	}
+	sort.Slice(taskIDs, func(i, j int) bool {
+		return taskIDs[i] < taskIDs[j]
+	})
+	for _, taskID := range taskIDs {
+		b.WriteString(c.errs[taskID].Error())
+		b.WriteByte(' ')
 	}
 
 	if b.Len() != 0 {
@@ -207,28 +227,17 @@ func (c *Container) startTaskExecer(te *taskExecer) (err error) {
 		for {
 			select {
 			case te.Err = <-errChan:
-				//当失败时，重试次数不超过最大重试次数，写入任务是否支持失败冲时，这些决定写入任务是否冲时
-				if te.Err != nil && te.WriterSuportFailOverport() && te.AttemptCount() <= c.retryMaxCount {
-					log.Debugf("datax job(%v) taskgruop(%v) task(%v) shutdown and retry. attemptCount: %v err: %v",
-						c.jobID, c.taskGroupID, te.Key(), te.AttemptCount(), te.Err)
-					//关闭任务
-					te.Shutdown()
-					timer := time.NewTimer(c.retryInterval)
-					defer timer.Stop()
-					select {
-					case <-timer.C:
-					case <-c.ctx.Done():
-						return
-					}
-					//从运行队列移到待执行队列
-					c.tasks.removeRunAndPushRemain(te)
-				} else {
-					//从任务调度器移除
-					c.tasks.removeRun(te)
-					c.setStats(te)
+				//当失败时，写入任务支持故障转移，重试次数不超过最大重试次数并且重试策略允许时，重新执行任务
+				if retry, wait := c.shouldFailover(te); retry {
+					log.Infof("datax job(%v) taskgruop(%v) task(%v) shutdown and retry after %v. attemptCount: %v err: %v",
+						c.jobID, c.taskGroupID, te.Key(), wait, te.AttemptCount(), te.Err)
+					c.failover(te, wait)
+					return
 				}
+				c.finish(te)
 				return
 			case <-c.ctx.Done():
+				te.Close()
 				return
 			case <-statsTimer.C:
 				c.setStats(te)
@@ -238,9 +247,112 @@ func (c *Container) startTaskExecer(te *taskExecer) (err error) {
 	return
 }
 
+// shouldFailover 判断失败的任务te是否需要重试以及重试前的等待时间wait
+func (c *Container) shouldFailover(te *taskExecer) (retry bool, wait time.Duration) {
+	if te.Err == nil || c.ctx.Err() != nil || !te.WriterSuportFailOverport() {
+		return false, 0
+	}
+	if te.AttemptCount() > c.retryMaxCount {
+		return false, 0
+	}
+	return c.retryStrategy.Next(te.Err, int(te.AttemptCount()))
+}
+
+// failover 关闭失败的任务te，等待wait后使用相同的任务配置生成新的任务执行器，
+// 从运行队列移到待执行队列中重新执行
+func (c *Container) failover(te *taskExecer, wait time.Duration) {
+	te.Shutdown()
+	c.setStats(te)
+	te.Close()
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-c.ctx.Done():
+		//等待重试时被取消，保留导致重试的错误，避免任务从结果中消失
+		c.tasks.removeRun(te)
+		c.setErr(te.taskID, te.Err)
+		return
+	}
+
+	retry, err := newTaskExecer(c.ctx, te.taskConf, c.jobID, c.taskGroupID, int(te.AttemptCount()))
+	if err != nil {
+		log.Errorf("datax job(%v) taskgruop(%v) task(%v) newTaskExecer fail. err: %v",
+			c.jobID, c.taskGroupID, te.Key(), err)
+		c.tasks.removeRun(te)
+		c.setErr(te.taskID, err)
+		return
+	}
+	//重试的任务执行器与原任务执行器关键字相同，所以可以直接从运行队列移到待执行队列
+	c.tasks.removeRunAndPushRemain(retry)
+	select {
+	case c.retried <- struct{}{}:
+	default:
+	}
+}
+
+// finish 结束任务te，从运行队列移除并记录统计信息以及执行结果
+func (c *Container) finish(te *taskExecer) {
+	c.tasks.removeRun(te)
+	c.setStats(te)
+	if te.Err == nil {
+		c.setFinished(te)
+	} else {
+		c.setErr(te.taskID, te.Err)
+	}
+	te.Close()
+}
+
+func (c *Container) setErr(taskID int64, err error) {
+	c.statsMutex.Lock()
+	c.errs[taskID] = err
+	c.statsMutex.Unlock()
+}
+
 func (c *Container) setStats(te *taskExecer) {
 	key := "metrics." + strconv.FormatInt(te.taskID, 10)
 	stats := te.Stats()
 
 	c.Metrics().Set(key, stats)
+
+	c.statsMutex.Lock()
+	c.stats[te.taskID] = stats
+	c.statsMutex.Unlock()
+}
+
+func (c *Container) setFinished(te *taskExecer) {
+	c.statsMutex.Lock()
+	c.finished = append(c.finished, te.taskID)
+	c.statsMutex.Unlock()
+}
+
+// FinishedTaskIDs 获取任务组中已经成功完成的任务编号
+func (c *Container) FinishedTaskIDs() []int64 {
+	c.statsMutex.RLock()
+	defer c.statsMutex.RUnlock()
+	return append([]int64(nil), c.finished...)
+}
+
+// TaskStats 获取任务组中所有任务的统计信息，按任务编号排序
+func (c *Container) TaskStats() (stats []Stats) {
+	c.statsMutex.RLock()
+	defer c.statsMutex.RUnlock()
+	for _, v := range c.stats {
+		stats = append(stats, v)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].TaskID < stats[j].TaskID
+	})
+	return
+}
+
+// RecordStats 获取任务组中所有任务读取的总记录数totalRecord和脏数据个数errorRecord
+func (c *Container) RecordStats() (totalRecord, errorRecord int64) {
+	c.statsMutex.RLock()
+	defer c.statsMutex.RUnlock()
+	for _, v := range c.stats {
+		totalRecord += v.Channel.TotalRecord
+		errorRecord += v.ErrorRecord
+	}
+	return
 }
@@ -18,12 +18,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
 	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/schedule"
 )
 
@@ -489,3 +492,292 @@ func TestContainer_startTaskExecer(t *testing.T) {
 		t.Errorf("Container.startTaskExecer() error = %v, wantErr true", err)
 	}
 }
+
+func TestContainer_RecordStats(t *testing.T) {
+	resetLoader()
+	initLoader("mock", []error{
+		nil, nil, nil, nil, nil,
+	})
+	c, err := NewContainer(context.TODO(), testJSONFromString(`{
+		"core" : {
+			"container": {
+				"job":{
+					"id": 1
+				},
+				"taskGroup":{
+					"id": 1
+				}
+			}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("NewContainer error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		te := testTaskExecer(context.TODO(), testJSONFromString(fmt.Sprintf(`{
+			"taskId":%d,
+			"reader":{
+				"name":"mock",
+				"parameter":{}
+			},
+			"writer":{
+				"name":"mock",
+				"parameter":{}
+			}
+		}`, i)), 1, 1, 0)
+		te.channel.Push(element.NewDefaultRecord())
+		te.collector.CollectDirtyRecordWithError(nil, errors.New("mock error"))
+		c.setStats(te)
+	}
+	totalRecord, errorRecord := c.RecordStats()
+	if totalRecord != 2 {
+		t.Errorf("RecordStats() totalRecord = %v, want %v", totalRecord, 2)
+	}
+	if errorRecord != 2 {
+		t.Errorf("RecordStats() errorRecord = %v, want %v", errorRecord, 2)
+	}
+}
+
+func TestContainer_FinishedTaskIDs(t *testing.T) {
+	content := testJSONFromString(`{
+		"core" : {
+			"container": {
+				"job":{
+					"id": 1
+				},
+				"taskGroup":{
+					"id": 1
+				}
+			}
+		}
+	}`)
+	for i := 0; i < 10; i++ {
+		content.SetRawString(coreconst.DataxJobContent+fmt.Sprintf(".%d", i), fmt.Sprintf(`{
+			"taskId": %d,
+			"reader":{
+				"name":"mock",
+				"parameter":{}
+			},
+			"writer":{
+				"name":"mock",
+				"parameter":{}
+			}
+		}`, i))
+	}
+
+	resetLoader()
+	loader.RegisterReader("mock", newMockReader([]error{
+		nil, nil, nil, nil, nil,
+	}))
+	loader.RegisterWriter("mock", newMockWriter([]error{
+		nil, nil, nil, nil, nil,
+	}))
+	c, _ := NewContainer(context.TODO(), content)
+	if err := c.Do(); err != nil {
+		t.Fatalf("Do error: %v", err)
+	}
+	stats := c.TaskStats()
+	if len(stats) != 10 {
+		t.Fatalf("TaskStats() = %v, want %v tasks", stats, 10)
+	}
+	for i, v := range stats {
+		if v.TaskID != int64(i) || v.Reader != "mock" || v.Writer != "mock" {
+			t.Errorf("TaskStats()[%v] = %+v", i, v)
+		}
+	}
+	got := c.FinishedTaskIDs()
+	sort.Slice(got, func(i, j int) bool {
+		return got[i] < got[j]
+	})
+	want := []int64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FinishedTaskIDs() = %v, want %v", got, want)
+	}
+
+	resetLoader()
+	loader.RegisterReader("mock", newMockReader([]error{
+		nil, nil, errors.New("mock test error"), nil, nil,
+	}))
+	loader.RegisterWriter("mock", newMockWriter([]error{
+		nil, nil, nil, nil, nil,
+	}))
+	c, _ = NewContainer(context.TODO(), content)
+	if err := c.Do(); err == nil {
+		t.Fatalf("Do error: %v", err)
+	}
+	if got := c.FinishedTaskIDs(); len(got) != 0 {
+		t.Errorf("FinishedTaskIDs() = %v, want empty", got)
+	}
+}
+
+func TestContainer_Failover(t *testing.T) {
+	newContent := func(failover string) *config.JSON {
+		content := testJSONFromString(`{
+			"core" : {
+				"container": {
+					"job":{
+						"id": 1
+					},
+					"taskGroup":{
+						"id": 1
+					}
+				}
+			}
+		}`)
+		content.SetRawString("core.container.task.failover", failover)
+		content.SetRawString(coreconst.DataxJobContent+".0", `{
+			"taskId": 0,
+			"reader":{
+				"name":"mock",
+				"parameter":{}
+			},
+			"writer":{
+				"name":"mock",
+				"parameter":{}
+			}
+		}`)
+		return content
+	}
+	tests := []struct {
+		name         string
+		failover     string
+		writer       *mockFailoverWriter
+		wantErr      bool
+		wantAttempts int32
+	}{
+		{
+			name:         "1",
+			failover:     `{"retryIntervalInMsec":10}`,
+			writer:       &mockFailoverWriter{failTimes: 1, supportFailOver: true},
+			wantAttempts: 2,
+		},
+		{
+			name:         "2",
+			failover:     `{"retryIntervalInMsec":10,"maxRetryTimes":3}`,
+			writer:       &mockFailoverWriter{failTimes: 3, supportFailOver: true},
+			wantAttempts: 4,
+		},
+		{
+			name:         "3",
+			failover:     `{"retryIntervalInMsec":10,"maxRetryTimes":2}`,
+			writer:       &mockFailoverWriter{failTimes: 3, supportFailOver: true},
+			wantErr:      true,
+			wantAttempts: 3,
+		},
+		{
+			name:         "4",
+			failover:     `{"retryIntervalInMsec":10}`,
+			writer:       &mockFailoverWriter{failTimes: 1, supportFailOver: false},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "5",
+			failover:     `{"maxRetryTimes":0}`,
+			writer:       &mockFailoverWriter{failTimes: 1, supportFailOver: true},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name: "6",
+			failover: `{"maxRetryTimes":5,"retry":{"type":"exponential",
+				"strategy":{"init":"10ms","max":"1s"}}}`,
+			writer:       &mockFailoverWriter{failTimes: 3, supportFailOver: true},
+			wantAttempts: 4,
+		},
+		{
+			name: "7",
+			failover: `{"maxRetryTimes":5,"retry":{"type":"ntimes",
+				"strategy":{"n":2,"wait":"10ms"}}}`,
+			writer:       &mockFailoverWriter{failTimes: 3, supportFailOver: true},
+			wantErr:      true,
+			wantAttempts: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetLoader()
+			loader.RegisterReader("mock", newMockReader([]error{
+				nil, nil, nil, nil, nil,
+			}))
+			loader.RegisterWriter("mock", tt.writer)
+			c := testContainer(context.TODO(), newContent(tt.failover))
+			if err := c.Do(); (err != nil) != tt.wantErr {
+				t.Errorf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := tt.writer.attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %v, want %v", got, tt.wantAttempts)
+			}
+			wantFinished := []int64{0}
+			if tt.wantErr {
+				wantFinished = nil
+			}
+			if got := c.FinishedTaskIDs(); !reflect.DeepEqual(got, wantFinished) {
+				t.Errorf("FinishedTaskIDs() = %v, want %v", got, wantFinished)
+			}
+		})
+	}
+}
+
+func TestContainer_FailoverCancel(t *testing.T) {
+	resetLoader()
+	loader.RegisterReader("mock", newMockReader([]error{
+		nil, nil, nil, nil, nil,
+	}))
+	writer := &mockFailoverWriter{failTimes: 1, supportFailOver: true}
+	loader.RegisterWriter("mock", writer)
+	content := testJSONFromString(`{
+		"core" : {
+			"container": {
+				"job":{
+					"id": 1
+				},
+				"taskGroup":{
+					"id": 1
+				},
+				"task":{
+					"failover":{
+						"retryIntervalInMsec":60000
+					}
+				}
+			}
+		}
+	}`)
+	content.SetRawString(coreconst.DataxJobContent+".0", `{
+		"taskId": 0,
+		"reader":{
+			"name":"mock",
+			"parameter":{}
+		},
+		"writer":{
+			"name":"mock",
+			"parameter":{}
+		}
+	}`)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := testContainer(ctx, content)
+	go func() {
+		//等待任务失败后进入重试前的等待
+		for writer.attempts.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	if err := c.Do(); err != context.Canceled {
+		t.Errorf("Do() error = %v", err)
+	}
+	if got := writer.attempts.Load(); got != 1 {
+		t.Errorf("attempts = %v, want 1", got)
+	}
+	if got := c.FinishedTaskIDs(); len(got) != 0 {
+		t.Errorf("FinishedTaskIDs() = %v, want empty", got)
+	}
+	c.statsMutex.Lock()
+	err := c.errs[0]
+	c.statsMutex.Unlock()
+	if err == nil || err.Error() != "mock failover error" {
+		t.Errorf("errs[0] = %v, want mock failover error", err)
+	}
+}
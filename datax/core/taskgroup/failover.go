@@ -0,0 +1,44 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskgroup
+
+import (
+	"context"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
+	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/pingcap/errors"
+)
+
+// failoverJudger 任务故障转移判定器，除了上下文取消以外的错误都需要重试
+type failoverJudger struct{}
+
+// ShouldRetry 通过错误err判断任务是否需要重试
+func (failoverJudger) ShouldRetry(err error) bool {
+	return err != nil && errors.Cause(err) != context.Canceled
+}
+
+// newFailoverStrategy 通过任务组配置conf生成任务故障转移的重试策略，
+// 配置了core.container.task.failover.retry时使用该重试策略，
+// 否则每隔retryInterval重试一次，最多重试maxRetryTimes次
+func newFailoverStrategy(conf *config.JSON, maxRetryTimes int32, retryInterval time.Duration) (schedule.RetryStrategy, error) {
+	failover, err := conf.GetConfig(coreconst.DataxCoreContainerTaskFailover)
+	if err == nil && failover.Exists("retry") {
+		return schedule.NewRetryStrategy(failoverJudger{}, failover)
+	}
+	return schedule.NewNTimesRetryStrategy(failoverJudger{}, int(maxRetryTimes)+1, retryInterval), nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package taskgroup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/pingcap/errors"
+)
+
+func Test_failoverJudger_ShouldRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "1",
+			err:  errors.New("mock error"),
+			want: true,
+		},
+		{
+			name: "2",
+			err:  nil,
+			want: false,
+		},
+		{
+			name: "3",
+			err:  context.Canceled,
+			want: false,
+		},
+		{
+			name: "4",
+			err:  errors.Wrap(context.Canceled, "mock"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (failoverJudger{}).ShouldRetry(tt.err); got != tt.want {
+				t.Errorf("failoverJudger.ShouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newFailoverStrategy(t *testing.T) {
+	tests := []struct {
+		name      string
+		conf      *config.JSON
+		n         int
+		wantRetry bool
+		wantWait  time.Duration
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			conf:      testJSONFromString(`{}`),
+			n:         1,
+			wantRetry: true,
+			wantWait:  time.Second,
+		},
+		{
+			name:      "2",
+			conf:      testJSONFromString(`{}`),
+			n:         2,
+			wantRetry: false,
+		},
+		{
+			name: "3",
+			conf: testJSONFromString(`{"core":{"container":{"task":{"failover":{
+				"retry":{"type":"ntimes","strategy":{"n":3,"wait":"10ms"}}}}}}}`),
+			n:         2,
+			wantRetry: true,
+			wantWait:  10 * time.Millisecond,
+		},
+		{
+			name: "4",
+			conf: testJSONFromString(`{"core":{"container":{"task":{"failover":{
+				"retry":{"type":"ntimes","strategy":{"n":3,"wait":"10ms"}}}}}}}`),
+			n:         3,
+			wantRetry: false,
+		},
+		{
+			name: "5",
+			conf: testJSONFromString(`{"core":{"container":{"task":{"failover":{
+				"retry":{"type":"mock","strategy":{}}}}}}}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newFailoverStrategy(tt.conf, 1, time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newFailoverStrategy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			retry, wait := s.Next(errors.New("mock error"), tt.n)
+			if retry != tt.wantRetry || wait != tt.wantWait {
+				t.Errorf("Next() = %v %v, want %v %v", retry, wait, tt.wantRetry, tt.wantWait)
+			}
+		})
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/Breeze0806/go-etl/datax/common/spi/reader"
 	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
 	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+	"go.uber.org/atomic"
 )
 
 type mockPlugin struct {
@@ -223,6 +225,40 @@ func (m *mockWriter) Task() writer.Task {
 	return newMockWriterTask(m.errs)
 }
 
+type mockFailoverWriterTask struct {
+	*mockWriterTask
+	writer *mockFailoverWriter
+}
+
+func (m *mockFailoverWriterTask) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) error {
+	if m.writer.attempts.Inc() <= m.writer.failTimes {
+		return errors.New("mock failover error")
+	}
+	return nil
+}
+
+func (m *mockFailoverWriterTask) SupportFailOver() bool {
+	return m.writer.supportFailOver
+}
+
+// mockFailoverWriter 前failTimes次写入失败的写入器
+type mockFailoverWriter struct {
+	failTimes       int32
+	supportFailOver bool
+	attempts        atomic.Int32
+}
+
+func (m *mockFailoverWriter) Job() writer.Job {
+	return &mockWriterJob{}
+}
+
+func (m *mockFailoverWriter) Task() writer.Task {
+	return &mockFailoverWriterTask{
+		mockWriterTask: newMockWriterTask([]error{nil, nil, nil, nil, nil}),
+		writer:         m,
+	}
+}
+
 func testJSONFromString(s string) *config.JSON {
 	j, err := config.NewJSONFromString(s)
 	if err != nil {
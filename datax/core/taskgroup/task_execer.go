@@ -23,9 +23,12 @@ import (
 	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
 	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
 	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/datax/core/statistics/container"
+	statplugin "github.com/Breeze0806/go-etl/datax/core/statistics/container/plugin"
 	"github.com/Breeze0806/go-etl/datax/core/taskgroup/runner"
 	"github.com/Breeze0806/go-etl/datax/core/transport/channel"
 	"github.com/Breeze0806/go-etl/datax/core/transport/exchange"
+	"github.com/Breeze0806/go-etl/datax/transform"
 	"github.com/pingcap/errors"
 	"go.uber.org/atomic"
 )
@@ -44,10 +47,13 @@ type taskExecer struct {
 
 	destroy      sync.Once
 	key          string
+	readName     string //读取器名
+	writeName    string //写入器名
 	exchanger    *exchange.RecordExchanger
-	cancalMutex  sync.Mutex         //由于取消函数会被多线程调用,需要加锁
-	cancel       context.CancelFunc //取消函数
-	attemptCount *atomic.Int32      //执行次数
+	collector    *statplugin.DefaultTaskCollector //任务收集器
+	cancalMutex  sync.Mutex                       //由于取消函数会被多线程调用,需要加锁
+	cancel       context.CancelFunc               //取消函数
+	attemptCount *atomic.Int32                    //执行次数
 }
 
 // newTaskExecer 根据上下文ctx，任务配置taskConf，前缀关键字prefixKey
@@ -68,6 +74,8 @@ func newTaskExecer(ctx context.Context, taskConf *config.JSON,
 		return nil, err
 	}
 	t.key = fmt.Sprintf("%v-%v-%v", jobID, taskGroupID, t.taskID)
+	t.collector = statplugin.NewDefaultTaskCollector(t.key,
+		taskConf.GetInt64OrDefaullt(coreconst.DataxCoreStatisticsCollectorPluginMaxdirtynum, 10))
 	readName, writeName := "", ""
 	readName, err = taskConf.GetString(coreconst.JobReaderName)
 	if err != nil {
@@ -90,6 +98,11 @@ func newTaskExecer(ctx context.Context, taskConf *config.JSON,
 		return nil, err
 	}
 
+	t.readName, t.writeName = readName, writeName
+	if err = t.setDirtyRecordWriter(writeConf, jobID, taskGroupID, attemptCount, readName, writeName); err != nil {
+		return nil, err
+	}
+
 	readTask, ok := loader.LoadReaderTask(readName)
 	if !ok {
 		return nil, errors.Errorf("reader task name (%v) does not exist", readName)
@@ -100,7 +113,14 @@ func newTaskExecer(ctx context.Context, taskConf *config.JSON,
 	readTask.SetPluginJobConf(readConf)
 	readTask.SetPeerPluginName(writeName)
 	readTask.SetPeerPluginJobConf(writeConf)
-	t.exchanger = exchange.NewRecordExchangerWithoutTransformer(t.channel)
+	readTask.SetTaskCollector(t.collector)
+	var tran transform.Transformer
+	tran, err = newTransformer(taskConf)
+	if err != nil {
+		return nil, err
+	}
+	t.exchanger = exchange.NewRecordExchanger(t.channel, tran)
+	t.exchanger.SetTaskCollector(t.collector)
 	t.readerRunner = runner.NewReader(readTask, t.exchanger, t.key)
 
 	writeTask, ok := loader.LoadWriterTask(writeName)
@@ -113,11 +133,44 @@ func newTaskExecer(ctx context.Context, taskConf *config.JSON,
 	writeTask.SetPluginJobConf(writeConf)
 	writeTask.SetPeerPluginName(readName)
 	writeTask.SetPeerPluginJobConf(readConf)
+	writeTask.SetTaskCollector(t.collector)
 	t.writerRunner = runner.NewWriter(writeTask, t.exchanger, t.key)
 
 	return
 }
 
+// setDirtyRecordWriter 当写入器配置writeConf中存在脏数据文件配置时，
+// 为任务收集器设置脏数据写入器，重试的任务使用带有重试次数attempt的脏数据文件
+func (t *taskExecer) setDirtyRecordWriter(writeConf *config.JSON,
+	jobID, taskGroupID int64, attempt int, readName, writeName string) error {
+	if !writeConf.Exists(coreconst.DataxJobSettingDirtyRecord) {
+		return nil
+	}
+	conf, err := writeConf.GetConfig(coreconst.DataxJobSettingDirtyRecord)
+	if err != nil {
+		return err
+	}
+	w, err := statplugin.NewDirtyRecordWriter(conf, jobID, taskGroupID, t.taskID, attempt, readName, writeName)
+	if err != nil {
+		return errors.Wrapf(err, "NewDirtyRecordWriter fail")
+	}
+	t.collector.SetDirtyRecordWriter(w)
+	return nil
+}
+
+// newTransformer 根据任务配置taskConf中的转化器配置生成转化器
+// 当转化器配置不存在时，生成空转化器
+func newTransformer(taskConf *config.JSON) (transform.Transformer, error) {
+	if !taskConf.Exists(coreconst.JobTransformer) {
+		return &transform.NilTransformer{}, nil
+	}
+	confs, err := taskConf.GetConfigArray(coreconst.JobTransformer)
+	if err != nil {
+		return nil, err
+	}
+	return transform.NewTransformer(confs)
+}
+
 // Start 读取运行器和写入运行器分别在携程中执行
 func (t *taskExecer) Start() {
 	var ctx context.Context
@@ -189,6 +242,13 @@ func (t *taskExecer) Do() (err error) {
 	}
 }
 
+// Close 关闭任务收集器，在任务不再执行时调用
+func (t *taskExecer) Close() {
+	if err := t.collector.Close(); err != nil {
+		log.Errorf("taskExecer %v close collector fail. err: %v", t.key, err)
+	}
+}
+
 // Key 关键字
 func (t *taskExecer) Key() string {
 	return t.key
@@ -247,14 +307,26 @@ Loop:
 
 // Stats 统计信息
 type Stats struct {
-	TaskID  int64             `json:"taskID"`
-	Channel channel.StatsJSON `json:"channel"`
+	TaskID            int64                   `json:"taskID"`
+	Reader            string                  `json:"reader"` //读取器名
+	Writer            string                  `json:"writer"` //写入器名
+	Channel           channel.StatsJSON       `json:"channel"`
+	Exchange          exchange.StatsJSON      `json:"exchange"`
+	ErrorRecord       int64                   `json:"errorRecord"`       //脏数据个数
+	BatchWriteLatency container.HistogramJSON `json:"batchWriteLatency"` //批量写入耗时
+	RetryCount        int64                   `json:"retryCount"`        //重试次数
 }
 
 // Stats 获取统计信息
 func (t *taskExecer) Stats() Stats {
 	return Stats{
-		TaskID:  t.taskID,
-		Channel: t.channel.StatsJSON(),
+		TaskID:            t.taskID,
+		Reader:            t.readName,
+		Writer:            t.writeName,
+		Channel:           t.channel.StatsJSON(),
+		Exchange:          t.exchanger.StatsJSON(),
+		ErrorRecord:       t.collector.ErrorRecord(),
+		BatchWriteLatency: t.collector.BatchWriteLatency(),
+		RetryCount:        t.collector.RetryCount(),
 	}
 }
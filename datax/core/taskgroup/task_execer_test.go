@@ -22,6 +22,7 @@ import (
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/common/plugin/loader"
+	"github.com/Breeze0806/go-etl/datax/transform"
 )
 
 func testTaskExecer(ctx context.Context, taskConf *config.JSON, jobID, taskGroupID int64, attemptCount int) *taskExecer {
@@ -39,6 +40,11 @@ func initLoader(name string, errs []error) {
 
 func Test_newTaskExecer(t *testing.T) {
 	resetLoader()
+	transform.UnregisterAllCreator()
+	defer transform.UnregisterAllCreator()
+	transform.RegisterCreator("mock", transform.CreatorFunc(func(conf *config.JSON) (transform.Transformer, error) {
+		return &transform.NilTransformer{}, nil
+	}))
 	initLoader("mock", []error{
 		nil, nil, nil, nil, nil,
 	})
@@ -218,6 +224,72 @@ func Test_newTaskExecer(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "9",
+			args: args{
+				ctx: context.Background(),
+				taskConf: testJSONFromString(`{
+						"taskId":9,
+						"reader":{
+							"name":"mock",
+							"parameter":{}
+						},
+						"writer":{
+							"name":"mock",
+							"parameter":{}
+						},
+						"transformer":[{"name":"mock"}]
+					}`),
+				jobID:        9,
+				taskGroupID:  9,
+				attemptCount: 0,
+			},
+			wantErr: false,
+		},
+		{
+			name: "10",
+			args: args{
+				ctx: context.Background(),
+				taskConf: testJSONFromString(`{
+						"taskId":10,
+						"reader":{
+							"name":"mock",
+							"parameter":{}
+						},
+						"writer":{
+							"name":"mock",
+							"parameter":{}
+						},
+						"transformer":[{"name":"mock1"}]
+					}`),
+				jobID:        10,
+				taskGroupID:  10,
+				attemptCount: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "11",
+			args: args{
+				ctx: context.Background(),
+				taskConf: testJSONFromString(`{
+						"taskId":11,
+						"reader":{
+							"name":"mock",
+							"parameter":{}
+						},
+						"writer":{
+							"name":"mock",
+							"parameter":{}
+						},
+						"transformer":{}
+					}`),
+				jobID:        11,
+				taskGroupID:  11,
+				attemptCount: 0,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -285,3 +357,85 @@ func Test_taskExecer_Do(t *testing.T) {
 		})
 	}
 }
+
+func Test_taskExecer_TaskCollector(t *testing.T) {
+	resetLoader()
+	initLoader("mock", []error{
+		nil, nil, nil, nil, nil,
+	})
+	te := testTaskExecer(context.Background(), testJSONFromString(`{
+		"taskId":1,
+		"reader":{
+			"name":"mock",
+			"parameter":{}
+		},
+		"writer":{
+			"name":"mock",
+			"parameter":{}
+		}
+	}`), 1, 1, 0)
+	if te.readerRunner.Plugin().TaskCollector() != te.collector {
+		t.Errorf("reader TaskCollector() = %v, want %v", te.readerRunner.Plugin().TaskCollector(), te.collector)
+	}
+	if te.writerRunner.Plugin().TaskCollector() != te.collector {
+		t.Errorf("writer TaskCollector() = %v, want %v", te.writerRunner.Plugin().TaskCollector(), te.collector)
+	}
+	te.writerRunner.Plugin().TaskCollector().CollectDirtyRecordWithError(nil, errors.New("mock error"))
+	if got := te.Stats().ErrorRecord; got != 1 {
+		t.Errorf("Stats().ErrorRecord = %v, want %v", got, 1)
+	}
+}
+
+func Test_newTaskExecer_DirtyRecord(t *testing.T) {
+	resetLoader()
+	initLoader("mock", []error{
+		nil, nil, nil, nil, nil,
+	})
+	_, err := newTaskExecer(context.Background(), testJSONFromString(`{
+		"taskId":1,
+		"reader":{
+			"name":"mock",
+			"parameter":{}
+		},
+		"writer":{
+			"name":"mock",
+			"parameter":{
+				"job":{
+					"setting":{
+						"dirtyRecord":{
+							"type":"csv"
+						}
+					}
+				}
+			}
+		}
+	}`), 1, 1, 0)
+	if err == nil {
+		t.Errorf("newTaskExecer() error = %v, wantErr true", err)
+	}
+
+	te, err := newTaskExecer(context.Background(), testJSONFromString(`{
+		"taskId":1,
+		"reader":{
+			"name":"mock",
+			"parameter":{}
+		},
+		"writer":{
+			"name":"mock",
+			"parameter":{
+				"job":{
+					"setting":{
+						"dirtyRecord":{
+							"path":"dirty",
+							"type":"csv"
+						}
+					}
+				}
+			}
+		}
+	}`), 1, 1, 0)
+	if err != nil {
+		t.Fatalf("newTaskExecer() error = %v", err)
+	}
+	te.Close()
+}
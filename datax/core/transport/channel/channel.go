@@ -26,10 +26,11 @@ import (
 
 // Channel 通道
 type Channel struct {
-	limiter *rate.Limiter
-	records *element.RecordChan
-	ctx     context.Context
-	stats   Stats
+	limiter    *rate.Limiter
+	jobLimiter *Limiter //工作限速器
+	records    *element.RecordChan
+	ctx        context.Context
+	stats      Stats
 }
 
 // Stats Channel的统计信息
@@ -69,7 +70,8 @@ func (s *Stats) statsJSON() StatsJSON {
 	return s.StatsJSON
 }
 
-// NewChannel 创建通道
+// NewChannel 通过上下文ctx和JSON配置conf创建通道，conf中的字节数限制单个通道的速度，
+// ctx中存在工作限速器时，还会受到工作限速器的限制
 func NewChannel(ctx context.Context, conf *config.JSON) *Channel {
 	r := -1
 	b := -1.0
@@ -85,9 +87,10 @@ func NewChannel(ctx context.Context, conf *config.JSON) *Channel {
 		r = 0
 	}
 	return &Channel{
-		records: element.NewRecordChanBuffer(ctx, r),
-		ctx:     ctx,
-		limiter: limiter,
+		records:    element.NewRecordChanBuffer(ctx, r),
+		ctx:        ctx,
+		limiter:    limiter,
+		jobLimiter: limiterFromContext(ctx),
 	}
 }
 
@@ -108,6 +111,11 @@ func (c *Channel) Push(r element.Record) (n int, err error) {
 			return 0, err
 		}
 	}
+	if c.jobLimiter != nil {
+		if err = c.jobLimiter.WaitN(c.ctx, int(r.ByteSize())); err != nil {
+			return 0, err
+		}
+	}
 	c.stats.increase(r.ByteSize())
 	return c.records.PushBack(r), nil
 }
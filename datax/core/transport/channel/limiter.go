@@ -0,0 +1,97 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package channel
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter 工作限速器，工作中所有任务组的通道共享同一个限速器，
+// 用于限制整个工作每秒的字节数和记录数
+type Limiter struct {
+	byteLimiter   *rate.Limiter
+	recordLimiter *rate.Limiter
+}
+
+// NewLimiter 通过每秒字节数b和每秒记录数r创建工作限速器，
+// 当b或者r不大于0时不限制对应的速度，都不大于0时返回nil
+func NewLimiter(b, r float64) *Limiter {
+	if b <= 0 && r <= 0 {
+		return nil
+	}
+	l := &Limiter{}
+	if b > 0 {
+		l.byteLimiter = newRateLimiter(b)
+	}
+	if r > 0 {
+		l.recordLimiter = newRateLimiter(r)
+	}
+	return l
+}
+
+// WaitN 等待直到允许一条n字节的记录通过，ctx取消时返回错误
+func (l *Limiter) WaitN(ctx context.Context, n int) (err error) {
+	if l.recordLimiter != nil {
+		if err = l.recordLimiter.Wait(ctx); err != nil {
+			return
+		}
+	}
+	if l.byteLimiter != nil {
+		return waitN(ctx, l.byteLimiter, n)
+	}
+	return
+}
+
+type limiterKey struct{}
+
+// WithLimiter 返回带有工作限速器l的上下文，通过该上下文创建的通道都会受到l的限制
+func WithLimiter(ctx context.Context, l *Limiter) context.Context {
+	if l == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, limiterKey{}, l)
+}
+
+// limiterFromContext 获取上下文ctx中的工作限速器，不存在时返回nil
+func limiterFromContext(ctx context.Context) *Limiter {
+	if ctx == nil {
+		return nil
+	}
+	l, _ := ctx.Value(limiterKey{}).(*Limiter)
+	return l
+}
+
+// newRateLimiter 创建每秒允许limit个令牌的限速器，桶容量为limit
+func newRateLimiter(limit float64) *rate.Limiter {
+	burst := int(limit)
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(limit), burst)
+}
+
+// waitN 等待限速器limiter允许n个令牌通过，n超过桶容量时分多次等待
+func waitN(ctx context.Context, limiter *rate.Limiter, n int) (err error) {
+	burst := limiter.Burst()
+	for n > burst {
+		if err = limiter.WaitN(ctx, burst); err != nil {
+			return
+		}
+		n -= burst
+	}
+	return limiter.WaitN(ctx, n)
+}
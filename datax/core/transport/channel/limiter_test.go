@@ -0,0 +1,151 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package channel
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestNewLimiter(t *testing.T) {
+	tests := []struct {
+		name       string
+		b          float64
+		r          float64
+		wantNil    bool
+		wantByte   bool
+		wantRecord bool
+	}{
+		{
+			name:    "1",
+			wantNil: true,
+		},
+		{
+			name:     "2",
+			b:        100,
+			wantByte: true,
+		},
+		{
+			name:       "3",
+			r:          100,
+			wantRecord: true,
+		},
+		{
+			name:       "4",
+			b:          100,
+			r:          0.5,
+			wantByte:   true,
+			wantRecord: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewLimiter(tt.b, tt.r)
+			if (l == nil) != tt.wantNil {
+				t.Fatalf("NewLimiter() = %v, wantNil %v", l, tt.wantNil)
+			}
+			if l == nil {
+				return
+			}
+			if (l.byteLimiter != nil) != tt.wantByte {
+				t.Errorf("NewLimiter() byteLimiter = %v, want %v", l.byteLimiter, tt.wantByte)
+			}
+			if (l.recordLimiter != nil) != tt.wantRecord {
+				t.Errorf("NewLimiter() recordLimiter = %v, want %v", l.recordLimiter, tt.wantRecord)
+			}
+		})
+	}
+}
+
+func TestWithLimiter(t *testing.T) {
+	ctx := context.TODO()
+	if got := WithLimiter(ctx, nil); got != ctx {
+		t.Errorf("WithLimiter() = %v, want %v", got, ctx)
+	}
+	if got := limiterFromContext(ctx); got != nil {
+		t.Errorf("limiterFromContext() = %v, want nil", got)
+	}
+	l := NewLimiter(100, 100)
+	if got := limiterFromContext(WithLimiter(ctx, l)); got != l {
+		t.Errorf("limiterFromContext() = %v, want %v", got, l)
+	}
+	if got := NewChannel(WithLimiter(ctx, l), nil).jobLimiter; got != l {
+		t.Errorf("NewChannel() jobLimiter = %v, want %v", got, l)
+	}
+}
+
+func TestLimiter_WaitN(t *testing.T) {
+	//超过桶容量的字节数分多次等待
+	l := NewLimiter(1000, 0)
+	start := time.Now()
+	if err := l.WaitN(context.TODO(), 1500); err != nil {
+		t.Fatalf("WaitN() error = %v", err)
+	}
+	if d := time.Since(start); d < 400*time.Millisecond {
+		t.Errorf("WaitN() duration = %v, want at least 400ms", d)
+	}
+
+	l = NewLimiter(0, 1)
+	if err := l.WaitN(context.TODO(), 1); err != nil {
+		t.Fatalf("WaitN() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	if err := l.WaitN(ctx, 1); err == nil {
+		t.Errorf("WaitN() error = nil")
+	}
+
+	l = NewLimiter(10, 0)
+	ctx, cancel = context.WithCancel(context.TODO())
+	cancel()
+	if err := l.WaitN(ctx, 100); err == nil {
+		t.Errorf("WaitN() error = nil")
+	}
+}
+
+func TestChannelWithJobLimiter(t *testing.T) {
+	//两个通道共享每秒100条记录的工作限速器
+	ctx := WithLimiter(context.TODO(), NewLimiter(0, 100))
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		ch := NewChannel(ctx, nil)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for {
+				r, _ := ch.Pop()
+				if _, ok := r.(*element.TerminateRecord); ok {
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 74; j++ {
+				ch.Push(element.NewDefaultRecord())
+			}
+			ch.PushTerminate()
+		}()
+	}
+	wg.Wait()
+	if d := time.Since(start); d < 400*time.Millisecond {
+		t.Errorf("duration = %v, want at least 400ms", d)
+	}
+}
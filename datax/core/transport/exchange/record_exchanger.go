@@ -17,9 +17,11 @@ package exchange
 import (
 	"errors"
 
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
 	"github.com/Breeze0806/go-etl/datax/core/transport/channel"
 	"github.com/Breeze0806/go-etl/datax/transform"
 	"github.com/Breeze0806/go-etl/element"
+	"go.uber.org/atomic"
 )
 
 // 错误枚举
@@ -29,11 +31,23 @@ var (
 	ErrShutdown  = errors.New("exchange is shutdowned")
 )
 
+// StatsJSON 记录交换器的JSON统计信息
+type StatsJSON struct {
+	FilteredRecord int64 `json:"filteredRecord"` //被转化器过滤的记录数
+	WrittenRecord  int64 `json:"writtenRecord"`  //交给写入器的记录数
+	WrittenByte    int64 `json:"writtenByte"`    //交给写入器的字节数
+}
+
 // RecordExchanger 记录交换器
 type RecordExchanger struct {
 	tran       transform.Transformer
 	ch         *channel.Channel
+	collector  plugin.TaskCollector
 	isShutdown bool
+
+	filteredRecord atomic.Int64
+	writtenRecord  atomic.Int64
+	writtenByte    atomic.Int64
 }
 
 // NewRecordExchangerWithoutTransformer 生成不带转化器的记录交换器
@@ -49,25 +63,53 @@ func NewRecordExchanger(ch *channel.Channel, tran transform.Transformer) *Record
 	}
 }
 
-// GetFromReader 从Reader中获取记录
+// SetTaskCollector 设置任务收集器collector，设置后转化失败的记录会作为脏数据
+// 交给collector并被跳过，否则转化失败时会报错
+func (r *RecordExchanger) SetTaskCollector(collector plugin.TaskCollector) {
+	r.collector = collector
+}
+
+// GetFromReader 从Reader中获取经过转化器转化的记录，被转化器过滤的记录会被跳过
 // 当交换器关闭，通道为空或者收到终止消息也会报错
 func (r *RecordExchanger) GetFromReader() (newRecord element.Record, err error) {
-	if r.isShutdown {
-		return nil, ErrShutdown
-	}
-	record, ok := r.ch.Pop()
-	if !ok {
-		return nil, ErrEmpty
-	}
+	for {
+		if r.isShutdown {
+			return nil, ErrShutdown
+		}
+		record, ok := r.ch.Pop()
+		if !ok {
+			return nil, ErrEmpty
+		}
 
-	switch record.(type) {
-	case *element.TerminateRecord:
-		return nil, ErrTerminate
-	default:
-		if newRecord, err = r.tran.DoTransform(record); err != nil {
-			return nil, err
+		switch record.(type) {
+		case *element.TerminateRecord:
+			return nil, ErrTerminate
+		default:
+			if newRecord, err = r.tran.DoTransform(record); err != nil {
+				if r.collector == nil {
+					return nil, err
+				}
+				r.collector.CollectDirtyRecordWithError(record, err)
+				newRecord, err = nil, nil
+				continue
+			}
+			if newRecord == nil {
+				r.filteredRecord.Inc()
+				continue
+			}
+			r.writtenRecord.Inc()
+			r.writtenByte.Add(newRecord.ByteSize())
+			return
 		}
-		return
+	}
+}
+
+// StatsJSON 返回记录交换器的统计信息
+func (r *RecordExchanger) StatsJSON() StatsJSON {
+	return StatsJSON{
+		FilteredRecord: r.filteredRecord.Load(),
+		WrittenRecord:  r.writtenRecord.Load(),
+		WrittenByte:    r.writtenByte.Load(),
 	}
 }
 
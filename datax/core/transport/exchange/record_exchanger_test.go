@@ -16,6 +16,7 @@ package exchange
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 
@@ -105,3 +106,116 @@ func TestRecordExchanger(t *testing.T) {
 		t.Errorf("GetFromReader() err = %v  want %v", err, ErrTerminate)
 	}
 }
+
+type mockFilterTransformer struct{}
+
+func (m *mockFilterTransformer) DoTransform(record element.Record) (element.Record, error) {
+	if record.(*mockRecord).i%2 == 0 {
+		return nil, nil
+	}
+	return record, nil
+}
+
+func TestRecordExchanger_Filter(t *testing.T) {
+	ch := channel.NewChannel(context.TODO(), nil)
+	defer ch.Close()
+	re := NewRecordExchanger(ch, &mockFilterTransformer{})
+	defer re.Shutdown()
+	for i := 1; i <= 10; i++ {
+		re.SendWriter(&mockRecord{
+			i: i,
+		})
+	}
+	re.Terminate()
+	for i := 1; i <= 10; i += 2 {
+		r, err := re.GetFromReader()
+		if err != nil {
+			t.Fatalf("GetFromReader() err = %v", err)
+		}
+		if r.(*mockRecord).i != i {
+			t.Errorf("GetFromReader() = %v  want %v", r.(*mockRecord).i, i)
+		}
+	}
+	_, err := re.GetFromReader()
+	if err != ErrTerminate {
+		t.Errorf("GetFromReader() err = %v  want %v", err, ErrTerminate)
+	}
+	want := StatsJSON{
+		FilteredRecord: 5,
+		WrittenRecord:  5,
+	}
+	if got := re.StatsJSON(); got != want {
+		t.Errorf("StatsJSON() = %v  want %v", got, want)
+	}
+}
+
+type mockErrorTransformer struct{}
+
+func (m *mockErrorTransformer) DoTransform(record element.Record) (element.Record, error) {
+	if record.(*mockRecord).i%2 == 0 {
+		return nil, errors.New("mock error")
+	}
+	return record, nil
+}
+
+type mockTaskCollector struct {
+	records []element.Record
+}
+
+func (m *mockTaskCollector) CollectDirtyRecordWithError(record element.Record, err error) {
+	m.CollectDirtyRecord(record, err, "")
+}
+
+func (m *mockTaskCollector) CollectDirtyRecordWithMsg(record element.Record, msgErr string) {
+	m.CollectDirtyRecord(record, nil, msgErr)
+}
+
+func (m *mockTaskCollector) CollectDirtyRecord(record element.Record, err error, msgErr string) {
+	m.records = append(m.records, record)
+}
+
+func (m *mockTaskCollector) CollectMessage(key string, value string) {}
+
+func TestRecordExchanger_DirtyRecord(t *testing.T) {
+	ch := channel.NewChannel(context.TODO(), nil)
+	defer ch.Close()
+	re := NewRecordExchanger(ch, &mockErrorTransformer{})
+	defer re.Shutdown()
+	re.SendWriter(&mockRecord{
+		i: 2,
+	})
+	if _, err := re.GetFromReader(); err == nil {
+		t.Fatalf("GetFromReader() err = %v", err)
+	}
+
+	collector := &mockTaskCollector{}
+	re.SetTaskCollector(collector)
+	for i := 1; i <= 10; i++ {
+		re.SendWriter(&mockRecord{
+			i: i,
+		})
+	}
+	re.Terminate()
+	for i := 1; i <= 10; i += 2 {
+		r, err := re.GetFromReader()
+		if err != nil {
+			t.Fatalf("GetFromReader() err = %v", err)
+		}
+		if r.(*mockRecord).i != i {
+			t.Errorf("GetFromReader() = %v  want %v", r.(*mockRecord).i, i)
+		}
+	}
+	_, err := re.GetFromReader()
+	if err != ErrTerminate {
+		t.Errorf("GetFromReader() err = %v  want %v", err, ErrTerminate)
+	}
+	if len(collector.records) != 5 {
+		t.Errorf("dirty records = %v  want %v", len(collector.records), 5)
+	}
+	want := StatsJSON{
+		WrittenRecord: 5,
+	}
+	if got := re.StatsJSON(); got != want {
+		t.Errorf("StatsJSON() = %v  want %v", got, want)
+	}
+}
@@ -79,3 +79,19 @@ func (e *Engine) Start() (err error) {
 
 	return e.Container.Start()
 }
+
+// Report 获取工作结束时的统计报告，不是以工作为单位工作或者工作未结束时为nil
+func (e *Engine) Report() *job.Report {
+	if c, ok := e.Container.(*job.Container); ok && c != nil {
+		return c.Report()
+	}
+	return nil
+}
+
+// CheckReport 获取预检查报告，不是以工作为单位工作或者未开启预检查时为nil
+func (e *Engine) CheckReport() *job.CheckReport {
+	if c, ok := e.Container.(*job.Container); ok && c != nil {
+		return c.CheckReport()
+	}
+	return nil
+}
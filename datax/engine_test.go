@@ -171,3 +171,119 @@ func TestEngine_Start(t *testing.T) {
 		})
 	}
 }
+
+func TestEngine_Report(t *testing.T) {
+	tests := []struct {
+		name       string
+		e          *Engine
+		wantReport bool
+	}{
+		{
+			name: "1",
+			e: NewEngine(context.TODO(), testJSONFromString(
+				`{
+					"core": {
+						"container":{
+							"model":"job"
+						}
+					}
+				}`)),
+			wantReport: false,
+		},
+		{
+			name: "2",
+			e: NewEngine(context.TODO(), testJSONFromString(
+				`{
+					"core": {
+						"container":{
+							"model":"taskGroup"
+						}
+					}
+				}`)),
+			wantReport: false,
+		},
+		{
+			name: "3",
+			e: NewEngine(context.TODO(), testJSONFromString(
+				`{
+					"core": {
+						"container":{
+							"model":"job",
+							"job":{
+								"id":1
+							}
+						}
+					}
+				}`)),
+			wantReport: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.Start()
+			if got := tt.e.Report(); (got != nil) != tt.wantReport {
+				t.Errorf("Engine.Report() = %v, wantReport %v", got, tt.wantReport)
+			}
+		})
+	}
+}
+
+func TestEngine_CheckReport(t *testing.T) {
+	tests := []struct {
+		name            string
+		e               *Engine
+		wantCheckReport bool
+	}{
+		{
+			name: "1",
+			e: NewEngine(context.TODO(), testJSONFromString(
+				`{
+					"core": {
+						"container":{
+							"model":"taskGroup"
+						}
+					}
+				}`)),
+			wantCheckReport: false,
+		},
+		{
+			name: "2",
+			e: NewEngine(context.TODO(), testJSONFromString(
+				`{
+					"core": {
+						"container":{
+							"model":"job",
+							"job":{
+								"id":1
+							}
+						}
+					}
+				}`)),
+			wantCheckReport: false,
+		},
+		{
+			name: "3",
+			e: NewEngine(context.TODO(), testJSONFromString(
+				`{
+					"core": {
+						"container":{
+							"model":"job",
+							"job":{
+								"id":1,
+								"check":true
+							}
+						}
+					}
+				}`)),
+			wantCheckReport: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.e.Start()
+			if got := tt.e.CheckReport(); (got != nil) != tt.wantCheckReport {
+				t.Errorf("Engine.CheckReport() = %v, wantCheckReport %v", got, tt.wantCheckReport)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 
 	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
 	//csv storage
 	"github.com/Breeze0806/go-etl/storage/stream/file/csv"
 )
@@ -25,6 +26,7 @@ import (
 // Config csv读入配置
 type Config struct {
 	csv.InConfig
+	file.PathConfig
 
 	Path []string `json:"path"`
 }
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
 )
 
 func TestNewConfig(t *testing.T) {
@@ -48,6 +49,20 @@ func TestNewConfig(t *testing.T) {
 				Path: []string{},
 			},
 		},
+		{
+			name: "3",
+			args: args{
+				conf: testJSONFromString(`{"path":["/data/in"],"recursive":true,"include":["*.csv.gz"],"exclude":["tmp_*"]}`),
+			},
+			wantC: &Config{
+				PathConfig: file.PathConfig{
+					Recursive: true,
+					Include:   []string{"*.csv.gz"},
+					Exclude:   []string{"tmp_*"},
+				},
+				Path: []string{"/data/in"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
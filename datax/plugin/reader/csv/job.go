@@ -42,9 +42,22 @@ func (j *Job) Init(ctx context.Context) (err error) {
 	return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginConf())
 }
 
-// Split 切分
+// PreCheck 预检查，检查文件是否可以读取
+func (j *Job) PreCheck(ctx context.Context) (err error) {
+	var paths []string
+	if paths, err = j.conf.Expand(j.conf.Path...); err != nil {
+		return err
+	}
+	return file.CheckReadable(paths...)
+}
+
+// Split 切分，path中的glob模式和目录会在此时展开成文件
 func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, err error) {
-	for _, v := range j.conf.Path {
+	var paths []string
+	if paths, err = j.conf.Expand(j.conf.Path...); err != nil {
+		return nil, err
+	}
+	for _, v := range paths {
 		conf, _ := config.NewJSONFromString("{}")
 		conf.Set("path", v)
 		conf.Set("content.0", j.conf.InConfig)
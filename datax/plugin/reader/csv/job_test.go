@@ -16,6 +16,10 @@ package csv
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -31,6 +35,16 @@ func testJSONFromString(json string) *config.JSON {
 }
 
 func TestJob_Split(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	for _, v := range []string{"a.csv", "b.csv", "c.txt"} {
+		if err = ioutil.WriteFile(filepath.Join(tmpDir, v), []byte("1"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
 	type args struct {
 		ctx    context.Context
 		number int
@@ -52,6 +66,25 @@ func TestJob_Split(t *testing.T) {
 				testJSONFromString(`{"path":"file1","content":[{"column":[],"encoding":"","delimiter":";","nullFormat":"","startRow":0,"comment":"","compress":""}]}`),
 			},
 		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[],"delimiter":";","encoding":""}`, filepath.Join(tmpDir, "*.csv"))),
+			args: args{
+				ctx: context.TODO(),
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(fmt.Sprintf(`{"path":%q,"content":[{"column":[],"encoding":"","delimiter":";","nullFormat":"","startRow":0,"comment":"","compress":""}]}`, filepath.Join(tmpDir, "a.csv"))),
+				testJSONFromString(fmt.Sprintf(`{"path":%q,"content":[{"column":[],"encoding":"","delimiter":";","nullFormat":"","startRow":0,"comment":"","compress":""}]}`, filepath.Join(tmpDir, "b.csv"))),
+			},
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[],"delimiter":";","encoding":"","exclude":["*"]}`, tmpDir)),
+			args: args{
+				ctx: context.TODO(),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -73,3 +106,53 @@ func TestJob_Split(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_PreCheck(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filename := filepath.Join(tmpDir, "file1")
+	if err = ioutil.WriteFile(filename, []byte("1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[],"delimiter":";","encoding":""}`, filename)),
+		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[],"delimiter":";","encoding":""}`, filepath.Join(tmpDir, "none", "file2"))),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[],"delimiter":";","encoding":""}`, tmpDir)),
+		},
+		{
+			name:    "4",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[],"delimiter":";","encoding":""}`, filepath.Join(tmpDir, "*.csv"))),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJob()
+			defer j.Destroy(context.TODO())
+
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); err != nil {
+				t.Fatalf("init fail. err: %v", err)
+			}
+			if err := j.PreCheck(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.PreCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
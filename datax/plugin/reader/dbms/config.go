@@ -15,22 +15,27 @@
 package dbms
 
 import (
+	"bytes"
 	"encoding/json"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/pingcap/errors"
 )
 
 // Config 关系型数据读入器配置
 type Config interface {
-	GetUsername() string               //获取用户名
-	GetPassword() string               //获取密码
-	GetURL() string                    //获取连接url
-	GetColumns() []Column              //获取列信息
-	GetBaseTable() *database.BaseTable //获取表信息
-	GetWhere() string                  //获取查询条件
-	GetSplitConfig() SplitConfig       //获取切分配置
-	GetQuerySQL() []string             //获取查询sql
+	GetUsername() string                                          //获取用户名
+	GetPassword() string                                          //获取密码
+	GetURL() string                                               //获取连接url
+	GetColumns() []Column                                         //获取列信息
+	GetBaseTable() *database.BaseTable                            //获取表信息
+	GetBaseTables() []*database.BaseTable                         //获取多表信息，只配置单张表时为空
+	GetWhere() string                                             //获取查询条件
+	GetSplitConfig() SplitConfig                                  //获取切分配置
+	GetQuerySQL() []string                                        //获取查询sql
+	GetIncrementalConfig() IncrementalConfig                      //获取增量同步配置
+	GetTypeMapping(dialect string) (*database.TypeMapping, error) //获取数据库方言dialect被工作覆盖后的类型映射
 }
 
 // Column 列信息
@@ -50,13 +55,15 @@ func (b *BaseColumn) GetName() string {
 
 // BaseConfig 基础关系型数据读入器配置
 type BaseConfig struct {
-	Username   string      `json:"username"`   //用户名
-	Password   string      `json:"password"`   //密码
-	Column     []string    `json:"column"`     //列信息
-	Connection ConnConfig  `json:"connection"` //连接信息
-	Where      string      `json:"where"`      //查询条件
-	Split      SplitConfig `json:"split"`      //切分键
-	QuerySQL   []string    `json:"querySql"`   //查询sql
+	Username    string                      `json:"username"`    //用户名
+	Password    string                      `json:"password"`    //密码
+	Column      []string                    `json:"column"`      //列信息
+	Connection  ConnConfig                  `json:"connection"`  //连接信息
+	Where       string                      `json:"where"`       //查询条件
+	Split       SplitConfig                 `json:"split"`       //切分键
+	QuerySQL    []string                    `json:"querySql"`    //查询sql
+	Incremental IncrementalConfig           `json:"incremental"` //增量同步配置
+	TypeMapping *database.TypeMappingConfig `json:"typeMapping"` //类型映射配置
 }
 
 // NewBaseConfig 通过json配置conf获取基础关系型数据读入器配置
@@ -66,6 +73,12 @@ func NewBaseConfig(conf *config.JSON) (c *BaseConfig, err error) {
 	if err != nil {
 		return nil, err
 	}
+	if err = c.Incremental.validate(); err != nil {
+		return nil, err
+	}
+	if c.Incremental.enabled() && len(c.QuerySQL) > 0 {
+		return nil, errors.New("incremental can not be used with querySql")
+	}
 	return
 }
 
@@ -99,6 +112,20 @@ func (b *BaseConfig) GetBaseTable() *database.BaseTable {
 	return database.NewBaseTable(b.Connection.Table.Db, b.Connection.Table.Schema, b.Connection.Table.Name)
 }
 
+// GetBaseTables 获取多表信息，table配置为数组或者表名带有通配符*或者%时为多表，只配置单张表时为空
+func (b *BaseConfig) GetBaseTables() (tables []*database.BaseTable) {
+	if len(b.Connection.Tables) == 0 {
+		if database.IsTablePattern(b.Connection.Table.Name) {
+			tables = append(tables, b.GetBaseTable())
+		}
+		return
+	}
+	for _, v := range b.Connection.Tables {
+		tables = append(tables, database.NewBaseTable(v.Db, v.Schema, v.Name))
+	}
+	return
+}
+
 // GetWhere 获取查询条件
 func (b *BaseConfig) GetWhere() string {
 	return b.Where
@@ -114,10 +141,46 @@ func (b *BaseConfig) GetQuerySQL() []string {
 	return b.QuerySQL
 }
 
+// GetIncrementalConfig 获取增量同步配置
+func (b *BaseConfig) GetIncrementalConfig() IncrementalConfig {
+	return b.Incremental
+}
+
+// setIncrementalField 设置增量列字段f
+func (b *BaseConfig) setIncrementalField(f database.Field) {
+	b.Incremental.incField = f
+}
+
+// GetTypeMapping 获取数据库方言dialect被工作覆盖后的类型映射
+func (b *BaseConfig) GetTypeMapping(dialect string) (*database.TypeMapping, error) {
+	return database.GetTypeMapping(dialect).Override(b.TypeMapping)
+}
+
 // ConnConfig 连接配置
 type ConnConfig struct {
-	URL   string      `json:"url"`   //连接数据库
-	Table TableConfig `json:"table"` //表配置
+	URL    string        `json:"url"`   //连接数据库
+	Table  TableConfig   `json:"table"` //表配置
+	Tables []TableConfig `json:"-"`     //多表配置，table配置为数组时使用
+}
+
+// UnmarshalJSON 反序列化连接配置，table既可以配置为单张表，也可以配置为多张表组成的数组
+func (c *ConnConfig) UnmarshalJSON(data []byte) (err error) {
+	var conf struct {
+		URL   string          `json:"url"`
+		Table json.RawMessage `json:"table"`
+	}
+	if err = json.Unmarshal(data, &conf); err != nil {
+		return
+	}
+	c.URL = conf.URL
+	table := bytes.TrimSpace(conf.Table)
+	if len(table) == 0 || string(table) == "null" {
+		return
+	}
+	if table[0] == '[' {
+		return json.Unmarshal(table, &c.Tables)
+	}
+	return json.Unmarshal(table, &c.Table)
 }
 
 // TableConfig 表配置
@@ -0,0 +1,135 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+func TestConnConfig_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    ConnConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			data: `{"url":"url","table":{"db":"db","schema":"schema","name":"name"}}`,
+			want: ConnConfig{
+				URL:   "url",
+				Table: TableConfig{Db: "db", Schema: "schema", Name: "name"},
+			},
+		},
+		{
+			name: "2",
+			data: `{"url":"url","table":[{"schema":"schema","name":"a"},{"name":"b_%"}]}`,
+			want: ConnConfig{
+				URL: "url",
+				Tables: []TableConfig{
+					{Schema: "schema", Name: "a"},
+					{Name: "b_%"},
+				},
+			},
+		},
+		{
+			name: "3",
+			data: `{"url":"url"}`,
+			want: ConnConfig{
+				URL: "url",
+			},
+		},
+		{
+			name:    "4",
+			data:    `{"url":"url","table":"name"}`,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			data:    `{"url":1}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ConnConfig
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConnConfig.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConnConfig.UnmarshalJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseConfig_GetBaseTables(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *BaseConfig
+		want []*database.BaseTable
+	}{
+		{
+			name: "1",
+			b: &BaseConfig{
+				Connection: ConnConfig{
+					Table: TableConfig{Db: "db", Name: "name"},
+				},
+			},
+		},
+		{
+			name: "2",
+			b: &BaseConfig{
+				Connection: ConnConfig{
+					Table: TableConfig{Schema: "schema", Name: "*"},
+				},
+			},
+			want: []*database.BaseTable{
+				database.NewBaseTable("", "schema", "*"),
+			},
+		},
+		{
+			name: "3",
+			b: &BaseConfig{
+				Connection: ConnConfig{
+					Tables: []TableConfig{
+						{Db: "db", Name: "a"},
+						{Db: "db", Name: "b_%"},
+					},
+				},
+			},
+			want: []*database.BaseTable{
+				database.NewBaseTable("db", "", "a"),
+				database.NewBaseTable("db", "", "b_%"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.GetBaseTables(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BaseConfig.GetBaseTables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -29,6 +29,9 @@ type DbHandler interface {
 	SplitParam(config Config, querier Querier) database.Parameter    //通过关系型数据库输入配置config和查询器querier获取切分表参数
 	MinParam(config Config, table database.Table) database.Parameter //通过关系型数据库输入配置config和表Table获取切分最小值参数
 	MaxParam(config Config, table database.Table) database.Parameter //通过关系型数据库输入配置config和表询器Table获取切分最大值参数
+
+	IncrementalParam(config Config, querier Querier) database.Parameter         //通过关系型数据库输入配置config和查询器querier获取增量列表参数
+	IncrementalMaxParam(config Config, table database.Table) database.Parameter //通过关系型数据库输入配置config和表Table获取增量列最大值参数
 }
 
 // BaseDbHandler 基础数据库句柄
@@ -74,3 +77,13 @@ func (d *BaseDbHandler) MinParam(config Config, table database.Table) database.P
 func (d *BaseDbHandler) MaxParam(config Config, table database.Table) database.Parameter {
 	return NewMaxParam(config, table, d.opts)
 }
+
+// IncrementalParam 通过关系型数据库输入配置config和查询器querier获取增量列表参数
+func (d *BaseDbHandler) IncrementalParam(config Config, querier Querier) database.Parameter {
+	return NewIncrementalParam(config, querier, d.opts)
+}
+
+// IncrementalMaxParam 通过关系型数据库输入配置config和表Table获取增量列最大值参数
+func (d *BaseDbHandler) IncrementalMaxParam(config Config, table database.Table) database.Parameter {
+	return NewIncrementalMaxParam(config, table, d.opts)
+}
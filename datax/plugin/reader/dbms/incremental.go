@@ -0,0 +1,310 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/pingcap/errors"
+)
+
+// IncrementalConfig 增量同步配置，每次只读取增量列大于上次同步高水位的记录，
+// 同步成功后将本次的高水位保存到状态文件中
+type IncrementalConfig struct {
+	Column    string `json:"column"`    //增量列，时间戳或者单调递增的编号
+	Type      string `json:"type"`      //增量列类型 bigInt, string, time，为空时通过列的类型判断
+	StateFile string `json:"stateFile"` //保存高水位的状态文件
+	Initial   string `json:"initial"`   //状态文件不存在时的初始高水位，为空时读取全部记录
+	Left      string `json:"left"`      //上次同步的高水位，不包含，由切分时设置
+	Right     string `json:"right"`     //本次同步的高水位，包含，由切分时设置
+
+	incField database.Field //增量列字段，增量列不在读取的列中时使用
+}
+
+// incrementalFieldSetter 增量列字段设置器
+type incrementalFieldSetter interface {
+	setIncrementalField(f database.Field) //设置增量列字段f
+}
+
+// enabled 是否开启增量同步
+func (i IncrementalConfig) enabled() bool {
+	return i.Column != ""
+}
+
+// validate 校验
+func (i IncrementalConfig) validate() error {
+	if !i.enabled() {
+		return nil
+	}
+	if i.StateFile == "" {
+		return errors.New("incremental stateFile is empty")
+	}
+	switch element.ColumnType(i.Type) {
+	case "", element.TypeBigInt, element.TypeString, element.TypeTime:
+	default:
+		return errors.Errorf("incremental type %v does not support", i.Type)
+	}
+	return nil
+}
+
+// splitRange 增量列值范围，时间使用默认时间格式
+func (i IncrementalConfig) splitRange() SplitRange {
+	return SplitRange{
+		Type:   i.Type,
+		Layout: element.DefaultTimeFormat,
+		Left:   i.Left,
+		Right:  i.Right,
+	}
+}
+
+// field 从表table中获取增量列，列名优先精确匹配，其次忽略大小写匹配，
+// 表中不存在时使用设置的增量列字段
+func (i IncrementalConfig) field(table database.Table) (database.Field, error) {
+	for _, v := range table.Fields() {
+		if v.Name() == i.Column {
+			return v, nil
+		}
+	}
+	for _, v := range table.Fields() {
+		if strings.EqualFold(v.Name(), i.Column) {
+			return v, nil
+		}
+	}
+	if i.incField != nil {
+		return i.incField, nil
+	}
+	return nil, errors.Errorf("incremental column %v does not exist", i.Column)
+}
+
+// where 在查询条件where后追加增量条件，增量条件的占位符从第offset+1个开始
+func (i IncrementalConfig) where(where string, table database.Table, offset int) (string, error) {
+	if !i.enabled() || (i.Left == "" && i.Right == "") {
+		return where, nil
+	}
+	f, err := i.field(table)
+	if err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if where != "" {
+		buf.WriteString("(")
+		buf.WriteString(where)
+		buf.WriteString(") and ")
+	}
+	if i.Left != "" {
+		offset++
+		fmt.Fprintf(buf, "%s > %s", f.Quoted(), f.BindVar(offset))
+	}
+	if i.Right != "" {
+		if i.Left != "" {
+			buf.WriteString(" and ")
+		}
+		offset++
+		fmt.Fprintf(buf, "%s <= %s", f.Quoted(), f.BindVar(offset))
+	}
+	return buf.String(), nil
+}
+
+// args 增量条件的查询参数
+func (i IncrementalConfig) args(table database.Table) (a []interface{}, err error) {
+	if !i.enabled() || (i.Left == "" && i.Right == "") {
+		return nil, nil
+	}
+	var f database.Field
+	if f, err = i.field(table); err != nil {
+		return nil, err
+	}
+	r := i.splitRange()
+	for _, v := range []string{i.Left, i.Right} {
+		if v == "" {
+			continue
+		}
+		var c element.Column
+		if c, err = r.fetchColumn(i.Column, v); err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if value, err = f.Valuer(c).Value(); err != nil {
+			return nil, err
+		}
+		a = append(a, value)
+	}
+	return
+}
+
+// columnType 获取增量列field的类型
+func (i IncrementalConfig) columnType(f database.Field) (string, error) {
+	if i.Type != "" {
+		return i.Type, nil
+	}
+	if typ, ok := f.Type().(database.ValuerGoType); ok {
+		switch typ.GoType() {
+		case database.GoTypeInt64:
+			return string(element.TypeBigInt), nil
+		case database.GoTypeString:
+			return string(element.TypeString), nil
+		case database.GoTypeTime:
+			return string(element.TypeTime), nil
+		}
+	}
+	return "", errors.Errorf("the type of incremental column %v is unknown, please set type", i.Column)
+}
+
+// columnValue 将增量列的值c转化为字符串
+func (i IncrementalConfig) columnValue(c element.Column) (string, error) {
+	switch element.ColumnType(i.Type) {
+	case element.TypeBigInt:
+		v, err := c.AsBigInt()
+		if err != nil {
+			return "", err
+		}
+		return v.String(), nil
+	case element.TypeTime:
+		t, err := c.AsTime()
+		if err != nil {
+			return "", err
+		}
+		return t.Format(element.DefaultTimeFormat), nil
+	}
+	return c.AsString()
+}
+
+// IncrementalState 增量同步状态，保存在状态文件中
+type IncrementalState struct {
+	Column string `json:"column"` //增量列
+	Type   string `json:"type"`   //增量列类型
+	Value  string `json:"value"`  //高水位
+}
+
+// LoadIncrementalState 从状态文件filename中读取增量同步状态，文件不存在时返回nil
+func LoadIncrementalState(filename string) (state *IncrementalState, err error) {
+	var data []byte
+	if data, err = ioutil.ReadFile(filename); err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	state = &IncrementalState{}
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrapf(err, "state file %v is invalid", filename)
+	}
+	return
+}
+
+// Save 将增量同步状态保存到状态文件filename中，先写入临时文件再重命名，
+// 避免写入中断导致状态文件损坏
+func (s *IncrementalState) Save(filename string) (err error) {
+	var data []byte
+	if data, err = json.Marshal(s); err != nil {
+		return
+	}
+	if dir := filepath.Dir(filename); dir != "" {
+		if err = os.MkdirAll(dir, 0755); err != nil {
+			return
+		}
+	}
+	tmp := filename + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	return os.Rename(tmp, filename)
+}
+
+// IncrementalParam 增量列表参数
+type IncrementalParam struct {
+	*database.BaseParam
+
+	Config Config
+}
+
+// NewIncrementalParam 获取表参数配置config，通过表参数获取对应数据库的表table和事务选项opts获取增量列表参数
+func NewIncrementalParam(config Config, table TableParamTable, opts *sql.TxOptions) *IncrementalParam {
+	return &IncrementalParam{
+		BaseParam: database.NewBaseParam(table.Table(config.GetBaseTable()), opts),
+
+		Config: config,
+	}
+}
+
+// Query 获取查询语句，查询所有列以便按照数据库中的列名找到增量列
+func (i *IncrementalParam) Query(_ []element.Record) (string, error) {
+	buf := bytes.NewBufferString("select * from ")
+	buf.WriteString(i.Table().Quoted())
+	buf.WriteString(" where 1 = 2")
+
+	return buf.String(), nil
+}
+
+// Agrs 获取查询参数
+func (i *IncrementalParam) Agrs(_ []element.Record) ([]interface{}, error) {
+	return nil, nil
+}
+
+// IncrementalMaxParam 增量列最大值参数，只查询大于上次同步高水位的记录
+type IncrementalMaxParam struct {
+	*database.BaseParam
+
+	Config Config
+}
+
+// NewIncrementalMaxParam 通过关系型数据库输入配置config，对应数据库表table和事务选项opts获取增量列最大值参数
+func NewIncrementalMaxParam(config Config, table database.Table, opts *sql.TxOptions) *IncrementalMaxParam {
+	return &IncrementalMaxParam{
+		BaseParam: database.NewBaseParam(table, opts),
+
+		Config: config,
+	}
+}
+
+// Query 获取查询语句
+func (i *IncrementalMaxParam) Query(_ []element.Record) (string, error) {
+	inc := i.Config.GetIncrementalConfig()
+	inc.Right = ""
+	f, err := inc.field(i.Table())
+	if err != nil {
+		return "", err
+	}
+	where, err := inc.where(i.Config.GetWhere(), i.Table(), 0)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.NewBufferString("select max(")
+	buf.WriteString(f.Quoted())
+	buf.WriteString(") from ")
+	buf.WriteString(i.Table().Quoted())
+	if where != "" {
+		buf.WriteString(" where ")
+		buf.WriteString(where)
+	}
+	return buf.String(), nil
+}
+
+// Agrs 获取查询参数
+func (i *IncrementalMaxParam) Agrs(_ []element.Record) ([]interface{}, error) {
+	inc := i.Config.GetIncrementalConfig()
+	inc.Right = ""
+	return inc.args(i.Table())
+}
@@ -0,0 +1,482 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+func testIncrementalTable(goType database.GoType) *MockTable {
+	t := NewMockTable(database.NewBaseTable("db", "schema", "table"))
+	t.AddField(database.NewBaseField(0, "f1", NewMockFieldType(goType)))
+	return t
+}
+
+func TestIncrementalConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		i       IncrementalConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			i:    IncrementalConfig{},
+		},
+		{
+			name: "2",
+			i: IncrementalConfig{
+				Column: "f1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "3",
+			i: IncrementalConfig{
+				Column:    "f1",
+				StateFile: "state.json",
+				Type:      "time",
+			},
+		},
+		{
+			name: "4",
+			i: IncrementalConfig{
+				Column:    "f1",
+				StateFile: "state.json",
+				Type:      "decimal",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.i.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("IncrementalConfig.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIncrementalConfig_whereAndArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		i         IncrementalConfig
+		where     string
+		offset    int
+		wantWhere string
+		wantArgs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			i:         IncrementalConfig{},
+			where:     "a < 1",
+			wantWhere: "a < 1",
+		},
+		{
+			name: "2",
+			i: IncrementalConfig{
+				Column: "f1",
+				Type:   "bigInt",
+			},
+			where:     "a < 1",
+			wantWhere: "a < 1",
+		},
+		{
+			name: "3",
+			i: IncrementalConfig{
+				Column: "f1",
+				Type:   "bigInt",
+				Left:   "10",
+				Right:  "20",
+			},
+			where:     "a < 1",
+			offset:    2,
+			wantWhere: "(a < 1) and f1 > $3 and f1 <= $4",
+			wantArgs:  []interface{}{int64(10), int64(20)},
+		},
+		{
+			name: "4",
+			i: IncrementalConfig{
+				Column: "f1",
+				Type:   "bigInt",
+				Right:  "20",
+			},
+			wantWhere: "f1 <= $1",
+			wantArgs:  []interface{}{int64(20)},
+		},
+		{
+			name: "5",
+			i: IncrementalConfig{
+				Column: "f2",
+				Type:   "bigInt",
+				Right:  "20",
+			},
+			wantErr: true,
+		},
+		{
+			name: "6",
+			i: IncrementalConfig{
+				Column: "f1",
+				Type:   "bigInt",
+				Left:   "a",
+			},
+			wantWhere: "f1 > $1",
+			wantErr:   true,
+		},
+		{
+			name: "7",
+			i: IncrementalConfig{
+				Column: "F1",
+				Type:   "bigInt",
+				Left:   "10",
+			},
+			wantWhere: "f1 > $1",
+			wantArgs:  []interface{}{int64(10)},
+		},
+		{
+			name: "8",
+			i: IncrementalConfig{
+				Column: "f2",
+				Type:   "bigInt",
+				Left:   "10",
+				incField: NewMockField(database.NewBaseField(0, "f2",
+					NewMockFieldType(database.GoTypeInt64)), NewMockFieldType(database.GoTypeInt64)),
+			},
+			where:     "a < 1",
+			wantWhere: "(a < 1) and f2 > $1",
+			wantArgs:  []interface{}{int64(10)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := testIncrementalTable(database.GoTypeInt64)
+			gotWhere, err := tt.i.where(tt.where, table, tt.offset)
+			if err == nil && gotWhere != tt.wantWhere {
+				t.Errorf("IncrementalConfig.where() = %v, want %v", gotWhere, tt.wantWhere)
+			}
+			var gotArgs []interface{}
+			if err == nil {
+				gotArgs, err = tt.i.args(table)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncrementalConfig.args() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("IncrementalConfig.args() = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestIncrementalConfig_columnTypeAndValue(t *testing.T) {
+	now := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	tests := []struct {
+		name      string
+		i         IncrementalConfig
+		goType    database.GoType
+		c         element.Column
+		wantType  string
+		wantValue string
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			i:         IncrementalConfig{Column: "f1"},
+			goType:    database.GoTypeInt64,
+			c:         element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(100), "f1", 0),
+			wantType:  "bigInt",
+			wantValue: "100",
+		},
+		{
+			name:      "2",
+			i:         IncrementalConfig{Column: "f1"},
+			goType:    database.GoTypeTime,
+			c:         element.NewDefaultColumn(element.NewTimeColumnValue(now), "f1", 0),
+			wantType:  "time",
+			wantValue: "2022-01-02 03:04:05.000000006Z",
+		},
+		{
+			name:      "3",
+			i:         IncrementalConfig{Column: "f1"},
+			goType:    database.GoTypeString,
+			c:         element.NewDefaultColumn(element.NewStringColumnValue("abc"), "f1", 0),
+			wantType:  "string",
+			wantValue: "abc",
+		},
+		{
+			name:      "4",
+			i:         IncrementalConfig{Column: "f1", Type: "string"},
+			goType:    database.GoTypeInt64,
+			c:         element.NewDefaultColumn(element.NewStringColumnValue("abc"), "f1", 0),
+			wantType:  "string",
+			wantValue: "abc",
+		},
+		{
+			name:    "5",
+			i:       IncrementalConfig{Column: "f1"},
+			goType:  database.GoTypeBytes,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := testIncrementalTable(tt.goType).Fields()[0]
+			typ, err := tt.i.columnType(f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IncrementalConfig.columnType() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if typ != tt.wantType {
+				t.Errorf("IncrementalConfig.columnType() = %v, want %v", typ, tt.wantType)
+			}
+			tt.i.Type = typ
+			value, err := tt.i.columnValue(tt.c)
+			if err != nil {
+				t.Fatalf("IncrementalConfig.columnValue() error = %v", err)
+			}
+			if value != tt.wantValue {
+				t.Errorf("IncrementalConfig.columnValue() = %v, want %v", value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestIncrementalState(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "incremental")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filename := filepath.Join(tmpDir, "state", "state.json")
+
+	state, err := LoadIncrementalState(filename)
+	if err != nil || state != nil {
+		t.Fatalf("LoadIncrementalState() = %v %v, want nil", state, err)
+	}
+
+	want := &IncrementalState{
+		Column: "f1",
+		Type:   "bigInt",
+		Value:  "100",
+	}
+	if err = want.Save(filename); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if state, err = LoadIncrementalState(filename); err != nil {
+		t.Fatalf("LoadIncrementalState() error = %v", err)
+	}
+	if !reflect.DeepEqual(state, want) {
+		t.Errorf("LoadIncrementalState() = %v, want %v", state, want)
+	}
+
+	if err = ioutil.WriteFile(filename, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = LoadIncrementalState(filename); err == nil {
+		t.Errorf("LoadIncrementalState() error = %v, wantErr true", err)
+	}
+}
+
+func TestIncrementalParam_Query(t *testing.T) {
+	config := &BaseConfig{
+		Connection: ConnConfig{
+			Table: TableConfig{
+				Db:     "db",
+				Schema: "schema",
+				Name:   "table",
+			},
+		},
+		Incremental: IncrementalConfig{
+			Column: "f1",
+		},
+	}
+	got, err := NewIncrementalParam(config, &MockQuerier{}, nil).Query(nil)
+	if err != nil {
+		t.Fatalf("IncrementalParam.Query() error = %v", err)
+	}
+	if want := "select * from db.schema.table where 1 = 2"; got != want {
+		t.Errorf("IncrementalParam.Query() = %v, want %v", got, want)
+	}
+}
+
+func TestIncrementalMaxParam(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *BaseConfig
+		want     string
+		wantArgs []interface{}
+	}{
+		{
+			name: "1",
+			config: &BaseConfig{
+				Incremental: IncrementalConfig{
+					Column: "f1",
+					Type:   "bigInt",
+				},
+			},
+			want: "select max(f1) from db.schema.table",
+		},
+		{
+			name: "2",
+			config: &BaseConfig{
+				Where: "a < 1",
+				Incremental: IncrementalConfig{
+					Column: "f1",
+					Type:   "bigInt",
+					Left:   "10",
+					Right:  "20",
+				},
+			},
+			want:     "select max(f1) from db.schema.table where (a < 1) and f1 > $1",
+			wantArgs: []interface{}{int64(10)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewIncrementalMaxParam(tt.config, testIncrementalTable(database.GoTypeInt64), nil)
+			got, err := p.Query(nil)
+			if err != nil {
+				t.Fatalf("IncrementalMaxParam.Query() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IncrementalMaxParam.Query() = %v, want %v", got, tt.want)
+			}
+			gotArgs, err := p.Agrs(nil)
+			if err != nil {
+				t.Fatalf("IncrementalMaxParam.Agrs() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("IncrementalMaxParam.Agrs() = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestJob_Incremental(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "incremental")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	stateFile := filepath.Join(tmpDir, "state.json")
+
+	tests := []struct {
+		name      string
+		incMax    element.ColumnValue
+		state     *IncrementalState
+		want      *config.JSON
+		wantState *IncrementalState
+		wantErr   bool
+	}{
+		{
+			name:   "1",
+			incMax: element.NewBigIntColumnValueFromInt64(100),
+			want: testJSONFromString(`{"incremental":{"column":"f1","type":"bigInt","stateFile":"` +
+				stateFile + `","initial":"","left":"","right":"100"}}`),
+			wantState: &IncrementalState{Column: "f1", Type: "bigInt", Value: "100"},
+		},
+		{
+			name:   "2",
+			incMax: element.NewBigIntColumnValueFromInt64(200),
+			state:  &IncrementalState{Column: "f1", Type: "bigInt", Value: "100"},
+			want: testJSONFromString(`{"incremental":{"column":"f1","type":"bigInt","stateFile":"` +
+				stateFile + `","initial":"","left":"100","right":"200"}}`),
+			wantState: &IncrementalState{Column: "f1", Type: "bigInt", Value: "200"},
+		},
+		{
+			name:   "3",
+			incMax: element.NewNilBigIntColumnValue(),
+			state:  &IncrementalState{Column: "f1", Type: "bigInt", Value: "100"},
+			want: testJSONFromString(`{"incremental":{"column":"f1","type":"bigInt","stateFile":"` +
+				stateFile + `","initial":"","left":"100","right":"100"}}`),
+			wantState: &IncrementalState{Column: "f1", Type: "bigInt", Value: "100"},
+		},
+		{
+			name:    "4",
+			incMax:  element.NewBigIntColumnValueFromInt64(200),
+			state:   &IncrementalState{Column: "f2", Type: "bigInt", Value: "100"},
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			incMax:  element.NewBigIntColumnValueFromInt64(200),
+			state:   &IncrementalState{Column: "f1", Type: "time", Value: "100"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Remove(stateFile)
+			if tt.state != nil {
+				if err := tt.state.Save(stateFile); err != nil {
+					t.Fatal(err)
+				}
+			}
+			jobConf := testJSONFromString(`{"incremental":{"column":"f1"}}`)
+			jobConf.Set("incremental.stateFile", stateFile)
+			c, err := NewBaseConfig(jobConf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			j := &Job{
+				BaseJob: plugin.NewBaseJob(),
+				Config:  c,
+				Querier: &MockQuerier{IncMax: tt.incMax},
+				handler: newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+					return &MockQuerier{}, nil
+				}),
+			}
+			j.SetPluginJobConf(jobConf)
+			configs, err := j.Split(context.TODO(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Job.Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(configs) != 1 || !equalConfigJSON(configs[0], tt.want) {
+				t.Errorf("Job.Split() = %v, want %v", configs, tt.want)
+			}
+			if f := j.Config.GetIncrementalConfig().incField; f == nil || f.Name() != "f1" {
+				t.Errorf("Job.Split() incField = %v, want f1", f)
+			}
+			if err = j.Post(context.TODO()); err != nil {
+				t.Fatalf("Job.Post() error = %v", err)
+			}
+			state, err := LoadIncrementalState(stateFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(state, tt.wantState) {
+				t.Errorf("state = %v, want %v", state, tt.wantState)
+			}
+		})
+	}
+}
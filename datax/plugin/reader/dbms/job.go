@@ -17,6 +17,7 @@ package dbms
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
@@ -34,6 +35,11 @@ type Job struct {
 	Querier Querier
 	Config  Config
 	handler DbHandler
+	dialect string //数据库方言
+
+	incrementalState *IncrementalState //同步成功后需要保存的增量同步状态
+	incrementalField database.Field    //增量列字段
+	checkTable       database.Table    //预检查时获取的表
 }
 
 // NewJob 通过数据库句柄handler获取工作
@@ -50,6 +56,7 @@ func (j *Job) Init(ctx context.Context) (err error) {
 	if name, err = j.PluginConf().GetString("dialect"); err != nil {
 		return errors.Wrapf(err, "GetString fail")
 	}
+	j.dialect = name
 
 	if j.Config, err = j.handler.Config(j.PluginJobConf()); err != nil {
 		return errors.Wrapf(err, "Config fail")
@@ -77,6 +84,130 @@ func (j *Job) Init(ctx context.Context) (err error) {
 	return
 }
 
+// ExpandTables 将配置的多张表展开为每张表的读取配置，表名模式会通过数据库目录查询匹配的表，
+// 只配置单张表时为空，多表时不支持querySql和增量同步
+func (j *Job) ExpandTables(ctx context.Context) (tables []plugin.ExpandedTable, err error) {
+	bases := j.Config.GetBaseTables()
+	if len(bases) == 0 {
+		return nil, nil
+	}
+	if len(j.Config.GetQuerySQL()) != 0 {
+		return nil, errors.NewNoStackError("multiple tables can not be used with querySql")
+	}
+	if j.Config.GetIncrementalConfig().enabled() {
+		return nil, errors.NewNoStackError("multiple tables can not be used with incremental")
+	}
+
+	var expanded []*database.BaseTable
+	for _, b := range bases {
+		if !database.IsTablePattern(b.Name()) {
+			expanded = append(expanded, b)
+			continue
+		}
+		var matched []*database.BaseTable
+		if matched, err = j.Querier.FetchTables(ctx, b); err != nil {
+			return nil, errors.Wrapf(err, "FetchTables fail")
+		}
+		log.Infof("jobID: %v table pattern %v matches %v tables", j.JobID(), b.Name(), len(matched))
+		expanded = append(expanded, matched...)
+	}
+
+	names := make(map[string]bool)
+	for _, b := range expanded {
+		name := tableName(b)
+		if names[name] {
+			continue
+		}
+		names[name] = true
+		conf := j.PluginJobConf().CloneConfig()
+		if err = conf.Set("connection.table", TableConfig{
+			Db:     b.Instance(),
+			Schema: b.Schema(),
+			Name:   b.Name(),
+		}); err != nil {
+			return nil, errors.Wrapf(err, "Set fail")
+		}
+		tables = append(tables, plugin.ExpandedTable{
+			Name: name,
+			Conf: conf,
+		})
+	}
+	if len(tables) == 0 {
+		return nil, errors.NewNoStackError("no table matches the configured tables")
+	}
+	return
+}
+
+// tableName 将基础表b中不为空的库、模式以及表名用.连接作为表名
+func tableName(b *database.BaseTable) string {
+	var parts []string
+	for _, v := range []string{b.Instance(), b.Schema(), b.Name()} {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// PreCheck 预检查，通过查询表结构检查表以及配置的列是否存在，使用querySql时不检查
+func (j *Job) PreCheck(ctx context.Context) (err error) {
+	if len(j.Config.GetQuerySQL()) != 0 {
+		return
+	}
+	var table database.Table
+	if table, err = j.fetchTable(ctx); err != nil {
+		return errors.Wrapf(err, "FetchTableWithParam fail")
+	}
+	j.checkTable = table
+	return
+}
+
+// SourceColumns 获取读取的源表的列以及经过类型映射后的列类别，用于写入器自动建表，使用querySql时不支持
+func (j *Job) SourceColumns(ctx context.Context) (columns []database.TableColumn, err error) {
+	if len(j.Config.GetQuerySQL()) != 0 {
+		return nil, errors.NewNoStackError("querySql does not support to provide source columns")
+	}
+	var m *database.TypeMapping
+	if m, err = j.Config.GetTypeMapping(j.dialect); err != nil {
+		return nil, errors.Wrapf(err, "GetTypeMapping fail")
+	}
+	var table database.Table
+	if table, err = j.fetchTable(ctx); err != nil {
+		return nil, errors.Wrapf(err, "FetchTableWithParam fail")
+	}
+	for _, f := range table.Fields() {
+		columns = append(columns, database.TableColumn{
+			Name: f.Name(),
+			Type: f.Type(),
+			Kind: m.FieldKind(f.Name(), f.Type()),
+		})
+	}
+	return
+}
+
+// fetchTable 通过查询表结构获取读取的表
+func (j *Job) fetchTable(ctx context.Context) (database.Table, error) {
+	param := j.handler.TableParam(j.Config, j.Querier)
+	if setter, ok := param.Table().(database.ConfigSetter); ok {
+		setter.SetConfig(j.PluginJobConf())
+	}
+	return j.Querier.FetchTableWithParam(ctx, param)
+}
+
+// CheckColumns 预检查后获取读取的列，使用querySql时为空
+func (j *Job) CheckColumns() (columns []plugin.CheckColumn) {
+	if j.checkTable == nil {
+		return
+	}
+	for _, f := range j.checkTable.Fields() {
+		columns = append(columns, plugin.CheckColumn{
+			Name: f.Name(),
+			Type: database.FieldColumnType(f),
+		})
+	}
+	return
+}
+
 // Destroy 销毁
 func (j *Job) Destroy(ctx context.Context) (err error) {
 	if j.Querier != nil {
@@ -128,6 +259,13 @@ func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, er
 		return
 	}
 
+	if j.Config.GetIncrementalConfig().enabled() {
+		if err = j.prepareIncremental(ctx); err != nil {
+			err = errors.Wrapf(err, "prepareIncremental fail")
+			return
+		}
+	}
+
 	if j.Config.GetSplitConfig().Key == "" || number == 1 {
 		return []*config.JSON{j.PluginJobConf().CloneConfig()}, nil
 	}
@@ -183,3 +321,98 @@ func (j *Job) split(ctx context.Context, number int, fetcher splitRangeFetcher)
 
 	return
 }
+
+// prepareIncremental 从状态文件中读取上次同步的高水位，并查询本次同步的高水位，
+// 本次同步只读取增量列在这两个高水位之间的记录
+func (j *Job) prepareIncremental(ctx context.Context) (err error) {
+	inc := j.Config.GetIncrementalConfig()
+	var state *IncrementalState
+	if state, err = LoadIncrementalState(inc.StateFile); err != nil {
+		return errors.Wrapf(err, "LoadIncrementalState fail")
+	}
+	inc.Left = inc.Initial
+	if state != nil {
+		if state.Column != inc.Column {
+			return errors.Errorf("the column %v of state file %v is not incremental column %v",
+				state.Column, inc.StateFile, inc.Column)
+		}
+		inc.Left = state.Value
+	}
+
+	var table database.Table
+	param := j.handler.IncrementalParam(j.Config, j.Querier)
+	if table, err = j.Querier.FetchTableWithParam(ctx, param); err != nil {
+		return errors.Wrapf(err, "FetchTableWithParam fail")
+	}
+	if j.incrementalField, err = inc.field(table); err != nil {
+		return
+	}
+	if inc.Type, err = inc.columnType(j.incrementalField); err != nil {
+		return
+	}
+	if state != nil && state.Type != inc.Type {
+		return errors.Errorf("the type %v of state file %v is not incremental type %v",
+			state.Type, inc.StateFile, inc.Type)
+	}
+	if err = j.setIncrementalConfig(inc); err != nil {
+		return
+	}
+
+	var maxColumn element.Column
+	maxHandler := database.NewBaseFetchHandler(func() (element.Record, error) {
+		return element.NewDefaultRecord(), nil
+	}, func(r element.Record) error {
+		maxColumn, _ = r.GetByIndex(0)
+		return nil
+	})
+	if err = j.Querier.FetchRecord(ctx, j.handler.IncrementalMaxParam(j.Config, table), maxHandler); err != nil {
+		return errors.Wrapf(err, "FetchRecord fail")
+	}
+
+	//没有新记录时，本次同步的高水位与上次同步相同
+	inc.Right = inc.Left
+	if maxColumn != nil && !maxColumn.IsNil() {
+		if inc.Right, err = inc.columnValue(maxColumn); err != nil {
+			return errors.Wrapf(err, "columnValue fail")
+		}
+	}
+	log.Infof("jobID: %v incremental column %v range (%v, %v]",
+		j.JobID(), inc.Column, inc.Left, inc.Right)
+	if inc.Right != "" {
+		j.incrementalState = &IncrementalState{
+			Column: inc.Column,
+			Type:   inc.Type,
+			Value:  inc.Right,
+		}
+	}
+	return j.setIncrementalConfig(inc)
+}
+
+// setIncrementalConfig 将增量同步配置inc设置到工作配置中，
+// 并设置增量列字段用于切分时的增量条件
+func (j *Job) setIncrementalConfig(inc IncrementalConfig) (err error) {
+	if err = j.PluginJobConf().Set("incremental", inc); err != nil {
+		return errors.Wrapf(err, "Set fail")
+	}
+	if j.Config, err = j.handler.Config(j.PluginJobConf()); err != nil {
+		return errors.Wrapf(err, "Config fail")
+	}
+	if setter, ok := j.Config.(incrementalFieldSetter); ok {
+		setter.setIncrementalField(j.incrementalField)
+	}
+	return
+}
+
+// Post 后置处理，增量同步时保存本次同步的高水位
+func (j *Job) Post(ctx context.Context) (err error) {
+	if j.incrementalState == nil {
+		return nil
+	}
+	filename := j.Config.GetIncrementalConfig().StateFile
+	if err = j.incrementalState.Save(filename); err != nil {
+		return errors.Wrapf(err, "Save(%v) fail", filename)
+	}
+	log.Infof("jobID: %v save incremental state %v to %v",
+		j.JobID(), j.incrementalState.Value, filename)
+	return
+}
@@ -22,6 +22,8 @@ import (
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
 )
 
 func newMockDbHandler(newQuerier func(name string, conf *config.JSON) (Querier, error)) DbHandler {
@@ -470,3 +472,224 @@ func TestJob_Split(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_PreCheck(t *testing.T) {
+	table := NewMockTable(database.NewBaseTable("db", "schema", "name"))
+	table.AddField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)))
+	table.AddField(database.NewBaseField(1, "f2", NewMockFieldType(database.GoTypeTime)))
+	newJob := func(c *BaseConfig, q *MockQuerier) *Job {
+		return &Job{
+			BaseJob: plugin.NewBaseJob(),
+			Config:  c,
+			Querier: q,
+			handler: newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+				return q, nil
+			}),
+		}
+	}
+	tests := []struct {
+		name        string
+		j           *Job
+		wantErr     bool
+		wantColumns []plugin.CheckColumn
+	}{
+		{
+			name: "1",
+			j:    newJob(&BaseConfig{}, &MockQuerier{table: table}),
+			wantColumns: []plugin.CheckColumn{
+				{Name: "f1", Type: element.TypeBigInt},
+				{Name: "f2", Type: element.TypeTime},
+			},
+		},
+		{
+			name:    "2",
+			j:       newJob(&BaseConfig{}, &MockQuerier{FetchErr: errors.New("mock error")}),
+			wantErr: true,
+		},
+		{
+			name: "3",
+			j: newJob(&BaseConfig{
+				QuerySQL: []string{"select * from a"},
+			}, &MockQuerier{FetchErr: errors.New("mock error")}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.j.PreCheck(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.PreCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := tt.j.CheckColumns(); !reflect.DeepEqual(got, tt.wantColumns) {
+				t.Errorf("Job.CheckColumns() = %v, want %v", got, tt.wantColumns)
+			}
+		})
+	}
+}
+
+func TestJob_ExpandTables(t *testing.T) {
+	newJob := func(conf string, q *MockQuerier) *Job {
+		j := NewJob(newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+			return q, nil
+		}))
+		j.SetPluginJobConf(testJSONFromString(conf))
+		j.Config, _ = NewBaseConfig(j.PluginJobConf())
+		j.Querier = q
+		return j
+	}
+	tests := []struct {
+		name       string
+		j          *Job
+		wantNames  []string
+		wantTables []TableConfig
+		wantErr    bool
+	}{
+		{
+			name: "1",
+			j:    newJob(`{"connection":{"table":{"db":"db","name":"orders"}}}`, &MockQuerier{}),
+		},
+		{
+			name:      "2",
+			j:         newJob(`{"connection":{"table":{"schema":"schema","name":"orders_%"}}}`, &MockQuerier{}),
+			wantNames: []string{"schema.orders_1", "schema.orders_2"},
+			wantTables: []TableConfig{
+				{Schema: "schema", Name: "orders_1"},
+				{Schema: "schema", Name: "orders_2"},
+			},
+		},
+		{
+			name: "3",
+			j: newJob(`{"connection":{"table":[{"db":"db","name":"*"},{"db":"db","name":"users"},{"db":"db","name":"goods"}]}}`,
+				&MockQuerier{}),
+			wantNames: []string{"db.orders_1", "db.orders_2", "db.users", "db.goods"},
+			wantTables: []TableConfig{
+				{Db: "db", Name: "orders_1"},
+				{Db: "db", Name: "orders_2"},
+				{Db: "db", Name: "users"},
+				{Db: "db", Name: "goods"},
+			},
+		},
+		{
+			name:    "4",
+			j:       newJob(`{"connection":{"table":{"name":"goods_%"}}}`, &MockQuerier{}),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			j:       newJob(`{"connection":{"table":{"name":"orders_%"}}}`, &MockQuerier{TablesErr: errors.New("mock error")}),
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			j:       newJob(`{"connection":{"table":[{"name":"orders"}]},"querySql":["select 1"]}`, &MockQuerier{}),
+			wantErr: true,
+		},
+		{
+			name: "7",
+			j: newJob(`{"connection":{"table":{"name":"orders_%"}},
+				"incremental":{"column":"id","stateFile":"state.json"}}`, &MockQuerier{}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.j.ExpandTables(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.ExpandTables() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var names []string
+			var tables []TableConfig
+			for _, v := range got {
+				names = append(names, v.Name)
+				c, err := NewBaseConfig(v.Conf)
+				if err != nil {
+					t.Fatalf("NewBaseConfig() error = %v", err)
+				}
+				tables = append(tables, c.Connection.Table)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("Job.ExpandTables() names = %v, want %v", names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(tables, tt.wantTables) {
+				t.Errorf("Job.ExpandTables() tables = %v, want %v", tables, tt.wantTables)
+			}
+		})
+	}
+}
+
+func TestJob_SourceColumns(t *testing.T) {
+	table := NewMockTable(database.NewBaseTable("db", "schema", "name"))
+	table.AddField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)))
+	table.AddField(database.NewBaseField(1, "f2", NewMockFieldType(database.GoTypeString)))
+	newJob := func(c *BaseConfig, q *MockQuerier) *Job {
+		return &Job{
+			BaseJob: plugin.NewBaseJob(),
+			Config:  c,
+			Querier: q,
+			handler: newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+				return q, nil
+			}),
+		}
+	}
+	tests := []struct {
+		name      string
+		j         *Job
+		wantKinds []database.ColumnKind
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			j:         newJob(&BaseConfig{}, &MockQuerier{table: table}),
+			wantKinds: []database.ColumnKind{database.ColumnKindUnknown, database.ColumnKindUnknown},
+		},
+		{
+			name: "2",
+			j: newJob(&BaseConfig{
+				TypeMapping: &database.TypeMappingConfig{
+					Types:   map[string]string{"2": "bigInt"},
+					Columns: map[string]string{"f2": "bool"},
+				},
+			}, &MockQuerier{table: table}),
+			wantKinds: []database.ColumnKind{database.ColumnKindBigInt, database.ColumnKindBool},
+		},
+		{
+			name: "3",
+			j: newJob(&BaseConfig{
+				TypeMapping: &database.TypeMappingConfig{
+					Types: map[string]string{"2": "boolean"},
+				},
+			}, &MockQuerier{table: table}),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			j:       newJob(&BaseConfig{}, &MockQuerier{FetchErr: errors.New("mock error")}),
+			wantErr: true,
+		},
+		{
+			name: "5",
+			j: newJob(&BaseConfig{
+				QuerySQL: []string{"select * from a"},
+			}, &MockQuerier{table: table}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.j.SourceColumns(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.SourceColumns() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			var kinds []database.ColumnKind
+			for i, c := range got {
+				if c.Name != table.Fields()[i].Name() || c.Type != table.Fields()[i].Type() {
+					t.Errorf("Job.SourceColumns() %v = %v, want %v", i, c.Name, table.Fields()[i].Name())
+				}
+				kinds = append(kinds, c.Kind)
+			}
+			if !reflect.DeepEqual(kinds, tt.wantKinds) {
+				t.Errorf("Job.SourceColumns() kinds = %v, want %v", kinds, tt.wantKinds)
+			}
+		})
+	}
+}
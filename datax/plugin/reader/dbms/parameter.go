@@ -111,9 +111,20 @@ func (q *QueryParam) Query(_ []element.Record) (string, error) {
 	}
 	buf.WriteString(" from ")
 	buf.WriteString(q.Table().Quoted())
-	if q.Config.GetWhere() != "" {
+	where := q.Config.GetWhere()
+	if inc := q.Config.GetIncrementalConfig(); inc.enabled() {
+		//增量条件的占位符在切分条件的占位符之后
+		splitAgrs, err := q.splitAgrs()
+		if err != nil {
+			return "", err
+		}
+		if where, err = inc.where(where, q.Table(), len(splitAgrs)); err != nil {
+			return "", err
+		}
+	}
+	if where != "" {
 		buf.WriteString(" where ")
-		buf.WriteString(q.Config.GetWhere())
+		buf.WriteString(where)
 	}
 	return buf.String(), nil
 }
@@ -124,6 +135,19 @@ func (q *QueryParam) Agrs(_ []element.Record) (a []interface{}, err error) {
 		return nil, nil
 	}
 
+	if a, err = q.splitAgrs(); err != nil {
+		return nil, err
+	}
+	var incAgrs []interface{}
+	inc := q.Config.GetIncrementalConfig()
+	if incAgrs, err = inc.args(q.Table()); err != nil {
+		return nil, err
+	}
+	return append(a, incAgrs...), nil
+}
+
+// splitAgrs 获取切分条件的查询参数
+func (q *QueryParam) splitAgrs() (a []interface{}, err error) {
 	if q.Config.GetSplitConfig().Key != "" {
 		for _, v := range q.Table().Fields() {
 			if q.Config.GetSplitConfig().Key == v.Name() {
@@ -205,16 +229,22 @@ func (m *MinParam) Query(_ []element.Record) (string, error) {
 
 	buf.WriteString(") from ")
 	buf.WriteString(m.Table().Quoted())
-	if m.Config.GetWhere() != "" {
+	inc := m.Config.GetIncrementalConfig()
+	where, err := inc.where(m.Config.GetWhere(), m.Table(), 0)
+	if err != nil {
+		return "", err
+	}
+	if where != "" {
 		buf.WriteString(" where ")
-		buf.WriteString(m.Config.GetWhere())
+		buf.WriteString(where)
 	}
 	return buf.String(), nil
 }
 
 // Agrs 获取查询参数
 func (m *MinParam) Agrs(_ []element.Record) ([]interface{}, error) {
-	return nil, nil
+	inc := m.Config.GetIncrementalConfig()
+	return inc.args(m.Table())
 }
 
 // MaxParam 最大值参数
@@ -240,14 +270,20 @@ func (m *MaxParam) Query(_ []element.Record) (string, error) {
 
 	buf.WriteString(") from ")
 	buf.WriteString(m.Table().Quoted())
-	if m.Config.GetWhere() != "" {
+	inc := m.Config.GetIncrementalConfig()
+	where, err := inc.where(m.Config.GetWhere(), m.Table(), 0)
+	if err != nil {
+		return "", err
+	}
+	if where != "" {
 		buf.WriteString(" where ")
-		buf.WriteString(m.Config.GetWhere())
+		buf.WriteString(where)
 	}
 	return buf.String(), nil
 }
 
 // Agrs 获取查询参数
 func (m *MaxParam) Agrs(_ []element.Record) ([]interface{}, error) {
-	return nil, nil
+	inc := m.Config.GetIncrementalConfig()
+	return inc.args(m.Table())
 }
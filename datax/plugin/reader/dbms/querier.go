@@ -31,6 +31,8 @@ type Querier interface {
 	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 	//通过参数param获取具体表
 	FetchTableWithParam(ctx context.Context, param database.Parameter) (database.Table, error)
+	//通过基础表t中的表名模式从数据库目录中获取匹配的表
+	FetchTables(ctx context.Context, t *database.BaseTable) ([]*database.BaseTable, error)
 	//通过参数param，处理句柄handler获取记录
 	FetchRecord(ctx context.Context, param database.Parameter, handler database.FetchHandler) (err error)
 	//通过参数param，处理句柄handler使用事务获取记录
@@ -111,6 +111,9 @@ type MockQuerier struct {
 	FetchMaxErr error
 	isTime      bool
 	config      *config.JSON
+	IncMax      element.ColumnValue
+	table       database.Table
+	TablesErr   error
 }
 
 func (m *MockQuerier) Table(bt *database.BaseTable) database.Table {
@@ -136,9 +139,26 @@ func (m *MockQuerier) FetchTableWithParam(ctx context.Context,
 		t.AddField(database.NewBaseField(0, "f1", NewMockFieldType(typ)))
 		return t, m.FetchErr
 	}
+	if _, ok := param.(*IncrementalParam); ok {
+		t := NewMockTable(database.NewBaseTable("db", "schema", "name"))
+		t.AddField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)))
+		return t, m.FetchErr
+	}
+	if _, ok := param.(*TableParam); ok && m.table != nil {
+		return m.table, m.FetchErr
+	}
 	return nil, m.FetchErr
 }
 
+func (m *MockQuerier) FetchTables(ctx context.Context, t *database.BaseTable) (tables []*database.BaseTable, err error) {
+	for _, v := range []string{"orders_1", "orders_2", "users"} {
+		if database.MatchTableName(t.Name(), v) {
+			tables = append(tables, database.NewBaseTable(t.Instance(), t.Schema(), v))
+		}
+	}
+	return tables, m.TablesErr
+}
+
 func (m *MockQuerier) FetchRecord(ctx context.Context,
 	param database.Parameter, handler database.FetchHandler) (err error) {
 
@@ -157,6 +177,11 @@ func (m *MockQuerier) FetchRecord(ctx context.Context,
 			return m.FetchMaxErr
 		}
 		r.Add(element.NewDefaultColumn(element.NewBigIntColumnValue(big.NewInt(30000)), "f1", 0))
+	case *IncrementalMaxParam:
+		if m.FetchMaxErr != nil {
+			return m.FetchMaxErr
+		}
+		r.Add(element.NewDefaultColumn(m.IncMax, "f1", 0))
 	}
 	return handler.OnRecord(r)
 }
@@ -34,6 +34,8 @@ type Task struct {
 	Querier Querier
 	Config  Config
 	Table   database.Table
+
+	typeMapping *database.TypeMapping
 }
 
 // NewTask 通过数据库句柄handler获取任务
@@ -56,6 +58,10 @@ func (t *Task) Init(ctx context.Context) (err error) {
 		return t.Wrapf(err, "Config fail")
 	}
 
+	if t.typeMapping, err = t.Config.GetTypeMapping(name); err != nil {
+		return t.Wrapf(err, "GetTypeMapping fail")
+	}
+
 	var jobSettingConf *config.JSON
 	if jobSettingConf, err = t.PluginJobConf().GetConfig(coreconst.DataxJobSetting); err != nil {
 		jobSettingConf, _ = config.NewJSONFromString("{}")
@@ -85,12 +91,38 @@ func (t *Task) Init(ctx context.Context) (err error) {
 		if t.Table, err = t.Querier.FetchTableWithParam(ctx, param); err != nil {
 			return t.Wrapf(err, "FetchTableWithParam fail")
 		}
-		return
+		return t.setIncrementalField(ctx)
 	}
 	t.Table = param.Table()
 	return
 }
 
+// setIncrementalField 增量列不在读取的列中时，查询增量列字段并设置到配置中
+func (t *Task) setIncrementalField(ctx context.Context) (err error) {
+	inc := t.Config.GetIncrementalConfig()
+	if !inc.enabled() {
+		return nil
+	}
+	if _, err = inc.field(t.Table); err == nil {
+		return nil
+	}
+	setter, ok := t.Config.(incrementalFieldSetter)
+	if !ok {
+		return t.Wrapf(err, "field fail")
+	}
+
+	var table database.Table
+	if table, err = t.Querier.FetchTableWithParam(ctx, t.handler.IncrementalParam(t.Config, t.Querier)); err != nil {
+		return t.Wrapf(err, "FetchTableWithParam fail")
+	}
+	var f database.Field
+	if f, err = inc.field(table); err != nil {
+		return t.Wrapf(err, "field fail")
+	}
+	setter.setIncrementalField(f)
+	return nil
+}
+
 // Destroy 销毁
 func (t *Task) Destroy(ctx context.Context) (err error) {
 	if t.Querier != nil {
@@ -147,6 +179,9 @@ func (b *BaseBatchReader) Parameter() database.Parameter {
 
 // 通过上下文ctx，查询阐述和数据库句柄handler查询
 func (b *BaseBatchReader) Read(ctx context.Context, param database.Parameter, handler database.FetchHandler) (err error) {
+	if b.task.typeMapping != nil && b.task.typeMapping.HasOverride() {
+		handler = newTypeMappingHandler(param, b.task.typeMapping, handler)
+	}
 	if b.mode == "Tx" {
 		return b.task.Querier.FetchRecordWithTx(ctx, param, handler)
 	}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/storage/database"
 )
 
 func TestTask_Init(t *testing.T) {
@@ -232,6 +233,63 @@ func TestTask_Init_Config_QuerySQL(t *testing.T) {
 	}
 }
 
+func TestTask_Init_Incremental(t *testing.T) {
+	tests := []struct {
+		name      string
+		table     *MockTable
+		wantField string
+		wantWhere string
+	}{
+		{
+			name:      "1",
+			table:     testIncrementalTable(database.GoTypeInt64),
+			wantWhere: "f1 > $1",
+		},
+		{
+			name: "2",
+			table: func() *MockTable {
+				t := NewMockTable(database.NewBaseTable("db", "schema", "table"))
+				t.AddField(database.NewBaseField(0, "f2", NewMockFieldType(database.GoTypeInt64)))
+				return t
+			}(),
+			wantField: "f1",
+			wantWhere: "f1 > $1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := NewTask(newMockDbHandler(func(name string, conf *config.JSON) (Querier, error) {
+				return &MockQuerier{
+					table: tt.table,
+				}, nil
+			}))
+			task.SetPluginConf(testJSON())
+			task.SetPluginJobConf(testJSONFromString(`{
+				"column":["f2"],
+				"incremental":{"column":"f1","type":"bigInt","stateFile":"state.json","left":"10"}
+			}`))
+			if err := task.Init(context.TODO()); err != nil {
+				t.Fatalf("Task.Init() error = %v", err)
+			}
+			inc := task.Config.GetIncrementalConfig()
+			gotField := ""
+			if inc.incField != nil {
+				gotField = inc.incField.Name()
+			}
+			if gotField != tt.wantField {
+				t.Errorf("incField = %v, want %v", gotField, tt.wantField)
+			}
+			where, err := inc.where("", task.Table, 0)
+			if err != nil {
+				t.Fatalf("where() error = %v", err)
+			}
+			if where != tt.wantWhere {
+				t.Errorf("where() = %v, want %v", where, tt.wantWhere)
+			}
+		})
+	}
+}
+
 func TestTask_Destroy(t *testing.T) {
 	type args struct {
 		ctx context.Context
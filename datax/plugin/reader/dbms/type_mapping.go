@@ -0,0 +1,74 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/pingcap/errors"
+)
+
+// typeMappingHandler 按照任务级的类型映射覆盖转化列值的获取句柄
+type typeMappingHandler struct {
+	database.FetchHandler
+
+	param   database.Parameter
+	mapping *database.TypeMapping
+	kinds   []database.ColumnKind
+}
+
+// newTypeMappingHandler 通过查询参数param，类型映射mapping包装获取句柄handler
+func newTypeMappingHandler(param database.Parameter, mapping *database.TypeMapping,
+	handler database.FetchHandler) *typeMappingHandler {
+	return &typeMappingHandler{
+		FetchHandler: handler,
+		param:        param,
+		mapping:      mapping,
+	}
+}
+
+// OnRecord 将记录r中被覆盖映射的列转化为对应逻辑类型后再交给原句柄处理
+func (h *typeMappingHandler) OnRecord(r element.Record) (err error) {
+	if h.kinds == nil {
+		h.initKinds()
+	}
+	for i, kind := range h.kinds {
+		if kind == database.ColumnKindUnknown {
+			continue
+		}
+		var c, converted element.Column
+		if c, err = r.GetByIndex(i); err != nil {
+			return errors.Wrapf(err, "GetByIndex(%v) fail", i)
+		}
+		if converted, err = database.ConvertColumn(c, kind); err != nil {
+			return errors.Wrapf(err, "ConvertColumn(%v) fail", c.Name())
+		}
+		if err = r.Set(i, converted); err != nil {
+			return errors.Wrapf(err, "Set(%v) fail", i)
+		}
+	}
+	return h.FetchHandler.OnRecord(r)
+}
+
+// initKinds 根据表的列计算每一列需要转化成的逻辑类型
+func (h *typeMappingHandler) initKinds() {
+	fields := h.param.Table().Fields()
+	h.kinds = make([]database.ColumnKind, len(fields))
+	for i, f := range fields {
+		if kind, ok := h.mapping.OverrideKind(f.Name(), f.Type()); ok {
+			h.kinds[i] = kind
+		}
+	}
+}
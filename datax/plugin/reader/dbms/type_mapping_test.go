@@ -0,0 +1,118 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+type mockFetchHandler struct {
+	records []element.Record
+	err     error
+}
+
+func (m *mockFetchHandler) OnRecord(r element.Record) error {
+	m.records = append(m.records, r)
+	return m.err
+}
+
+func (m *mockFetchHandler) CreateRecord() (element.Record, error) {
+	return element.NewDefaultRecord(), nil
+}
+
+func testTypeMappingRecord(v1 int64, v2 string) element.Record {
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValue(big.NewInt(v1)), "f1", 0))
+	r.Add(element.NewDefaultColumn(element.NewStringColumnValue(v2), "f2", 0))
+	r.Add(element.NewDefaultColumn(element.NewStringColumnValue(v2), "f3", 0))
+	return r
+}
+
+func Test_typeMappingHandler_OnRecord(t *testing.T) {
+	table := NewMockTable(database.NewBaseTable("db", "schema", "name"))
+	table.AddField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeInt64)))
+	table.AddField(database.NewBaseField(1, "f2", NewMockFieldType(database.GoTypeString)))
+	table.AddField(database.NewBaseField(2, "f3", NewMockFieldType(database.GoTypeString)))
+	mapping, err := (&database.TypeMapping{}).Override(&database.TypeMappingConfig{
+		Types:   map[string]string{"2": "string"},
+		Columns: map[string]string{"f2": "bool"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		handler *mockFetchHandler
+		r       element.Record
+		want    []element.ColumnType
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			handler: &mockFetchHandler{},
+			r:       testTypeMappingRecord(1, "true"),
+			want:    []element.ColumnType{element.TypeString, element.TypeBool, element.TypeString},
+		},
+		{
+			name:    "2",
+			handler: &mockFetchHandler{},
+			r:       testTypeMappingRecord(1, "abc"),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			handler: &mockFetchHandler{},
+			r:       element.NewDefaultRecord(),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			handler: &mockFetchHandler{err: errors.New("mock error")},
+			r:       testTypeMappingRecord(1, "false"),
+			want:    []element.ColumnType{element.TypeString, element.TypeBool, element.TypeString},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTypeMappingHandler(NewQueryParam(&BaseConfig{}, table, nil), mapping, tt.handler)
+			if err := h.OnRecord(tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("typeMappingHandler.OnRecord() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.want == nil {
+				return
+			}
+			var got []element.ColumnType
+			for i := 0; i < tt.r.ColumnNumber(); i++ {
+				c, _ := tt.r.GetByIndex(i)
+				got = append(got, c.Type())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("typeMappingHandler.OnRecord() = %v, want %v", got, tt.want)
+			}
+			if len(tt.handler.records) != 1 {
+				t.Errorf("typeMappingHandler.OnRecord() records = %v, want 1", len(tt.handler.records))
+			}
+		})
+	}
+}
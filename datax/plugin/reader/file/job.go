@@ -16,8 +16,11 @@ package file
 
 import (
 	"context"
+	"os"
+	"strings"
 
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/pingcap/errors"
 )
 
 // Job 工作
@@ -36,3 +39,28 @@ func NewJob() *Job {
 func (j *Job) Destroy(ctx context.Context) (err error) {
 	return
 }
+
+// CheckReadable 检查文件路径paths是否都是可以读取的文件
+func CheckReadable(paths ...string) error {
+	var msgs []string
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			msgs = append(msgs, err.Error())
+			continue
+		}
+		fi, err := f.Stat()
+		f.Close()
+		if err != nil {
+			msgs = append(msgs, err.Error())
+			continue
+		}
+		if fi.IsDir() {
+			msgs = append(msgs, path+" is a directory")
+		}
+	}
+	if len(msgs) != 0 {
+		return errors.NewNoStackError(strings.Join(msgs, "; "))
+	}
+	return nil
+}
@@ -16,6 +16,9 @@ package file
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -46,3 +49,42 @@ func TestJob_Destroy(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckReadable(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filename := filepath.Join(tmpDir, "a.csv")
+	if err = ioutil.WriteFile(filename, []byte("1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		paths   []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			paths: []string{filename},
+		},
+		{
+			name:    "2",
+			paths:   []string{filename, filepath.Join(tmpDir, "b.csv")},
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			paths:   []string{tmpDir},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckReadable(tt.paths...); (err != nil) != tt.wantErr {
+				t.Errorf("CheckReadable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// PathConfig 文件路径展开配置，path中可以配置glob模式和目录
+type PathConfig struct {
+	Recursive bool     `json:"recursive"` // 是否递归读取目录下子目录中的文件
+	Include   []string `json:"include"`   // 包含的文件名模式，为空时包含所有文件
+	Exclude   []string `json:"exclude"`   // 排除的文件名模式
+}
+
+// Expand 展开文件路径paths，glob模式会展开成匹配的文件，
+// 目录会展开成目录下的文件，由glob模式或目录展开得到的文件会
+// 按照include和exclude过滤，普通文件路径保持不变
+func (p *PathConfig) Expand(paths ...string) (files []string, err error) {
+	if err = p.validate(); err != nil {
+		return nil, err
+	}
+	existed := make(map[string]bool)
+	add := func(file string) {
+		if !existed[file] {
+			existed[file] = true
+			files = append(files, file)
+		}
+	}
+
+	for _, path := range paths {
+		if !isGlob(path) {
+			if fi, statErr := os.Stat(path); statErr != nil || !fi.IsDir() {
+				add(path)
+				continue
+			}
+		}
+
+		var expanded []string
+		if expanded, err = p.expand(path); err != nil {
+			return nil, errors.Wrapf(err, "expand path fail. path: %v", path)
+		}
+		for _, v := range expanded {
+			add(v)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, errors.Errorf("no file matches path %v", paths)
+	}
+	return
+}
+
+// expand 展开glob模式或者目录path
+func (p *PathConfig) expand(path string) (files []string, err error) {
+	var matches []string
+	if isGlob(path) {
+		if matches, err = filepath.Glob(path); err != nil {
+			return nil, err
+		}
+	} else {
+		matches = []string{path}
+	}
+
+	for _, m := range matches {
+		var fi os.FileInfo
+		if fi, err = os.Stat(m); err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			if p.match(m) {
+				files = append(files, m)
+			}
+			continue
+		}
+
+		var dirFiles []string
+		if dirFiles, err = p.walk(m); err != nil {
+			return nil, err
+		}
+		files = append(files, dirFiles...)
+	}
+	return
+}
+
+// walk 获取目录dir下符合条件的文件，仅在recursive为true时读取子目录
+func (p *PathConfig) walk(dir string) (files []string, err error) {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && !p.Recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if p.match(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return
+}
+
+// match 文件名是否满足include和exclude
+func (p *PathConfig) match(path string) bool {
+	name := filepath.Base(path)
+	for _, v := range p.Exclude {
+		if ok, _ := filepath.Match(v, name); ok {
+			return false
+		}
+	}
+	if len(p.Include) == 0 {
+		return true
+	}
+	for _, v := range p.Include {
+		if ok, _ := filepath.Match(v, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *PathConfig) validate() error {
+	for _, v := range append(append([]string{}, p.Include...), p.Exclude...) {
+		if _, err := filepath.Match(v, ""); err != nil {
+			return errors.Wrapf(err, "pattern %v is not valid", v)
+		}
+	}
+	return nil
+}
+
+// isGlob 路径path是否为glob模式
+func isGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
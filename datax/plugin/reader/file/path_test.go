@@ -0,0 +1,130 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPathConfig_Expand(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	if err = os.Mkdir(filepath.Join(tmpDir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"a.csv", "b.csv.gz", "c.txt", "sub/d.csv", "sub/e.csv"} {
+		if err = ioutil.WriteFile(filepath.Join(tmpDir, v), []byte("1"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	join := func(names ...string) (paths []string) {
+		for _, v := range names {
+			paths = append(paths, filepath.Join(tmpDir, v))
+		}
+		return
+	}
+
+	tests := []struct {
+		name    string
+		p       *PathConfig
+		paths   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			p:     &PathConfig{},
+			paths: join("a.csv"),
+			want:  join("a.csv"),
+		},
+		{
+			name:  "2",
+			p:     &PathConfig{},
+			paths: join("*.csv*"),
+			want:  join("a.csv", "b.csv.gz"),
+		},
+		{
+			name:  "3",
+			p:     &PathConfig{},
+			paths: []string{tmpDir},
+			want:  join("a.csv", "b.csv.gz", "c.txt"),
+		},
+		{
+			name: "4",
+			p: &PathConfig{
+				Recursive: true,
+				Include:   []string{"*.csv"},
+			},
+			paths: []string{tmpDir},
+			want:  join("a.csv", "sub/d.csv", "sub/e.csv"),
+		},
+		{
+			name: "5",
+			p: &PathConfig{
+				Recursive: true,
+				Exclude:   []string{"e.csv", "*.txt"},
+			},
+			paths: join("*"),
+			want:  join("a.csv", "b.csv.gz", "sub/d.csv"),
+		},
+		{
+			name: "6",
+			p: &PathConfig{
+				Exclude: []string{"*.csv"},
+			},
+			paths: join("a.csv", "*.csv", "none.csv"),
+			want:  join("a.csv", "none.csv"),
+		},
+		{
+			name:    "7",
+			p:       &PathConfig{},
+			paths:   join("*.xlsx"),
+			wantErr: true,
+		},
+		{
+			name: "8",
+			p: &PathConfig{
+				Include: []string{"["},
+			},
+			paths:   []string{tmpDir},
+			wantErr: true,
+		},
+		{
+			name:    "9",
+			p:       &PathConfig{},
+			paths:   join("["),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.p.Expand(tt.paths...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PathConfig.Expand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PathConfig.Expand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+	"github.com/Breeze0806/go-etl/storage/stream/file/jsonl"
+)
+
+func TestNewConfig(t *testing.T) {
+	type args struct {
+		conf *config.JSON
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantC   *Config
+		wantErr bool
+	}{
+		{
+			name: "1",
+			args: args{
+				conf: testJSONFromString(`{"column":1}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "2",
+			args: args{
+				conf: testJSONFromString(`{"path":[]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "3",
+			args: args{
+				conf: testJSONFromString(`{"path":["/data/in"],"recursive":true,"include":["*.jsonl"],"column":[{"path":"a.b","type":"bigInt"}],"compress":"gz"}`),
+			},
+			wantC: &Config{
+				InConfig: jsonl.InConfig{
+					Columns: []jsonl.Column{
+						{
+							Path: "a.b",
+							Type: "bigInt",
+						},
+					},
+					Compress: "gz",
+				},
+				PathConfig: file.PathConfig{
+					Recursive: true,
+					Include:   []string{"*.jsonl"},
+				},
+				Path: []string{"/data/in"},
+			},
+		},
+		{
+			name: "4",
+			args: args{
+				conf: testJSONFromString(`{"path":["/data/in"],"column":[{"path":"a","type":"time"}]}`),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotC, err := NewConfig(tt.args.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotC, tt.wantC) {
+				t.Errorf("NewConfig() = %v, want %v", gotC, tt.wantC)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"context"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+	"github.com/pingcap/errors"
+)
+
+// Job 工作
+type Job struct {
+	*file.Job
+
+	conf *Config
+}
+
+// NewJob 创建工作
+func NewJob() *Job {
+	return &Job{
+		Job: file.NewJob(),
+	}
+}
+
+// Init 初始化
+func (j *Job) Init(ctx context.Context) (err error) {
+	j.conf, err = NewConfig(j.PluginJobConf())
+	return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginConf())
+}
+
+// PreCheck 预检查，检查文件是否可以读取
+func (j *Job) PreCheck(ctx context.Context) (err error) {
+	var paths []string
+	if paths, err = j.conf.Expand(j.conf.Path...); err != nil {
+		return err
+	}
+	return file.CheckReadable(paths...)
+}
+
+// Split 切分，path中的glob模式和目录会在此时展开成文件
+func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, err error) {
+	var paths []string
+	if paths, err = j.conf.Expand(j.conf.Path...); err != nil {
+		return nil, err
+	}
+	for _, v := range paths {
+		conf, _ := config.NewJSONFromString("{}")
+		conf.Set("path", v)
+		conf.Set("content.0", j.conf.InConfig)
+		configs = append(configs, conf)
+	}
+	return
+}
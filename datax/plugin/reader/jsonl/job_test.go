@@ -0,0 +1,154 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func TestJob_Split(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	for _, v := range []string{"a.jsonl", "b.jsonl", "c.txt"} {
+		if err = ioutil.WriteFile(filepath.Join(tmpDir, v), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	type args struct {
+		ctx    context.Context
+		number int
+	}
+	tests := []struct {
+		name        string
+		jobConf     *config.JSON
+		args        args
+		wantConfigs []*config.JSON
+		wantErr     bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJSONFromString(`{"path":["file1"],"column":[{"path":"a.b","type":"bigInt"}]}`),
+			args: args{
+				ctx: context.TODO(),
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(`{"path":"file1","content":[{"column":[{"path":"a.b","name":"","type":"bigInt","format":""}],"compress":""}]}`),
+			},
+		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[{"path":"a"}],"compress":"gz"}`, filepath.Join(tmpDir, "*.jsonl"))),
+			args: args{
+				ctx: context.TODO(),
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(fmt.Sprintf(`{"path":%q,"content":[{"column":[{"path":"a","name":"","type":"","format":""}],"compress":"gz"}]}`, filepath.Join(tmpDir, "a.jsonl"))),
+				testJSONFromString(fmt.Sprintf(`{"path":%q,"content":[{"column":[{"path":"a","name":"","type":"","format":""}],"compress":"gz"}]}`, filepath.Join(tmpDir, "b.jsonl"))),
+			},
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[{"path":"a"}]}`, filepath.Join(tmpDir, "*.json"))),
+			args: args{
+				ctx: context.TODO(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJob()
+			defer j.Destroy(tt.args.ctx)
+
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(tt.args.ctx); err != nil {
+				t.Errorf("init fail. err: %v", err)
+			}
+			gotConfigs, err := j.Split(tt.args.ctx, tt.args.number)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Job.Split() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotConfigs, tt.wantConfigs) {
+				t.Errorf("Job.Split() = %v, want %v", gotConfigs, tt.wantConfigs)
+			}
+		})
+	}
+}
+
+func TestJob_PreCheck(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filename := filepath.Join(tmpDir, "file1")
+	if err = ioutil.WriteFile(filename, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[{"path":"a"}]}`, filename)),
+		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[{"path":"a"}]}`, filepath.Join(tmpDir, "none", "file2"))),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[{"path":"a"}]}`, filepath.Join(tmpDir, "*.jsonl"))),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJob()
+			defer j.Destroy(context.TODO())
+
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); err != nil {
+				t.Fatalf("init fail. err: %v", err)
+			}
+			if err := j.PreCheck(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.PreCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 
 	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
 	//xlsx storage
 	"github.com/Breeze0806/go-etl/storage/stream/file/xlsx"
 )
@@ -25,6 +26,7 @@ import (
 // Config xlsx输入配置
 type Config struct {
 	xlsx.InConfig
+	file.PathConfig
 	Xlsxs []Xlsx `json:"xlsxs"`
 }
 
@@ -43,18 +43,37 @@ func (j *Job) Init(ctx context.Context) (err error) {
 	return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginJobConf())
 }
 
-// Split 切分
+// PreCheck 预检查，检查文件是否可以读取
+func (j *Job) PreCheck(ctx context.Context) (err error) {
+	var paths []string
+	for _, v := range j.conf.Xlsxs {
+		var files []string
+		if files, err = j.conf.Expand(v.Path); err != nil {
+			return err
+		}
+		paths = append(paths, files...)
+	}
+	return file.CheckReadable(paths...)
+}
+
+// Split 切分，path中的glob模式和目录会在此时展开成文件
 func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, err error) {
 	for _, x := range j.conf.Xlsxs {
-		conf, _ := config.NewJSONFromString("{}")
-		conf.Set("path", x.Path)
+		var files []string
+		if files, err = j.conf.Expand(x.Path); err != nil {
+			return nil, err
+		}
+		for _, f := range files {
+			conf, _ := config.NewJSONFromString("{}")
+			conf.Set("path", f)
 
-		for i, v := range x.Sheets {
-			xlsxConfig := j.conf.InConfig
-			xlsxConfig.Sheet = v
-			conf.Set("content."+strconv.Itoa(i), xlsxConfig)
+			for i, v := range x.Sheets {
+				xlsxConfig := j.conf.InConfig
+				xlsxConfig.Sheet = v
+				conf.Set("content."+strconv.Itoa(i), xlsxConfig)
+			}
+			configs = append(configs, conf)
 		}
-		configs = append(configs, conf)
 	}
 	return
 }
@@ -16,6 +16,10 @@ package xlsx
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -31,6 +35,16 @@ func testJSONFromString(json string) *config.JSON {
 }
 
 func TestJob_Split(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "xlsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	for _, v := range []string{"a.xlsx", "b.xlsx", "c.csv"} {
+		if err = ioutil.WriteFile(filepath.Join(tmpDir, v), []byte("1"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
 	type args struct {
 		ctx    context.Context
 		number int
@@ -53,6 +67,25 @@ func TestJob_Split(t *testing.T) {
 				testJSONFromString(`{"path":"file2","content":[{"column":[],"sheet":"Sheet1","nullFormat":"(null)","startRow":2}]}`),
 			},
 		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"xlsxs":[{"path":%q,"sheets":["Sheet1"]}],"include":["*.xlsx"],"column":[],"nullFormat":"(null)","startRow":2}`, tmpDir)),
+			args: args{
+				ctx: context.TODO(),
+			},
+			wantConfigs: []*config.JSON{
+				testJSONFromString(fmt.Sprintf(`{"path":%q,"content":[{"column":[],"sheet":"Sheet1","nullFormat":"(null)","startRow":2}]}`, filepath.Join(tmpDir, "a.xlsx"))),
+				testJSONFromString(fmt.Sprintf(`{"path":%q,"content":[{"column":[],"sheet":"Sheet1","nullFormat":"(null)","startRow":2}]}`, filepath.Join(tmpDir, "b.xlsx"))),
+			},
+		},
+		{
+			name:    "3",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"xlsxs":[{"path":%q,"sheets":["Sheet1"]}],"column":[],"nullFormat":"(null)","startRow":2}`, filepath.Join(tmpDir, "*.xls"))),
+			args: args{
+				ctx: context.TODO(),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -74,3 +107,44 @@ func TestJob_Split(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_PreCheck(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "xlsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filename := filepath.Join(tmpDir, "file1")
+	if err = ioutil.WriteFile(filename, []byte("1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"xlsxs":[{"path":%q,"sheets":["Sheet1"]}],"column":[],"nullFormat":"(null)","startRow":2}`, filename)),
+		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"xlsxs":[{"path":%q,"sheets":["Sheet1"]}],"column":[],"nullFormat":"(null)","startRow":2}`, filepath.Join(tmpDir, "none", "file2"))),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJob()
+			defer j.Destroy(context.TODO())
+
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); err != nil {
+				t.Fatalf("init fail. err: %v", err)
+			}
+			if err := j.PreCheck(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.PreCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
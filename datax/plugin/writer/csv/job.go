@@ -18,7 +18,7 @@ import (
 	"context"
 
 	"github.com/Breeze0806/go-etl/config"
-	"github.com/Breeze0806/go-etl/datax/plugin/reader/file"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
 	"github.com/pingcap/errors"
 )
 
@@ -41,6 +41,11 @@ func (j *Job) Init(ctx context.Context) (err error) {
 	return errors.Wrapf(err, "NewConfig fail. val: %v", j.PluginJobConf())
 }
 
+// PreCheck 预检查，检查文件是否可以写入
+func (j *Job) PreCheck(ctx context.Context) (err error) {
+	return file.CheckWritable(j.conf.Path...)
+}
+
 // Split 切分
 func (j *Job) Split(ctx context.Context, number int) (configs []*config.JSON, err error) {
 	for _, v := range j.conf.Path {
@@ -16,6 +16,10 @@ package csv
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -73,3 +77,41 @@ func TestJob_Split(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_PreCheck(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	filename := filepath.Join(tmpDir, "file1")
+	tests := []struct {
+		name    string
+		jobConf *config.JSON
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[]}`, filename)),
+		},
+		{
+			name:    "2",
+			jobConf: testJSONFromString(fmt.Sprintf(`{"path":[%q],"column":[]}`, filepath.Join(tmpDir, "none", "file2"))),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJob()
+			defer j.Destroy(context.TODO())
+
+			j.SetPluginJobConf(tt.jobConf)
+			if err := j.Init(context.TODO()); err != nil {
+				t.Fatalf("init fail. err: %v", err)
+			}
+			if err := j.PreCheck(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.PreCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
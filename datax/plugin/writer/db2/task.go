@@ -27,6 +27,9 @@ import (
 
 var execModeMap = map[string]string{
 	database.WriteModeInsert: dbms.ExecModeNormal,
+	database.WriteModeUpsert: dbms.ExecModeNormal,
+	database.WriteModeUpdate: dbms.ExecModeStmtTx,
+	database.WriteModeDelete: dbms.ExecModeStmtTx,
 }
 
 func execMode(writeMode string) string {
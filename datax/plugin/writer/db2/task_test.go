@@ -45,6 +45,20 @@ func Test_execMode(t *testing.T) {
 			},
 			want: dbms.ExecModeNormal,
 		},
+		{
+			name: "3",
+			args: args{
+				writeMode: database.WriteModeUpdate,
+			},
+			want: dbms.ExecModeStmtTx,
+		},
+		{
+			name: "4",
+			args: args{
+				writeMode: database.WriteModeDelete,
+			},
+			want: dbms.ExecModeStmtTx,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
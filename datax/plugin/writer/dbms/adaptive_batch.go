@@ -0,0 +1,86 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"sync"
+	"time"
+)
+
+// adaptiveBatchSize 自适应批量大小，在最小和最大批量写入数之间调整单次批量写入数，
+// 单次批量写入耗时超过目标耗时时减半，满批次写入耗时不到目标耗时一半时增加一半，
+// 批量过大导致写入失败时减到失败批次的一半，并且最大批量写入数降到失败批次以下
+type adaptiveBatchSize struct {
+	mu     sync.Mutex
+	size   int
+	min    int
+	max    int
+	target time.Duration
+}
+
+// newAdaptiveBatchSize 通过初始批量写入数size和自适应批量配置conf生成自适应批量大小
+func newAdaptiveBatchSize(size int, conf *AdaptiveBatchConfig) *adaptiveBatchSize {
+	a := &adaptiveBatchSize{
+		min:    conf.MinBatchSize,
+		max:    conf.MaxBatchSize,
+		target: conf.TargetLatency.Duration,
+	}
+	a.setSize(size)
+	return a
+}
+
+// Size 当前单次批量写入数
+func (a *adaptiveBatchSize) Size() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.size
+}
+
+// observe 根据成功写入n条记录的耗时latency调整批量大小
+func (a *adaptiveBatchSize) observe(n int, latency time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch {
+	case latency > a.target:
+		a.setSize(a.size / 2)
+	case latency < a.target/2 && n >= a.size:
+		a.setSize(a.size + a.size/2 + 1)
+	}
+}
+
+// shrink 写入n条记录时批量过大，将批量大小减到不超过n的一半，并且之后不再增加到n
+func (a *adaptiveBatchSize) shrink(n int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if n-1 < a.max {
+		a.max = n - 1
+		if a.max < a.min {
+			a.max = a.min
+		}
+	}
+	if n/2 < a.size {
+		a.setSize(n / 2)
+	}
+}
+
+func (a *adaptiveBatchSize) setSize(size int) {
+	if size < a.min {
+		size = a.min
+	}
+	if size > a.max {
+		size = a.max
+	}
+	a.size = size
+}
@@ -0,0 +1,169 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dbms
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go/time2"
+)
+
+func testAdaptiveBatchConfig(min, max int, target time.Duration) *AdaptiveBatchConfig {
+	return &AdaptiveBatchConfig{
+		MinBatchSize:  min,
+		MaxBatchSize:  max,
+		TargetLatency: time2.NewDuration(target),
+	}
+}
+
+func Test_newAdaptiveBatchSize(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		conf *AdaptiveBatchConfig
+		want int
+	}{
+		{
+			name: "1",
+			size: 1000,
+			conf: testAdaptiveBatchConfig(10, 10000, time.Second),
+			want: 1000,
+		},
+		{
+			name: "2",
+			size: 1,
+			conf: testAdaptiveBatchConfig(10, 10000, time.Second),
+			want: 10,
+		},
+		{
+			name: "3",
+			size: 100000,
+			conf: testAdaptiveBatchConfig(10, 10000, time.Second),
+			want: 10000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newAdaptiveBatchSize(tt.size, tt.conf).Size(); got != tt.want {
+				t.Errorf("newAdaptiveBatchSize().Size() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adaptiveBatchSize_observe(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       *adaptiveBatchSize
+		n       int
+		latency time.Duration
+		want    int
+	}{
+		{
+			name:    "1",
+			a:       newAdaptiveBatchSize(1000, testAdaptiveBatchConfig(10, 10000, time.Second)),
+			n:       1000,
+			latency: 2 * time.Second,
+			want:    500,
+		},
+		{
+			name:    "2",
+			a:       newAdaptiveBatchSize(15, testAdaptiveBatchConfig(10, 10000, time.Second)),
+			n:       15,
+			latency: 2 * time.Second,
+			want:    10,
+		},
+		{
+			name:    "3",
+			a:       newAdaptiveBatchSize(1000, testAdaptiveBatchConfig(10, 10000, time.Second)),
+			n:       1000,
+			latency: 100 * time.Millisecond,
+			want:    1501,
+		},
+		{
+			name:    "4",
+			a:       newAdaptiveBatchSize(8000, testAdaptiveBatchConfig(10, 10000, time.Second)),
+			n:       8000,
+			latency: 100 * time.Millisecond,
+			want:    10000,
+		},
+		{
+			name:    "5",
+			a:       newAdaptiveBatchSize(1000, testAdaptiveBatchConfig(10, 10000, time.Second)),
+			n:       10,
+			latency: 100 * time.Millisecond,
+			want:    1000,
+		},
+		{
+			name:    "6",
+			a:       newAdaptiveBatchSize(1000, testAdaptiveBatchConfig(10, 10000, time.Second)),
+			n:       1000,
+			latency: 800 * time.Millisecond,
+			want:    1000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.a.observe(tt.n, tt.latency)
+			if got := tt.a.Size(); got != tt.want {
+				t.Errorf("adaptiveBatchSize.Size() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_adaptiveBatchSize_shrink(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       *adaptiveBatchSize
+		n       int
+		want    int
+		wantMax int
+	}{
+		{
+			name:    "1",
+			a:       newAdaptiveBatchSize(1000, testAdaptiveBatchConfig(1, 10000, time.Second)),
+			n:       1000,
+			want:    500,
+			wantMax: 999,
+		},
+		{
+			name:    "2",
+			a:       newAdaptiveBatchSize(100, testAdaptiveBatchConfig(1, 10000, time.Second)),
+			n:       1000,
+			want:    100,
+			wantMax: 999,
+		},
+		{
+			name:    "3",
+			a:       newAdaptiveBatchSize(1000, testAdaptiveBatchConfig(1, 10000, time.Second)),
+			n:       1,
+			want:    1,
+			wantMax: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.a.shrink(tt.n)
+			if got := tt.a.Size(); got != tt.want {
+				t.Errorf("adaptiveBatchSize.Size() = %v, want %v", got, tt.want)
+			}
+			if tt.a.max != tt.wantMax {
+				t.Errorf("adaptiveBatchSize.max = %v, want %v", tt.a.max, tt.wantMax)
+			}
+		})
+	}
+}
@@ -43,6 +43,7 @@ type BatchWriter interface {
 	TaskID() int64                                                  //任务编号
 	BatchSize() int                                                 //单次批量写入数
 	BatchTimeout() time.Duration                                    //单次批量写入超时时间
+	BatchConcurrency() int                                          //同时写入的批次数
 	BatchWrite(ctx context.Context, records []element.Record) error //批量写入
 }
 
@@ -53,6 +54,9 @@ type BaseBatchWriter struct {
 	strategy schedule.RetryStrategy
 	judger   database.Judger
 	opts     *database.ParameterOptions
+
+	sizeJudger database.BatchSizeJudger
+	adaptive   *adaptiveBatchSize //自适应批量大小，为nil时不启用
 }
 
 // NewBaseBatchWriter 获取任务task，执行模式execMode，事务选项opts创建批量写入器
@@ -76,6 +80,11 @@ func NewBaseBatchWriter(task *Task, execMode string, opts *sql.TxOptions) *BaseB
 		w.strategy = schedule.NewNoneRetryStrategy()
 	}
 
+	if conf := task.Config.GetAdaptiveBatch(); conf != nil {
+		w.adaptive = newAdaptiveBatchSize(task.Config.GetBatchSize(), conf)
+		w.sizeJudger, _ = task.Table.(database.BatchSizeJudger)
+	}
+
 	w.opts = &database.ParameterOptions{
 		Table:     task.Table,
 		Mode:      task.Config.GetWriteMode(),
@@ -99,8 +108,11 @@ func (b *BaseBatchWriter) TaskID() int64 {
 	return b.Task.TaskID()
 }
 
-// BatchSize 单批次插入数据
+// BatchSize 单批次插入数据，启用自适应批量时为当前调整后的批量大小
 func (b *BaseBatchWriter) BatchSize() int {
+	if b.adaptive != nil {
+		return b.adaptive.Size()
+	}
 	return b.Task.Config.GetBatchSize()
 }
 
@@ -109,24 +121,63 @@ func (b *BaseBatchWriter) BatchTimeout() time.Duration {
 	return b.Task.Config.GetBatchTimeout()
 }
 
-// BatchWrite 批次写入
+// BatchConcurrency 同时写入的批次数
+func (b *BaseBatchWriter) BatchConcurrency() int {
+	return b.Task.Config.GetBatchConcurrency()
+}
+
+// BatchWrite 批次写入，可以被多个携程同时调用
 func (b *BaseBatchWriter) BatchWrite(ctx context.Context, records []element.Record) (err error) {
 	if b.strategy != nil {
 		retry := schedule.NewRetryTask(ctx, b.strategy, newWriteTask(func() error {
-			return b.batchWrite(ctx, records)
+			start := time.Now()
+			err := b.batchWrite(ctx, records)
+			latency := time.Since(start)
+			if collector := b.metricCollector(); collector != nil {
+				collector.CollectBatchWriteLatency(latency)
+			}
+			if err != nil {
+				return err
+			}
+			if b.adaptive != nil {
+				b.adaptive.observe(len(records), latency)
+			}
+			return nil
 		}))
 		err = retry.Do()
+		b.collectRetry(retry.RetryCount())
+	}
+
+	//批量过大导致写入失败时，缩小批量大小并将记录分成两半分别写入
+	if b.shouldShrink(err) && len(records) > 1 {
+		b.adaptive.shrink(len(records))
+		log.Debugf("jobID: %v taskgroupID:%v taskID: %v batch of %v records is too large, shrink batch size to %v",
+			b.JobID(), b.TaskGroupID(), b.TaskID(), len(records), b.adaptive.Size())
+		half := len(records) / 2
+		if err = b.BatchWrite(ctx, records[:half]); err != nil {
+			return err
+		}
+		return b.BatchWrite(ctx, records[half:])
 	}
 
 	if b.judger != nil {
 		if b.judger.ShouldOneByOne(err) {
+			err = nil
 			for _, r := range records {
 				retry := schedule.NewRetryTask(ctx, b.strategy, newWriteTask(func() error {
 					return b.batchWrite(ctx, []element.Record{r})
 				}))
-				err = retry.Do()
-				if b.Task.Config.IgnoreOneByOneError() {
-					err = nil
+				rerr := retry.Do()
+				b.collectRetry(retry.RetryCount())
+				if rerr == nil {
+					continue
+				}
+				//逐条写入失败的记录作为脏数据收集
+				if collector := b.Task.TaskCollector(); collector != nil {
+					collector.CollectDirtyRecordWithError(r, rerr)
+				}
+				if !b.Task.Config.IgnoreOneByOneError() && err == nil {
+					err = rerr
 				}
 			}
 		}
@@ -134,20 +185,38 @@ func (b *BaseBatchWriter) BatchWrite(ctx context.Context, records []element.Reco
 	return err
 }
 
+// metricCollector 任务收集器支持收集指标时返回指标收集器，否则返回nil
+func (b *BaseBatchWriter) metricCollector() plugin.MetricCollector {
+	collector, _ := b.Task.TaskCollector().(plugin.MetricCollector)
+	return collector
+}
+
+func (b *BaseBatchWriter) collectRetry(n int) {
+	if n == 0 {
+		return
+	}
+	if collector := b.metricCollector(); collector != nil {
+		collector.CollectRetry(n)
+	}
+}
+
+func (b *BaseBatchWriter) shouldShrink(err error) bool {
+	return err != nil && b.adaptive != nil && b.sizeJudger != nil && b.sizeJudger.ShouldShrinkBatch(err)
+}
+
 func (b *BaseBatchWriter) batchWrite(ctx context.Context, records []element.Record) error {
-	b.opts.Records = records
-	defer func() {
-		b.opts.Records = nil
-	}()
+	//并发写入时每个批次使用独立的参数选项
+	opts := *b.opts
+	opts.Records = records
 	switch b.execMode {
 	case ExecModeTx:
-		return b.Task.Execer.BatchExecWithTx(ctx, b.opts)
+		return b.Task.Execer.BatchExecWithTx(ctx, &opts)
 	case ExecModeStmt:
-		return b.Task.Execer.BatchExecStmt(ctx, b.opts)
+		return b.Task.Execer.BatchExecStmt(ctx, &opts)
 	case ExecModeStmtTx:
-		return b.Task.Execer.BatchExecStmtWithTx(ctx, b.opts)
+		return b.Task.Execer.BatchExecStmtWithTx(ctx, &opts)
 	}
-	return b.Task.Execer.BatchExec(ctx, b.opts)
+	return b.Task.Execer.BatchExec(ctx, &opts)
 }
 
 type writeTask struct {
@@ -208,6 +277,7 @@ func StartWrite(ctx context.Context, w BatchWriter,
 	ticker := time.NewTicker(w.BatchTimeout())
 	defer ticker.Stop()
 	var records []element.Record
+	pipeline := newBatchPipeline(w)
 	log.Debugf("jobID: %v taskgroupID:%v taskID: %v  start to BatchWrite",
 		w.JobID(), w.TaskGroupID(), w.TaskID())
 	for {
@@ -216,12 +286,13 @@ func StartWrite(ctx context.Context, w BatchWriter,
 			if !ok {
 				//当写入结束时，将剩余的记录写入数据库
 				if len(records) > 0 {
-					if err = w.BatchWrite(ctx, records); err != nil {
-						log.Errorf("jobID: %v taskgroupID:%v taskID: %v BatchWrite(%v) error: %+v",
-							w.JobID(), w.TaskGroupID(), w.TaskID(), records, err)
-					}
+					err = pipeline.submit(ctx, records)
 				}
 				records = nil
+				//等待所有写入中的批次结束
+				if werr := pipeline.wait(); err == nil {
+					err = werr
+				}
 				if err == nil {
 					err = rerr
 				}
@@ -231,9 +302,7 @@ func StartWrite(ctx context.Context, w BatchWriter,
 
 			//当数据量超过单次批量数时 写入数据库
 			if len(records) >= w.BatchSize() {
-				if err = w.BatchWrite(ctx, records); err != nil {
-					log.Errorf("jobID: %v taskgroupID:%v taskID: %v BatchWrite(%v) error: %+v",
-						w.JobID(), w.TaskGroupID(), w.TaskID(), records, err)
+				if err = pipeline.submit(ctx, records); err != nil {
 					goto End
 				}
 				records = nil
@@ -241,9 +310,7 @@ func StartWrite(ctx context.Context, w BatchWriter,
 		//当写入数据未达到单次批量数，超时也写入
 		case <-ticker.C:
 			if len(records) > 0 {
-				if err = w.BatchWrite(ctx, records); err != nil {
-					log.Errorf("jobID: %v taskgroupID:%v taskID: %v BatchWrite(%v) error: %+v",
-						w.JobID(), w.TaskGroupID(), w.TaskID(), records, err)
+				if err = pipeline.submit(ctx, records); err != nil {
 					goto End
 				}
 			}
@@ -252,6 +319,8 @@ func StartWrite(ctx context.Context, w BatchWriter,
 	}
 End:
 	cancel()
+	//出错时也要等待所有写入中的批次结束
+	pipeline.wait()
 	log.Debugf("jobID: %v taskgroupID:%v taskID: %v wait all goroutine",
 		w.JobID(), w.TaskGroupID(), w.TaskID())
 	//等待携程结束
@@ -268,3 +337,58 @@ End:
 	}
 	return errors.Wrapf(err, "jobID: %v taskgroupID:%v taskID: %v", w.JobID(), w.TaskGroupID(), w.TaskID())
 }
+
+// batchPipeline 批量写入流水线，最多同时写入BatchConcurrency个批次，
+// 在写入的批次数未达到上限时可以继续接收记录，并按照批次提交的顺序报告错误
+type batchPipeline struct {
+	w           BatchWriter
+	concurrency int
+	pending     []chan error //按提交顺序排列的写入中批次的结果
+}
+
+func newBatchPipeline(w BatchWriter) *batchPipeline {
+	concurrency := w.BatchConcurrency()
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &batchPipeline{
+		w:           w,
+		concurrency: concurrency,
+	}
+}
+
+// submit 提交批次records进行写入，写入中的批次数达到上限时等待最早提交的批次结束，
+// 返回最早提交的批次的写入错误
+func (p *batchPipeline) submit(ctx context.Context, records []element.Record) error {
+	result := make(chan error, 1)
+	go func() {
+		err := p.w.BatchWrite(ctx, records)
+		if err != nil {
+			log.Errorf("jobID: %v taskgroupID:%v taskID: %v BatchWrite(%v) error: %+v",
+				p.w.JobID(), p.w.TaskGroupID(), p.w.TaskID(), records, err)
+		}
+		result <- err
+	}()
+	p.pending = append(p.pending, result)
+	if len(p.pending) < p.concurrency {
+		return nil
+	}
+	return p.waitOldest()
+}
+
+// waitOldest 等待最早提交的批次结束，返回该批次的写入错误
+func (p *batchPipeline) waitOldest() error {
+	result := p.pending[0]
+	p.pending = p.pending[1:]
+	return <-result
+}
+
+// wait 等待所有写入中的批次结束，返回按照提交顺序第一个写入错误
+func (p *batchPipeline) wait() (err error) {
+	for len(p.pending) > 0 {
+		if werr := p.waitOldest(); err == nil {
+			err = werr
+		}
+	}
+	return
+}
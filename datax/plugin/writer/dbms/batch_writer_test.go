@@ -17,6 +17,8 @@ package dbms
 import (
 	"context"
 	"errors"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -309,3 +311,445 @@ func TestBaseBatchWriter_BatchWrite(t *testing.T) {
 		})
 	}
 }
+
+type mockTaskCollector struct {
+	records []element.Record
+}
+
+func (m *mockTaskCollector) CollectDirtyRecordWithError(record element.Record, err error) {
+	m.CollectDirtyRecord(record, err, "")
+}
+
+func (m *mockTaskCollector) CollectDirtyRecordWithMsg(record element.Record, msgErr string) {
+	m.CollectDirtyRecord(record, nil, msgErr)
+}
+
+func (m *mockTaskCollector) CollectDirtyRecord(record element.Record, err error, msgErr string) {
+	m.records = append(m.records, record)
+}
+
+func (m *mockTaskCollector) CollectMessage(key string, value string) {}
+
+func TestBaseBatchWriter_BatchWriteDirtyRecord(t *testing.T) {
+	tests := []struct {
+		name      string
+		conf      string
+		wantDirty int
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			conf:      `{"job":{"setting":{"retry":{"type":"ntimes","strategy":{"wait":"1ns","n":1},"ignoreOneByOneError":true}}}}`,
+			wantDirty: 4,
+			wantErr:   false,
+		},
+		{
+			name:      "2",
+			conf:      `{"job":{"setting":{"retry":{"type":"ntimes","strategy":{"wait":"1ns","n":1}}}}}`,
+			wantDirty: 4,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := &mockTaskCollector{}
+			task := &Task{
+				BaseTask: spiwriter.NewBaseTask(),
+				Execer: &MockExecer{
+					BatchErr: errors.New("mock error"),
+				},
+				Table: NewMockTableWithJudger(database.NewBaseTable("instance",
+					"schema", "table"), false, true),
+				Config: testBaseConfig(testJSONFromString(tt.conf)),
+			}
+			task.SetTaskCollector(collector)
+			b := NewBaseBatchWriter(task, ExecModeNormal, nil)
+			err := b.BatchWrite(context.TODO(), []element.Record{
+				element.NewDefaultRecord(),
+				element.NewDefaultRecord(),
+				element.NewDefaultRecord(),
+				element.NewDefaultRecord(),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BaseBatchWriter.BatchWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(collector.records) != tt.wantDirty {
+				t.Errorf("dirty records = %v, want %v", len(collector.records), tt.wantDirty)
+			}
+		})
+	}
+}
+
+type mockPipelineWriter struct {
+	concurrency int
+	wait        time.Duration
+	errs        map[int]error //第几个批次返回的错误
+
+	mu          sync.Mutex
+	n           int
+	running     int
+	maxRunning  int
+	recordCount int
+}
+
+func (m *mockPipelineWriter) JobID() int64 {
+	return 1
+}
+
+func (m *mockPipelineWriter) TaskGroupID() int64 {
+	return 1
+}
+
+func (m *mockPipelineWriter) TaskID() int64 {
+	return 1
+}
+
+func (m *mockPipelineWriter) BatchSize() int {
+	return 10
+}
+
+func (m *mockPipelineWriter) BatchTimeout() time.Duration {
+	return time.Second
+}
+
+func (m *mockPipelineWriter) BatchConcurrency() int {
+	return m.concurrency
+}
+
+func (m *mockPipelineWriter) BatchWrite(ctx context.Context, records []element.Record) error {
+	m.mu.Lock()
+	n := m.n
+	m.n++
+	m.running++
+	if m.running > m.maxRunning {
+		m.maxRunning = m.running
+	}
+	m.recordCount += len(records)
+	m.mu.Unlock()
+
+	time.Sleep(m.wait)
+
+	m.mu.Lock()
+	m.running--
+	m.mu.Unlock()
+	return m.errs[n]
+}
+
+func Test_batchPipeline(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+	tests := []struct {
+		name           string
+		w              *mockPipelineWriter
+		batches        int
+		wantMaxRunning int
+		wantSubmitErr  bool
+		wantErr        error
+	}{
+		{
+			name: "1",
+			w: &mockPipelineWriter{
+				concurrency: 0,
+				wait:        time.Millisecond,
+			},
+			batches:        5,
+			wantMaxRunning: 1,
+		},
+		{
+			name: "2",
+			w: &mockPipelineWriter{
+				concurrency: 3,
+				wait:        10 * time.Millisecond,
+			},
+			batches:        9,
+			wantMaxRunning: 3,
+		},
+		{
+			name: "3",
+			w: &mockPipelineWriter{
+				concurrency: 4,
+				wait:        time.Millisecond,
+				errs: map[int]error{
+					1: errFirst,
+					2: errSecond,
+				},
+			},
+			batches:        3,
+			wantMaxRunning: 3,
+			wantErr:        errFirst,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newBatchPipeline(tt.w)
+			for i := 0; i < tt.batches; i++ {
+				if err := p.submit(context.TODO(), []element.Record{element.NewDefaultRecord()}); err != nil {
+					t.Fatalf("submit() error = %v", err)
+				}
+			}
+			if err := p.wait(); err != tt.wantErr {
+				t.Errorf("wait() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.w.maxRunning != tt.wantMaxRunning {
+				t.Errorf("maxRunning = %v, want %v", tt.w.maxRunning, tt.wantMaxRunning)
+			}
+			if tt.w.recordCount != tt.batches {
+				t.Errorf("recordCount = %v, want %v", tt.w.recordCount, tt.batches)
+			}
+		})
+	}
+}
+
+func TestStartWrite_Concurrency(t *testing.T) {
+	tests := []struct {
+		name            string
+		w               *mockPipelineWriter
+		receiver        plugin.RecordReceiver
+		wantMaxRunning  int
+		wantRecordCount int
+		wantErr         bool
+	}{
+		{
+			name: "1",
+			w: &mockPipelineWriter{
+				concurrency: 4,
+				wait:        10 * time.Millisecond,
+			},
+			receiver:        NewMockReceiverWithoutWait(105, exchange.ErrTerminate),
+			wantMaxRunning:  4,
+			wantRecordCount: 104,
+		},
+		{
+			name: "2",
+			w: &mockPipelineWriter{
+				concurrency: 4,
+				wait:        10 * time.Millisecond,
+				errs: map[int]error{
+					0: errors.New("mock error"),
+				},
+			},
+			receiver:       NewMockReceiverWithoutWait(1000, exchange.ErrTerminate),
+			wantMaxRunning: 4,
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := StartWrite(context.TODO(), tt.w, tt.receiver); (err != nil) != tt.wantErr {
+				t.Errorf("StartWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.w.maxRunning != tt.wantMaxRunning {
+				t.Errorf("maxRunning = %v, want %v", tt.w.maxRunning, tt.wantMaxRunning)
+			}
+			if tt.wantRecordCount != 0 && tt.w.recordCount != tt.wantRecordCount {
+				t.Errorf("recordCount = %v, want %v", tt.w.recordCount, tt.wantRecordCount)
+			}
+		})
+	}
+}
+
+var errMockTooLarge = errors.New("mock too large")
+
+type mockTableWithBatchSizeJudger struct {
+	*MockTableWithJudger
+}
+
+func (m *mockTableWithBatchSizeJudger) ShouldShrinkBatch(err error) bool {
+	return err == errMockTooLarge
+}
+
+type mockLimitExecer struct {
+	*MockExecer
+
+	limit   int
+	batches []int
+}
+
+func (m *mockLimitExecer) BatchExec(ctx context.Context, opts *database.ParameterOptions) (err error) {
+	if len(opts.Records) > m.limit {
+		return errMockTooLarge
+	}
+	m.batches = append(m.batches, len(opts.Records))
+	return nil
+}
+
+func TestBaseBatchWriter_AdaptiveBatchWrite(t *testing.T) {
+	tests := []struct {
+		name        string
+		conf        string
+		limit       int
+		records     int
+		wantBatches []int
+		wantSize    int
+		wantErr     bool
+	}{
+		{
+			name:        "1",
+			conf:        `{"batchSize":8,"adaptiveBatch":{"targetLatency":"1h"}}`,
+			limit:       3,
+			records:     8,
+			wantBatches: []int{2, 2, 2, 2},
+			wantSize:    3,
+		},
+		{
+			name:        "2",
+			conf:        `{"batchSize":8,"adaptiveBatch":{"targetLatency":"1h"}}`,
+			limit:       10,
+			records:     8,
+			wantBatches: []int{8},
+			wantSize:    8,
+		},
+		{
+			name:        "3",
+			conf:        `{"batchSize":8,"adaptiveBatch":{"minBatchSize":4,"targetLatency":"1h"}}`,
+			limit:       3,
+			records:     8,
+			wantBatches: []int{2, 2, 2, 2},
+			wantSize:    4,
+		},
+		{
+			name:    "4",
+			conf:    `{"batchSize":8}`,
+			limit:   3,
+			records: 8,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execer := &mockLimitExecer{
+				MockExecer: &MockExecer{},
+				limit:      tt.limit,
+			}
+			task := &Task{
+				BaseTask: spiwriter.NewBaseTask(),
+				Execer:   execer,
+				Table: &mockTableWithBatchSizeJudger{
+					MockTableWithJudger: NewMockTableWithJudger(database.NewBaseTable("instance",
+						"schema", "table"), false, false),
+				},
+				Config: testBaseConfig(testJSONFromString(tt.conf)),
+			}
+			b := NewBaseBatchWriter(task, ExecModeNormal, nil)
+			var records []element.Record
+			for i := 0; i < tt.records; i++ {
+				records = append(records, element.NewDefaultRecord())
+			}
+			err := b.BatchWrite(context.TODO(), records)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BaseBatchWriter.BatchWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(execer.batches, tt.wantBatches) {
+				t.Errorf("batches = %v, want %v", execer.batches, tt.wantBatches)
+			}
+			if got := b.BatchSize(); got != tt.wantSize {
+				t.Errorf("BaseBatchWriter.BatchSize() = %v, want %v", got, tt.wantSize)
+			}
+		})
+	}
+}
+
+type mockMetricCollector struct {
+	mockTaskCollector
+
+	latencies int
+	retries   int
+}
+
+func (m *mockMetricCollector) CollectBatchWriteLatency(latency time.Duration) {
+	m.latencies++
+}
+
+func (m *mockMetricCollector) CollectRetry(n int) {
+	m.retries += n
+}
+
+type mockFailNExecer struct {
+	*MockExecer
+
+	n int
+}
+
+func (m *mockFailNExecer) BatchExec(ctx context.Context, opts *database.ParameterOptions) (err error) {
+	if m.n > 0 {
+		m.n--
+		return errors.New("mock error")
+	}
+	return nil
+}
+
+type mockRetryErrorTable struct {
+	*MockTable
+}
+
+func (m *mockRetryErrorTable) ShouldRetry(err error) bool {
+	return err != nil
+}
+
+func (m *mockRetryErrorTable) ShouldOneByOne(err error) bool {
+	return false
+}
+
+func TestBaseBatchWriter_BatchWriteMetric(t *testing.T) {
+	tests := []struct {
+		name          string
+		fail          int
+		wantLatencies int
+		wantRetries   int
+		wantErr       bool
+	}{
+		{
+			name:          "1",
+			fail:          0,
+			wantLatencies: 1,
+			wantRetries:   0,
+		},
+		{
+			name:          "2",
+			fail:          2,
+			wantLatencies: 3,
+			wantRetries:   2,
+		},
+		{
+			name:          "3",
+			fail:          5,
+			wantLatencies: 3,
+			wantRetries:   2,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := &mockMetricCollector{}
+			task := &Task{
+				BaseTask: spiwriter.NewBaseTask(),
+				Execer: &mockFailNExecer{
+					MockExecer: &MockExecer{},
+					n:          tt.fail,
+				},
+				Table: &mockRetryErrorTable{
+					MockTable: NewMockTable(database.NewBaseTable("instance", "schema", "table")),
+				},
+				Config: testBaseConfig(testJSONFromString(
+					`{"job":{"setting":{"retry":{"type":"ntimes","strategy":{"wait":"1ns","n":3}}}}}`)),
+			}
+			task.SetTaskCollector(collector)
+			b := NewBaseBatchWriter(task, ExecModeNormal, nil)
+			err := b.BatchWrite(context.TODO(), []element.Record{
+				element.NewDefaultRecord(),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BaseBatchWriter.BatchWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if collector.latencies != tt.wantLatencies {
+				t.Errorf("latencies = %v, want %v", collector.latencies, tt.wantLatencies)
+			}
+			if collector.retries != tt.wantRetries {
+				t.Errorf("retries = %v, want %v", collector.retries, tt.wantRetries)
+			}
+		})
+	}
+}
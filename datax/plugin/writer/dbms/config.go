@@ -30,8 +30,9 @@ import (
 
 // 默认参数
 var (
-	defalutBatchSize    = 1000
-	defalutBatchTimeout = 1 * time.Second
+	defalutBatchSize        = 1000
+	defalutBatchTimeout     = 1 * time.Second
+	defalutBatchConcurrency = 1
 )
 
 // Config 关系数据库写入器配置
@@ -44,27 +45,43 @@ type Config interface {
 	GetWriteMode() string                                                    //获取写入模式
 	GetBatchSize() int                                                       //单次批量写入数
 	GetBatchTimeout() time.Duration                                          //单次批量写入超时时间
+	GetBatchConcurrency() int                                                //同时写入的批次数
+	GetAdaptiveBatch() *AdaptiveBatchConfig                                  //获取自适应批量配置，为nil时不启用
 	GetRetryStrategy(j schedule.RetryJudger) (schedule.RetryStrategy, error) //获取重试策略
 	IgnoreOneByOneError() bool                                               //忽略一个个重试的错误
 	GetPreSQL() []string                                                     //获取准备的SQL语句
 	GetPostSQL() []string                                                    //获取结束的SQL语句
+	GetAutoCreateTable() bool                                                //是否在目标表不存在时自动建表
+	GetTypeMapping(dialect string) (*database.TypeMapping, error)            //获取数据库方言dialect被工作覆盖后的类型映射
 }
 
 // BaseConfig 用于实现基本的关系数据库配置，如无特殊情况采用该配置，帮助快速实现writer
 type BaseConfig struct {
-	Username            string                `json:"username"`     //用户名
-	Password            string                `json:"password"`     //密码
-	Column              []string              `json:"column"`       //列信息
-	Connection          dbmsreader.ConnConfig `json:"connection"`   //连接信息
-	WriteMode           string                `json:"writeMode"`    //写入模式,如插入insert
-	BatchSize           int                   `json:"batchSize"`    //单次批量写入数
-	BatchTimeout        time2.Duration        `json:"batchTimeout"` //单次批量写入超时时间
-	PreSQL              []string              `json:"preSQL"`       //准备的SQL语句
-	PostSQL             []string              `json:"postSQL"`      //结束的SQL语句
-	ignoreOneByOneError bool                  //忽略一个个重试的错误
+	Username            string                      `json:"username"`         //用户名
+	Password            string                      `json:"password"`         //密码
+	Column              []string                    `json:"column"`           //列信息
+	Connection          dbmsreader.ConnConfig       `json:"connection"`       //连接信息
+	WriteMode           string                      `json:"writeMode"`        //写入模式,如插入insert
+	BatchSize           int                         `json:"batchSize"`        //单次批量写入数
+	BatchTimeout        time2.Duration              `json:"batchTimeout"`     //单次批量写入超时时间
+	BatchConcurrency    int                         `json:"batchConcurrency"` //同时写入的批次数
+	AdaptiveBatch       *AdaptiveBatchConfig        `json:"adaptiveBatch"`    //自适应批量配置
+	PreSQL              []string                    `json:"preSQL"`           //准备的SQL语句
+	PostSQL             []string                    `json:"postSQL"`          //结束的SQL语句
+	KeyColumn           []string                    `json:"keyColumn"`        //键列，用于upsert，update和delete写入模式
+	AutoCreateTable     bool                        `json:"autoCreateTable"`  //目标表不存在时根据源表结构自动建表
+	TypeMapping         *database.TypeMappingConfig `json:"typeMapping"`      //类型映射配置
+	ignoreOneByOneError bool                        //忽略一个个重试的错误
 	newRetryStrategy    func(j schedule.RetryJudger) (schedule.RetryStrategy, error)
 }
 
+// AdaptiveBatchConfig 自适应批量配置，根据单次批量写入耗时以及批量过大的错误在最小和最大批量写入数之间调整批量大小
+type AdaptiveBatchConfig struct {
+	MinBatchSize  int            `json:"minBatchSize"`  //最小单次批量写入数
+	MaxBatchSize  int            `json:"maxBatchSize"`  //最大单次批量写入数
+	TargetLatency time2.Duration `json:"targetLatency"` //单次批量写入的目标耗时
+}
+
 // NewBaseConfig 从conf解析出关系数据库配置
 func NewBaseConfig(conf *config.JSON) (c *BaseConfig, err error) {
 	c = &BaseConfig{}
@@ -83,6 +100,21 @@ func NewBaseConfig(conf *config.JSON) (c *BaseConfig, err error) {
 		return schedule.NewRetryStrategy(j, jobsetting)
 	}
 
+	if len(c.Connection.Tables) != 0 {
+		return nil, fmt.Errorf("table of writer can not be an array")
+	}
+
+	switch c.WriteMode {
+	case database.WriteModeUpsert, database.WriteModeUpdate, database.WriteModeDelete:
+		if len(c.KeyColumn) == 0 {
+			return nil, fmt.Errorf("keyColumn is empty when writeMode is %v", c.WriteMode)
+		}
+		//多个批次同时写入时同一键列的记录写入顺序无法保证
+		if c.BatchConcurrency > 1 {
+			return nil, fmt.Errorf("batchConcurrency(%v) should not be greater than 1 when writeMode is %v", c.BatchConcurrency, c.WriteMode)
+		}
+	}
+
 	if err = checkHasSelect(c.PreSQL); err != nil {
 		return nil, fmt.Errorf("check preSQL fail. error: %v", err)
 	}
@@ -146,6 +178,36 @@ func (b *BaseConfig) GetBatchSize() int {
 	return b.BatchSize
 }
 
+// GetBatchConcurrency 同时写入的批次数，不大于0时为1
+func (b *BaseConfig) GetBatchConcurrency() int {
+	if b.BatchConcurrency <= 0 {
+		return defalutBatchConcurrency
+	}
+	return b.BatchConcurrency
+}
+
+// GetAdaptiveBatch 获取自适应批量配置，未配置时为nil，
+// 最小单次批量写入数默认为1，最大单次批量写入数默认为单次批量写入数，目标耗时默认为单次批量超时时间
+func (b *BaseConfig) GetAdaptiveBatch() *AdaptiveBatchConfig {
+	if b.AdaptiveBatch == nil {
+		return nil
+	}
+	c := *b.AdaptiveBatch
+	if c.MinBatchSize <= 0 {
+		c.MinBatchSize = 1
+	}
+	if c.MaxBatchSize <= 0 {
+		c.MaxBatchSize = b.GetBatchSize()
+	}
+	if c.MaxBatchSize < c.MinBatchSize {
+		c.MaxBatchSize = c.MinBatchSize
+	}
+	if c.TargetLatency.Duration <= 0 {
+		c.TargetLatency.Duration = b.GetBatchTimeout()
+	}
+	return &c
+}
+
 // GetPreSQL 获取准备的SQL语句
 func (b *BaseConfig) GetPreSQL() []string {
 	return getSQlsWithoutEmpty(b.PreSQL)
@@ -156,6 +218,16 @@ func (b *BaseConfig) GetPostSQL() []string {
 	return getSQlsWithoutEmpty(b.PostSQL)
 }
 
+// GetAutoCreateTable 是否在目标表不存在时自动建表
+func (b *BaseConfig) GetAutoCreateTable() bool {
+	return b.AutoCreateTable
+}
+
+// GetTypeMapping 获取数据库方言dialect被工作覆盖后的类型映射
+func (b *BaseConfig) GetTypeMapping(dialect string) (*database.TypeMapping, error) {
+	return database.GetTypeMapping(dialect).Override(b.TypeMapping)
+}
+
 // IgnoreOneByOneError 忽略一个个重试的错误
 func (b *BaseConfig) IgnoreOneByOneError() bool {
 	return b.ignoreOneByOneError
@@ -23,6 +23,7 @@ import (
 	"github.com/Breeze0806/go-etl/config"
 	dbmsreader "github.com/Breeze0806/go-etl/datax/plugin/reader/dbms"
 	"github.com/Breeze0806/go-etl/schedule"
+	"github.com/Breeze0806/go/time2"
 )
 
 func testBaseConfig(conf *config.JSON) (bc *BaseConfig) {
@@ -142,6 +143,87 @@ func TestBaseConfig_GetBatchSize(t *testing.T) {
 	}
 }
 
+func TestBaseConfig_GetBatchConcurrency(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *BaseConfig
+		want int
+	}{
+		{
+			name: "1",
+			b:    testBaseConfig(testJSONFromString("{}")),
+			want: defalutBatchConcurrency,
+		},
+		{
+			name: "2",
+			b:    testBaseConfig(testJSONFromString(`{"batchConcurrency":-1}`)),
+			want: defalutBatchConcurrency,
+		},
+		{
+			name: "3",
+			b:    testBaseConfig(testJSONFromString(`{"batchConcurrency":4}`)),
+			want: 4,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.GetBatchConcurrency(); got != tt.want {
+				t.Errorf("BaseConfig.GetBatchConcurrency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseConfig_GetAdaptiveBatch(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *BaseConfig
+		want *AdaptiveBatchConfig
+	}{
+		{
+			name: "1",
+			b:    testBaseConfig(testJSONFromString("{}")),
+			want: nil,
+		},
+		{
+			name: "2",
+			b:    testBaseConfig(testJSONFromString(`{"adaptiveBatch":{}}`)),
+			want: &AdaptiveBatchConfig{
+				MinBatchSize:  1,
+				MaxBatchSize:  defalutBatchSize,
+				TargetLatency: time2.NewDuration(defalutBatchTimeout),
+			},
+		},
+		{
+			name: "3",
+			b: testBaseConfig(testJSONFromString(`{"batchSize":500,"batchTimeout":"100ms",
+				"adaptiveBatch":{"minBatchSize":100,"maxBatchSize":10000,"targetLatency":"2s"}}`)),
+			want: &AdaptiveBatchConfig{
+				MinBatchSize:  100,
+				MaxBatchSize:  10000,
+				TargetLatency: time2.NewDuration(2 * time.Second),
+			},
+		},
+		{
+			name: "4",
+			b: testBaseConfig(testJSONFromString(`{"batchSize":500,"batchTimeout":"100ms",
+				"adaptiveBatch":{"minBatchSize":1000}}`)),
+			want: &AdaptiveBatchConfig{
+				MinBatchSize:  1000,
+				MaxBatchSize:  1000,
+				TargetLatency: time2.NewDuration(100 * time.Millisecond),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.GetAdaptiveBatch(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BaseConfig.GetAdaptiveBatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestBaseConfig_GetRetryStrategy(t *testing.T) {
 	type args struct {
 		j schedule.RetryJudger
@@ -202,6 +284,71 @@ func TestBaseConfig_IgnoreOneByOneError(t *testing.T) {
 	}
 }
 
+func TestBaseConfig_GetAutoCreateTable(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *BaseConfig
+		want bool
+	}{
+		{
+			name: "1",
+			b:    testBaseConfig(testJSONFromString(`{"autoCreateTable":true}`)),
+			want: true,
+		},
+		{
+			name: "2",
+			b:    testBaseConfig(testJSONFromString(`{}`)),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.b.GetAutoCreateTable(); got != tt.want {
+				t.Errorf("BaseConfig.GetAutoCreateTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseConfig_GetTypeMapping(t *testing.T) {
+	tests := []struct {
+		name         string
+		b            *BaseConfig
+		wantOverride bool
+		wantErr      bool
+	}{
+		{
+			name: "1",
+			b:    testBaseConfig(testJSONFromString(`{}`)),
+		},
+		{
+			name:         "2",
+			b:            testBaseConfig(testJSONFromString(`{"typeMapping":{"columns":{"c1":"bool"},"ddl":{"string":"text"}}}`)),
+			wantOverride: true,
+		},
+		{
+			name:    "3",
+			b:       testBaseConfig(testJSONFromString(`{"typeMapping":{"types":{"INT":"integer"}}}`)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.GetTypeMapping("mock")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BaseConfig.GetTypeMapping() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.HasOverride() != tt.wantOverride {
+				t.Errorf("BaseConfig.GetTypeMapping() HasOverride = %v, want %v", got.HasOverride(), tt.wantOverride)
+			}
+		})
+	}
+}
+
 func TestBaseConfig_GetPreSQL(t *testing.T) {
 	tests := []struct {
 		name string
@@ -314,6 +461,62 @@ func TestNewBaseConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "7",
+			args: args{
+				conf: testJSONFromString(`{"writeMode":"upsert"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "8",
+			args: args{
+				conf: testJSONFromString(`{"writeMode":"upsert","keyColumn":[]}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "9",
+			args: args{
+				conf: testJSONFromString(`{"writeMode":"update"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "10",
+			args: args{
+				conf: testJSONFromString(`{"writeMode":"delete"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "11",
+			args: args{
+				conf: testJSONFromString(`{"connection":{"table":[{"name":"a"},{"name":"b"}]}}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "12",
+			args: args{
+				conf: testJSONFromString(`{"writeMode":"upsert","keyColumn":["a"],"batchConcurrency":2}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "13",
+			args: args{
+				conf: testJSONFromString(`{"writeMode":"update","keyColumn":["a"],"batchConcurrency":2}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "14",
+			args: args{
+				conf: testJSONFromString(`{"writeMode":"delete","keyColumn":["a"],"batchConcurrency":2}`),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
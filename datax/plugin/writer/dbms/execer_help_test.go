@@ -18,8 +18,10 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
@@ -108,6 +110,24 @@ func (m *MockTable) SetConfig(conf *config.JSON) {
 	m.conf = conf
 }
 
+type MockTableCreator struct {
+	*MockTable
+}
+
+func (m *MockTableCreator) CreateTableQuery(columns []database.TableColumn, _ *database.TypeMapping) (string, error) {
+	var names []string
+	for _, c := range columns {
+		names = append(names, c.Name+" "+c.Type.DatabaseTypeName()+" "+c.Kind.String())
+	}
+	return "create table " + m.Quoted() + "(" + strings.Join(names, ",") + ")", nil
+}
+
+var errMockTableNotExist = errors.New("mock table not exist")
+
+func (m *MockTableCreator) IsTableNotExist(err error) bool {
+	return err == errMockTableNotExist
+}
+
 type MockTableWithJudger struct {
 	*MockTable
 
@@ -140,9 +160,15 @@ type MockExecer struct {
 	BatchErr error
 	ExecErr  error
 	config   *config.JSON
+	table    database.Table
+	creator  bool
+	query    string
 }
 
 func (m *MockExecer) Table(bt *database.BaseTable) database.Table {
+	if m.creator {
+		return &MockTableCreator{MockTable: NewMockTable(bt)}
+	}
 	return NewMockTable(bt)
 }
 
@@ -155,6 +181,7 @@ func (m *MockExecer) QueryContext(ctx context.Context, query string, args ...int
 }
 
 func (m *MockExecer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	m.query = query
 	if query == "wait" {
 		time.Sleep(100 * time.Millisecond)
 	}
@@ -162,6 +189,9 @@ func (m *MockExecer) ExecContext(ctx context.Context, query string, args ...inte
 }
 
 func (m *MockExecer) FetchTableWithParam(ctx context.Context, param database.Parameter) (database.Table, error) {
+	if m.table != nil {
+		return m.table, m.FetchErr
+	}
 	return NewMockTable(nil), m.FetchErr
 }
 
@@ -16,11 +16,13 @@ package dbms
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
 	coreconst "github.com/Breeze0806/go-etl/datax/common/config/core"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/pingcap/errors"
 )
 
@@ -31,6 +33,9 @@ type Job struct {
 	Handler DbHandler //数据库句柄
 	Execer  Execer    //执行器
 	conf    Config    //配置
+	dialect string    //数据库方言
+
+	checkTable database.Table //预检查时获取的表
 }
 
 // NewJob 通过数据库句柄获取工作
@@ -47,6 +52,11 @@ func (j *Job) Init(ctx context.Context) (err error) {
 	if name, err = j.PluginConf().GetString("dialect"); err != nil {
 		return errors.Wrapf(err, "GetString fail")
 	}
+	j.dialect = name
+
+	if err = j.resolveTableName(); err != nil {
+		return errors.Wrapf(err, "resolveTableName fail")
+	}
 
 	if j.conf, err = j.Handler.Config(j.PluginJobConf()); err != nil {
 		return errors.Wrapf(err, "Config fail")
@@ -73,6 +83,132 @@ func (j *Job) Init(ctx context.Context) (err error) {
 	return
 }
 
+// resolveTableName 将目标表名中的通配符*替换为读取器的表名，
+// 用于多表同步时每张源表写入各自对应的目标表，如ods_*
+func (j *Job) resolveTableName() (err error) {
+	name := j.PluginJobConf().GetStringOrDefaullt("connection.table.name", "")
+	if !strings.Contains(name, "*") {
+		return nil
+	}
+	var source string
+	if j.PeerPluginJobConf() != nil {
+		source = j.PeerPluginJobConf().GetStringOrDefaullt("connection.table.name", "")
+	}
+	if source == "" {
+		return errors.Errorf("table %v needs the table name of reader", name)
+	}
+	return j.PluginJobConf().Set("connection.table.name", strings.ReplaceAll(name, "*", source))
+}
+
+// PreCheck 预检查，通过查询表结构检查表以及配置的列是否存在，
+// 开启自动建表时目标表不存在不视为错误
+func (j *Job) PreCheck(ctx context.Context) (err error) {
+	var table database.Table
+	if table, err = j.fetchTable(ctx); err != nil {
+		if j.conf.GetAutoCreateTable() && j.isTableNotExist(err) {
+			log.Infof("jobID: %v table %v will be created automatically. err: %v",
+				j.JobID(), j.Handler.TableParam(j.conf, j.Execer).Table(), err)
+			return nil
+		}
+		return errors.Wrapf(err, "FetchTableWithParam fail")
+	}
+	j.checkTable = table
+	return
+}
+
+// AutoCreateTable 是否开启自动建表
+func (j *Job) AutoCreateTable() bool {
+	return j.conf.GetAutoCreateTable()
+}
+
+// CreateTable 目标表不存在时根据源表的列columns创建目标表，
+// 配置的列不为*时按照顺序作为目标表的列名，此时列数需要和源表的列数一致
+func (j *Job) CreateTable(ctx context.Context, columns []database.TableColumn) (err error) {
+	table := j.Handler.TableParam(j.conf, j.Execer).Table()
+	if _, err = j.fetchTable(ctx); err == nil {
+		log.Infof("jobID: %v table %v exists", j.JobID(), table)
+		return nil
+	}
+
+	creator, ok := table.(database.TableCreator)
+	if !ok {
+		return errors.Errorf("table %v does not support to be created automatically", table)
+	}
+	if !creator.IsTableNotExist(err) {
+		return errors.Wrapf(err, "FetchTableWithParam fail")
+	}
+
+	var m *database.TypeMapping
+	if m, err = j.conf.GetTypeMapping(j.dialect); err != nil {
+		return errors.Wrapf(err, "GetTypeMapping fail")
+	}
+	if columns, err = j.tableColumns(columns, m); err != nil {
+		return errors.Wrapf(err, "tableColumns fail")
+	}
+
+	var query string
+	if query, err = creator.CreateTableQuery(columns, m); err != nil {
+		return errors.Wrapf(err, "CreateTableQuery fail")
+	}
+	log.Infof("jobID: %v create table %v: %v", j.JobID(), table, query)
+	if _, err = j.Execer.ExecContext(ctx, query); err != nil {
+		return errors.Wrapf(err, "ExecContext(%v) fail", query)
+	}
+	return
+}
+
+// isTableNotExist 获取表结构的错误err是否由目标表不存在导致，不支持自动建表时为false
+func (j *Job) isTableNotExist(err error) bool {
+	creator, ok := j.Handler.TableParam(j.conf, j.Execer).Table().(database.TableCreator)
+	return ok && creator.IsTableNotExist(err)
+}
+
+// fetchTable 通过查询表结构获取目标表
+func (j *Job) fetchTable(ctx context.Context) (database.Table, error) {
+	param := j.Handler.TableParam(j.conf, j.Execer)
+	if setter, ok := param.Table().(database.ConfigSetter); ok {
+		setter.SetConfig(j.PluginJobConf())
+	}
+	return j.Execer.FetchTableWithParam(ctx, param)
+}
+
+// tableColumns 根据源表的列source，配置的列以及类型映射m获取建表时的列，
+// 工作覆盖的列类别优先于源表的列类别
+func (j *Job) tableColumns(source []database.TableColumn, m *database.TypeMapping) (columns []database.TableColumn, err error) {
+	names := j.conf.GetColumns()
+	if len(names) == 1 && names[0].GetName() == "*" {
+		names = nil
+	}
+	if len(names) != 0 && len(names) != len(source) {
+		return nil, errors.Errorf("the number of columns %v is not equal to the number of source columns %v",
+			len(names), len(source))
+	}
+	for i, c := range source {
+		if len(names) != 0 {
+			c.Name = names[i].GetName()
+		}
+		if kind, ok := m.OverrideKind(c.Name, c.Type); ok {
+			c.Kind = kind
+		}
+		columns = append(columns, c)
+	}
+	return
+}
+
+// CheckColumns 预检查后获取写入的列
+func (j *Job) CheckColumns() (columns []plugin.CheckColumn) {
+	if j.checkTable == nil {
+		return
+	}
+	for _, f := range j.checkTable.Fields() {
+		columns = append(columns, plugin.CheckColumn{
+			Name: f.Name(),
+			Type: database.FieldColumnType(f),
+		})
+	}
+	return
+}
+
 // Prepare 准备
 func (j *Job) Prepare(ctx context.Context) (err error) {
 	preSQL := j.conf.GetPreSQL()
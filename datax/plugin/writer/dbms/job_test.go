@@ -23,6 +23,8 @@ import (
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
 )
 
 func newMockDbHandler(newExecer func(name string, conf *config.JSON) (Execer, error)) DbHandler {
@@ -409,3 +411,212 @@ func TestJob_Post(t *testing.T) {
 		})
 	}
 }
+
+func TestJob_PreCheck(t *testing.T) {
+	table := NewMockTable(database.NewBaseTable("db", "schema", "name"))
+	table.AddField(database.NewBaseField(0, "f1", NewMockFieldType(database.GoTypeFloat64)))
+	table.AddField(database.NewBaseField(1, "f2", NewMockFieldType(database.GoTypeString)))
+	newJob := func(e *MockExecer) *Job {
+		return &Job{
+			BaseJob: plugin.NewBaseJob(),
+			conf:    &BaseConfig{},
+			Execer:  e,
+			Handler: newMockDbHandler(func(name string, conf *config.JSON) (Execer, error) {
+				return e, nil
+			}),
+		}
+	}
+	tests := []struct {
+		name        string
+		j           *Job
+		wantErr     bool
+		wantColumns []plugin.CheckColumn
+	}{
+		{
+			name: "1",
+			j:    newJob(&MockExecer{table: table}),
+			wantColumns: []plugin.CheckColumn{
+				{Name: "f1", Type: element.TypeDecimal},
+				{Name: "f2", Type: element.TypeString},
+			},
+		},
+		{
+			name:    "2",
+			j:       newJob(&MockExecer{FetchErr: errors.New("mock error")}),
+			wantErr: true,
+		},
+		{
+			name: "3",
+			j: func() *Job {
+				j := newJob(&MockExecer{creator: true, FetchErr: errMockTableNotExist})
+				j.conf = &BaseConfig{AutoCreateTable: true}
+				return j
+			}(),
+		},
+		{
+			name: "4",
+			j: func() *Job {
+				j := newJob(&MockExecer{creator: true, FetchErr: errors.New("mock error")})
+				j.conf = &BaseConfig{AutoCreateTable: true}
+				return j
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.j.PreCheck(context.TODO()); (err != nil) != tt.wantErr {
+				t.Errorf("Job.PreCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := tt.j.CheckColumns(); !reflect.DeepEqual(got, tt.wantColumns) {
+				t.Errorf("Job.CheckColumns() = %v, want %v", got, tt.wantColumns)
+			}
+		})
+	}
+}
+
+func TestJob_resolveTableName(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     string
+		peerConf *config.JSON
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			conf:     `{"connection":{"table":{"name":"dst"}}}`,
+			peerConf: testJSONFromString(`{"connection":{"table":{"name":"src"}}}`),
+			want:     "dst",
+		},
+		{
+			name:     "2",
+			conf:     `{"connection":{"table":{"name":"*"}}}`,
+			peerConf: testJSONFromString(`{"connection":{"table":{"name":"src"}}}`),
+			want:     "src",
+		},
+		{
+			name:     "3",
+			conf:     `{"connection":{"table":{"name":"ods_*"}}}`,
+			peerConf: testJSONFromString(`{"connection":{"table":{"name":"src"}}}`),
+			want:     "ods_src",
+		},
+		{
+			name:     "4",
+			conf:     `{"connection":{"table":{"name":"ods_*"}}}`,
+			peerConf: testJSONFromString(`{"path":"a.csv"}`),
+			wantErr:  true,
+		},
+		{
+			name:    "5",
+			conf:    `{"connection":{"table":{"name":"ods_*"}}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := NewJob(nil)
+			j.SetPluginJobConf(testJSONFromString(tt.conf))
+			if tt.peerConf != nil {
+				j.SetPeerPluginJobConf(tt.peerConf)
+			}
+			if err := j.resolveTableName(); (err != nil) != tt.wantErr {
+				t.Errorf("Job.resolveTableName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := j.PluginJobConf().GetStringOrDefaullt("connection.table.name", ""); got != tt.want {
+				t.Errorf("Job.resolveTableName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJob_CreateTable(t *testing.T) {
+	source := []database.TableColumn{
+		{Name: "f1", Type: NewMockFieldType(database.GoTypeInt64)},
+		{Name: "f2", Type: NewMockFieldType(database.GoTypeString), Kind: database.ColumnKindString},
+	}
+	newJob := func(c *BaseConfig, e *MockExecer) *Job {
+		c.Connection.Table.Db = "db"
+		c.Connection.Table.Schema = "schema"
+		c.Connection.Table.Name = "dst"
+		return &Job{
+			BaseJob: plugin.NewBaseJob(),
+			conf:    c,
+			Execer:  e,
+			Handler: newMockDbHandler(func(name string, conf *config.JSON) (Execer, error) {
+				return e, nil
+			}),
+		}
+	}
+	tests := []struct {
+		name      string
+		j         *Job
+		wantErr   bool
+		wantQuery string
+	}{
+		{
+			name: "1",
+			j:    newJob(&BaseConfig{AutoCreateTable: true}, &MockExecer{creator: true}),
+		},
+		{
+			name:      "2",
+			j:         newJob(&BaseConfig{Column: []string{"*"}}, &MockExecer{creator: true, FetchErr: errMockTableNotExist}),
+			wantQuery: "create table db.schema.dst(f1 2 unknown,f2 4 string)",
+		},
+		{
+			name:      "3",
+			j:         newJob(&BaseConfig{Column: []string{"c1", "c2"}}, &MockExecer{creator: true, FetchErr: errMockTableNotExist}),
+			wantQuery: "create table db.schema.dst(c1 2 unknown,c2 4 string)",
+		},
+		{
+			name:    "4",
+			j:       newJob(&BaseConfig{Column: []string{"c1"}}, &MockExecer{creator: true, FetchErr: errMockTableNotExist}),
+			wantErr: true,
+		},
+		{
+			name: "7",
+			j: newJob(&BaseConfig{Column: []string{"c1", "c2"}, TypeMapping: &database.TypeMappingConfig{
+				Columns: map[string]string{"c1": "bool"},
+			}}, &MockExecer{creator: true, FetchErr: errMockTableNotExist}),
+			wantQuery: "create table db.schema.dst(c1 2 bool,c2 4 string)",
+		},
+		{
+			name: "8",
+			j: newJob(&BaseConfig{TypeMapping: &database.TypeMappingConfig{
+				DDL: map[string]string{"boolean": "bit"},
+			}}, &MockExecer{creator: true, FetchErr: errMockTableNotExist}),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			j:       newJob(&BaseConfig{}, &MockExecer{FetchErr: errors.New("mock error")}),
+			wantErr: true,
+		},
+		{
+			name: "6",
+			j: newJob(&BaseConfig{}, &MockExecer{creator: true, FetchErr: errMockTableNotExist,
+				ExecErr: errors.New("mock error")}),
+			wantErr:   true,
+			wantQuery: "create table db.schema.dst(f1 2 unknown,f2 4 string)",
+		},
+		{
+			name:    "9",
+			j:       newJob(&BaseConfig{}, &MockExecer{creator: true, FetchErr: errors.New("mock error")}),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.j.CreateTable(context.TODO(), source); (err != nil) != tt.wantErr {
+				t.Errorf("Job.CreateTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := tt.j.Execer.(*MockExecer).query; got != tt.wantQuery {
+				t.Errorf("Job.CreateTable() query = %v, want %v", got, tt.wantQuery)
+			}
+		})
+	}
+}
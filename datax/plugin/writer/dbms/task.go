@@ -90,3 +90,16 @@ func (t *Task) Destroy(ctx context.Context) (err error) {
 	}
 	return t.Wrapf(err, "Close fail")
 }
+
+// SupportFailOver 写入模式为upsert、update或者delete时重复写入的结果相同，支持故障转移，
+// 即任务失败后重新执行整个任务
+func (t *Task) SupportFailOver() bool {
+	if t.Config == nil {
+		return false
+	}
+	switch t.Config.GetWriteMode() {
+	case database.WriteModeUpsert, database.WriteModeUpdate, database.WriteModeDelete:
+		return true
+	}
+	return false
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/common/spi/writer"
+	"github.com/Breeze0806/go-etl/storage/database"
 )
 
 func TestTask_Init(t *testing.T) {
@@ -215,3 +216,59 @@ func TestTask_Destroy(t *testing.T) {
 		})
 	}
 }
+
+func TestTask_SupportFailOver(t *testing.T) {
+	tests := []struct {
+		name string
+		t    *Task
+		want bool
+	}{
+		{
+			name: "1",
+			t:    NewTask(nil),
+			want: false,
+		},
+		{
+			name: "2",
+			t: &Task{
+				Config: &BaseConfig{WriteMode: database.WriteModeInsert},
+			},
+			want: false,
+		},
+		{
+			name: "3",
+			t: &Task{
+				Config: &BaseConfig{WriteMode: database.WriteModeUpsert},
+			},
+			want: true,
+		},
+		{
+			name: "4",
+			t: &Task{
+				Config: &BaseConfig{WriteMode: database.WriteModeUpdate},
+			},
+			want: true,
+		},
+		{
+			name: "5",
+			t: &Task{
+				Config: &BaseConfig{WriteMode: database.WriteModeDelete},
+			},
+			want: true,
+		},
+		{
+			name: "6",
+			t: &Task{
+				Config: &BaseConfig{WriteMode: "copyIn"},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.SupportFailOver(); got != tt.want {
+				t.Errorf("Task.SupportFailOver() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package file
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/Breeze0806/go-etl/config"
@@ -31,12 +32,16 @@ var (
 type Config interface {
 	GetBatchSize() int              //单次批量写入数
 	GetBatchTimeout() time.Duration //单次批量写入超时时间
+	GetMaxRowsPerFile() int         //单个文件最大记录数，0代表不限制
+	GetMaxBytesPerFile() int64      //单个文件最大字节数，0代表不限制
 }
 
 // BaseConfig 基础文件流输出配置
 type BaseConfig struct {
-	BatchSize    int            `json:"batchSize"`    //单次批量写入数
-	BatchTimeout time2.Duration `json:"batchTimeout"` //单次批量写入超时时间
+	BatchSize       int            `json:"batchSize"`                 //单次批量写入数
+	BatchTimeout    time2.Duration `json:"batchTimeout"`              //单次批量写入超时时间
+	MaxRowsPerFile  int            `json:"maxRowsPerFile,omitempty"`  //单个文件最大记录数，超过后写入下一个编号文件
+	MaxBytesPerFile int64          `json:"maxBytesPerFile,omitempty"` //单个文件最大字节数，超过后写入下一个编号文件
 }
 
 // NewBaseConfig 通过json配置获取基础文件流输出配置
@@ -45,6 +50,12 @@ func NewBaseConfig(conf *config.JSON) (*BaseConfig, error) {
 	if err := json.Unmarshal([]byte(conf.String()), c); err != nil {
 		return nil, err
 	}
+	if c.MaxRowsPerFile < 0 {
+		return nil, fmt.Errorf("maxRowsPerFile(%v) should not be negative", c.MaxRowsPerFile)
+	}
+	if c.MaxBytesPerFile < 0 {
+		return nil, fmt.Errorf("maxBytesPerFile(%v) should not be negative", c.MaxBytesPerFile)
+	}
 	return c, nil
 }
 
@@ -64,3 +75,13 @@ func (b *BaseConfig) GetBatchSize() int {
 
 	return b.BatchSize
 }
+
+// GetMaxRowsPerFile 单个文件最大记录数，0代表不限制
+func (b *BaseConfig) GetMaxRowsPerFile() int {
+	return b.MaxRowsPerFile
+}
+
+// GetMaxBytesPerFile 单个文件最大字节数，0代表不限制
+func (b *BaseConfig) GetMaxBytesPerFile() int64 {
+	return b.MaxBytesPerFile
+}
@@ -89,3 +89,57 @@ func TestBaseConfig_GetBatchSize(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBaseConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		conf         *config.JSON
+		wantMaxRows  int
+		wantMaxBytes int64
+		wantErr      bool
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{}`),
+		},
+		{
+			name:         "2",
+			conf:         testJSONFromString(`{"maxRowsPerFile":100,"maxBytesPerFile":1048576}`),
+			wantMaxRows:  100,
+			wantMaxBytes: 1048576,
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"maxRowsPerFile":-1}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"maxBytesPerFile":-1}`),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    testJSONFromString(`{"maxBytesPerFile":"1"}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBaseConfig(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBaseConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.GetMaxRowsPerFile() != tt.wantMaxRows {
+				t.Errorf("GetMaxRowsPerFile() = %v, want %v", got.GetMaxRowsPerFile(), tt.wantMaxRows)
+			}
+			if got.GetMaxBytesPerFile() != tt.wantMaxBytes {
+				t.Errorf("GetMaxBytesPerFile() = %v, want %v", got.GetMaxBytesPerFile(), tt.wantMaxBytes)
+			}
+		})
+	}
+}
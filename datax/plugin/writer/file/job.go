@@ -16,8 +16,13 @@ package file
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/Breeze0806/go-etl/datax/common/plugin"
+	"github.com/pingcap/errors"
 )
 
 // Job 工作
@@ -36,3 +41,25 @@ func NewJob() *Job {
 func (j *Job) Destroy(ctx context.Context) (err error) {
 	return
 }
+
+// CheckWritable 检查文件路径paths是否都可以写入，文件所在目录需要存在并且可以在其中创建文件
+func CheckWritable(paths ...string) error {
+	var msgs []string
+	for _, path := range paths {
+		if fi, err := os.Stat(path); err == nil && fi.IsDir() {
+			msgs = append(msgs, path+" is a directory")
+			continue
+		}
+		f, err := ioutil.TempFile(filepath.Dir(path), ".datax-check-")
+		if err != nil {
+			msgs = append(msgs, err.Error())
+			continue
+		}
+		f.Close()
+		os.Remove(f.Name())
+	}
+	if len(msgs) != 0 {
+		return errors.NewNoStackError(strings.Join(msgs, "; "))
+	}
+	return nil
+}
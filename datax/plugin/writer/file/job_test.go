@@ -16,6 +16,9 @@ package file
 
 import (
 	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -45,3 +48,45 @@ func TestJob_Destroy(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckWritable(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	tests := []struct {
+		name    string
+		paths   []string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			paths: []string{filepath.Join(tmpDir, "a.csv")},
+		},
+		{
+			name:    "2",
+			paths:   []string{filepath.Join(tmpDir, "a.csv"), filepath.Join(tmpDir, "none", "b.csv")},
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			paths:   []string{tmpDir},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckWritable(tt.paths...); (err != nil) != tt.wantErr {
+				t.Errorf("CheckWritable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	files, err := ioutil.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("CheckWritable() leaves files %v", files)
+	}
+}
@@ -16,6 +16,9 @@ package file
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -35,6 +38,12 @@ type Task struct {
 	conf      Config
 	newConfig func(conf *config.JSON) (Config, error)
 	content   *config.JSON
+
+	creator  string //文件创建器名
+	filename string //配置的文件名
+	fileNum  int    //当前文件编号，从1开始
+	rows     int    //当前文件已写入记录数
+	bytes    int64  //当前文件刷新后已写入的字节数
 }
 
 // NewTask 通过获取配置newConfig创建任务
@@ -47,12 +56,10 @@ func NewTask(newConfig func(conf *config.JSON) (Config, error)) *Task {
 
 // Init 初始化
 func (t *Task) Init(ctx context.Context) (err error) {
-	var name string
-	if name, err = t.PluginConf().GetString("creator"); err != nil {
+	if t.creator, err = t.PluginConf().GetString("creator"); err != nil {
 		return t.Wrapf(err, "GetString fail")
 	}
-	var filename string
-	if filename, err = t.PluginJobConf().GetString("path"); err != nil {
+	if t.filename, err = t.PluginJobConf().GetString("path"); err != nil {
 		return t.Wrapf(err, "GetString fail")
 	}
 
@@ -64,7 +71,7 @@ func (t *Task) Init(ctx context.Context) (err error) {
 		return t.Wrapf(err, "newConfig fail")
 	}
 
-	if t.streamer, err = file.NewOutStreamer(name, filename); err != nil {
+	if t.streamer, err = file.NewOutStreamer(t.creator, t.nextFilename()); err != nil {
 		return t.Wrapf(err, "NewOutStreamer fail")
 	}
 
@@ -85,6 +92,10 @@ func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (
 	if sw, err = t.streamer.Writer(t.content); err != nil {
 		return t.Wrapf(err, "Writer fail")
 	}
+	if err = t.checkWrittenSize(sw); err != nil {
+		sw.Close()
+		return t.Wrapf(err, "checkWrittenSize fail")
+	}
 
 	recordChan := make(chan element.Record)
 	var rerr error
@@ -134,7 +145,7 @@ func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (
 			if !ok {
 				//当写入结束时，将剩余的记录写入数据库
 				if cnt > 0 {
-					if err = sw.Flush(); err != nil {
+					if err = t.flush(sw); err != nil {
 						log.Errorf(t.Format("Flush error: %v"), err)
 					}
 				}
@@ -144,15 +155,25 @@ func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (
 				goto End
 			}
 
+			//超过单个文件的限制时，切换到下一个编号文件
+			if t.needRotate() {
+				if sw, err = t.rotate(sw); err != nil {
+					log.Errorf(t.Format("rotate error: %v"), err)
+					goto End
+				}
+				cnt = 0
+			}
+
 			//写入文件
 			if err = sw.Write(record); err != nil {
 				log.Errorf(t.Format("Write error: %v"), err)
 				goto End
 			}
 			cnt++
+			t.rows++
 			//当数据量超过单次批量数时 写入文件
 			if cnt >= t.conf.GetBatchSize() {
-				if err = sw.Flush(); err != nil {
+				if err = t.flush(sw); err != nil {
 					log.Errorf(t.Format("Flush error: %v"), err)
 					goto End
 				}
@@ -161,7 +182,7 @@ func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (
 		//当写入数据未达到单次批量数，超时也写入
 		case <-ticker.C:
 			if cnt > 0 {
-				if err = sw.Flush(); err != nil {
+				if err = t.flush(sw); err != nil {
 					log.Errorf(t.Format("Flush error: %v"), err)
 					goto End
 				}
@@ -170,8 +191,11 @@ func (t *Task) StartWrite(ctx context.Context, receiver plugin.RecordReceiver) (
 		}
 	}
 End:
-	if cerr := sw.Close(); cerr != nil {
-		log.Errorf(t.Format("Close error: %v"), cerr)
+	//切换文件失败时sw已经在切换时关闭并且为空
+	if sw != nil {
+		if cerr := sw.Close(); cerr != nil {
+			log.Errorf(t.Format("Close error: %v"), cerr)
+		}
 	}
 	cancel()
 	log.Debugf(t.Format("wait all goroutine"))
@@ -188,3 +212,97 @@ End:
 	}
 	return t.Wrapf(err, "")
 }
+
+// rotated 是否按照maxRowsPerFile或者maxBytesPerFile切换文件
+func (t *Task) rotated() bool {
+	return t.conf.GetMaxRowsPerFile() > 0 || t.conf.GetMaxBytesPerFile() > 0
+}
+
+// needRotate 写入下一条记录前是否需要切换到下一个文件，每个文件至少写入一条记录，
+// 字节数为最近一次刷新后文件的实际大小
+func (t *Task) needRotate() bool {
+	if t.rows == 0 {
+		return false
+	}
+	if max := t.conf.GetMaxRowsPerFile(); max > 0 && t.rows >= max {
+		return true
+	}
+	if max := t.conf.GetMaxBytesPerFile(); max > 0 && t.bytes >= max {
+		return true
+	}
+	return false
+}
+
+// checkWrittenSize 配置了maxBytesPerFile时检查写入器sw能否获取已经写入文件的字节数
+func (t *Task) checkWrittenSize(sw file.StreamWriter) (err error) {
+	if t.conf.GetMaxBytesPerFile() <= 0 {
+		return nil
+	}
+	sizer, ok := sw.(file.WrittenSizer)
+	if !ok {
+		return fmt.Errorf("maxBytesPerFile is not supported by %v", t.creator)
+	}
+	_, err = sizer.WrittenSize()
+	return
+}
+
+// flush 刷新写入器sw，配置了maxBytesPerFile时获取当前文件已经写入的字节数
+func (t *Task) flush(sw file.StreamWriter) (err error) {
+	if err = sw.Flush(); err != nil {
+		return
+	}
+	if t.conf.GetMaxBytesPerFile() > 0 {
+		if t.bytes, err = sw.(file.WrittenSizer).WrittenSize(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// rotate 关闭当前的写入器sw和文件，创建下一个编号文件并返回其写入器，
+// 无论是否出错当前的写入器sw都会被关闭，出错时返回的写入器为空
+func (t *Task) rotate(sw file.StreamWriter) (file.StreamWriter, error) {
+	err := sw.Flush()
+	//刷新失败时也需要关闭写入器，防止压缩写入器泄漏以及tar的临时文件残留
+	if cerr := sw.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = t.streamer.Close(); err != nil {
+		return nil, err
+	}
+	t.streamer = nil
+
+	if t.streamer, err = file.NewOutStreamer(t.creator, t.nextFilename()); err != nil {
+		return nil, err
+	}
+	t.rows = 0
+	t.bytes = 0
+	return t.streamer.Writer(t.content)
+}
+
+// nextFilename 获取下一个写入的文件名，切换文件时文件名会加上编号
+func (t *Task) nextFilename() string {
+	if !t.rotated() {
+		return t.filename
+	}
+	t.fileNum++
+	return numberedFilename(t.filename, t.fileNum)
+}
+
+// numberedFilename 在文件名filename的第一个扩展名前加上编号num，
+// 如out.csv.gz的第1个文件为out_0001.csv.gz
+func numberedFilename(filename string, num int) string {
+	dir, base := filepath.Split(filename)
+	suffix, start := "", 0
+	//忽略隐藏文件开头的.
+	if strings.HasPrefix(base, ".") {
+		start = 1
+	}
+	if i := strings.Index(base[start:], "."); i >= 0 {
+		base, suffix = base[:start+i], base[start+i:]
+	}
+	return dir + fmt.Sprintf("%s_%04d%s", base, num, suffix)
+}
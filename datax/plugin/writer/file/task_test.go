@@ -17,6 +17,8 @@ package file
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -70,6 +72,7 @@ type mockStreamWriter struct {
 	writeErr error
 	flushErr error
 	closeErr error
+	closed   int
 }
 
 func (m *mockStreamWriter) Write(record element.Record) (err error) {
@@ -82,9 +85,38 @@ func (m *mockStreamWriter) Flush() (err error) {
 }
 
 func (m *mockStreamWriter) Close() (err error) {
+	m.closed++
 	return m.closeErr
 }
 
+type mockSizedStreamWriter struct {
+	*mockStreamWriter
+
+	buffered int64
+	written  int64
+	sizeErr  error
+}
+
+func (m *mockSizedStreamWriter) Write(record element.Record) (err error) {
+	m.buffered += record.ByteSize()
+	return m.mockStreamWriter.Write(record)
+}
+
+func (m *mockSizedStreamWriter) Flush() (err error) {
+	m.written += m.buffered
+	m.buffered = 0
+	return m.mockStreamWriter.Flush()
+}
+
+func (m *mockSizedStreamWriter) Close() (err error) {
+	m.written, m.buffered = 0, 0
+	return m.mockStreamWriter.Close()
+}
+
+func (m *mockSizedStreamWriter) WrittenSize() (int64, error) {
+	return m.written, m.sizeErr
+}
+
 type mockOutStream struct {
 	writer    file.StreamWriter
 	writerErr error
@@ -99,13 +131,32 @@ func (m *mockOutStream) Close() (err error) {
 }
 
 type mockCreater struct {
-	stream file.OutStream
+	stream    file.OutStream
+	filenames []string
 }
 
 func (m *mockCreater) Create(filename string) (stream file.OutStream, err error) {
+	m.filenames = append(m.filenames, filename)
 	return m.stream, nil
 }
 
+type mockRecordsReceiver struct {
+	records []element.Record
+}
+
+func (m *mockRecordsReceiver) GetFromReader() (element.Record, error) {
+	if len(m.records) == 0 {
+		return nil, exchange.ErrTerminate
+	}
+	record := m.records[0]
+	m.records = m.records[1:]
+	return record, nil
+}
+
+func (m *mockRecordsReceiver) Shutdown() error {
+	return nil
+}
+
 func TestTask_StartWrite(t *testing.T) {
 	file.UnregisterAllCreater()
 	file.RegisterCreator("mock", &mockCreater{
@@ -484,3 +535,201 @@ func TestTask_StartWriteCancel(t *testing.T) {
 		})
 	}
 }
+
+func TestTask_StartWriteRotate(t *testing.T) {
+	newRecords := func(sizes ...int) (records []element.Record) {
+		for _, v := range sizes {
+			record := element.NewDefaultRecord()
+			record.Add(element.NewDefaultColumn(element.NewStringColumnValue(strings.Repeat("a", v)), "a", v))
+			records = append(records, record)
+		}
+		return
+	}
+	tests := []struct {
+		name          string
+		records       []element.Record
+		jobConf       *config.JSON
+		writer        file.StreamWriter
+		writerErr     error
+		wantFilenames []string
+		wantErr       bool
+	}{
+		{
+			name:          "1",
+			records:       newRecords(1, 1, 1, 1, 1, 1, 1),
+			jobConf:       testJSONFromString(`{"path":"/data/out.csv.gz","content":{}}`),
+			wantFilenames: []string{"/data/out.csv.gz"},
+		},
+		{
+			name:          "2",
+			records:       newRecords(1, 1, 1, 1, 1, 1, 1),
+			jobConf:       testJSONFromString(`{"path":"/data/out.csv.gz","content":{"maxRowsPerFile":3}}`),
+			wantFilenames: []string{"/data/out_0001.csv.gz", "/data/out_0002.csv.gz", "/data/out_0003.csv.gz"},
+		},
+		{
+			name:          "3",
+			records:       newRecords(4, 4, 4, 10, 1),
+			jobConf:       testJSONFromString(`{"path":"out.csv","content":{"maxBytesPerFile":8,"batchSize":1}}`),
+			writer:        &mockSizedStreamWriter{mockStreamWriter: &mockStreamWriter{}},
+			wantFilenames: []string{"out_0001.csv", "out_0002.csv", "out_0003.csv"},
+		},
+		{
+			name:          "4",
+			records:       newRecords(1, 1, 1),
+			jobConf:       testJSONFromString(`{"path":"out","content":{"maxRowsPerFile":2,"maxBytesPerFile":100}}`),
+			writer:        &mockSizedStreamWriter{mockStreamWriter: &mockStreamWriter{}},
+			wantFilenames: []string{"out_0001", "out_0002"},
+		},
+		{
+			name:          "5",
+			records:       newRecords(1, 1, 1),
+			jobConf:       testJSONFromString(`{"path":"out.csv","content":{"maxRowsPerFile":1}}`),
+			writerErr:     errors.New("mock error"),
+			wantFilenames: []string{"out_0001.csv"},
+			wantErr:       true,
+		},
+		{
+			name:          "6",
+			records:       newRecords(4, 4, 4, 4, 4),
+			jobConf:       testJSONFromString(`{"path":"out.csv","content":{"maxBytesPerFile":8,"batchSize":2}}`),
+			writer:        &mockSizedStreamWriter{mockStreamWriter: &mockStreamWriter{}},
+			wantFilenames: []string{"out_0001.csv", "out_0002.csv", "out_0003.csv"},
+		},
+		{
+			name:          "7",
+			records:       newRecords(1, 1, 1),
+			jobConf:       testJSONFromString(`{"path":"out.csv","content":{"maxBytesPerFile":8}}`),
+			wantFilenames: []string{"out_0001.csv"},
+			wantErr:       true,
+		},
+		{
+			name:    "8",
+			records: newRecords(1, 1, 1),
+			jobConf: testJSONFromString(`{"path":"out.csv","content":{"maxBytesPerFile":8}}`),
+			writer: &mockSizedStreamWriter{mockStreamWriter: &mockStreamWriter{},
+				sizeErr: errors.New("mock error")},
+			wantFilenames: []string{"out_0001.csv"},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file.UnregisterAllCreater()
+			if tt.writer == nil {
+				tt.writer = &mockStreamWriter{}
+			}
+			stream := &mockOutStream{
+				writer: tt.writer,
+			}
+			creator := &mockCreater{
+				stream: stream,
+			}
+			file.RegisterCreator("mock", creator)
+
+			task := NewTask(func(conf *config.JSON) (Config, error) {
+				c, err := NewBaseConfig(conf)
+				if err != nil {
+					return nil, err
+				}
+				return c, nil
+			})
+			task.SetPluginConf(testJSONFromString(`{"creator":"mock"}`))
+			task.SetPluginJobConf(tt.jobConf)
+			defer task.Destroy(context.TODO())
+			if err := task.Init(context.TODO()); err != nil {
+				t.Fatalf("Task.Init() error = %v", err)
+			}
+			stream.writerErr = tt.writerErr
+			err := task.StartWrite(context.TODO(), &mockRecordsReceiver{records: tt.records})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Task.StartWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(creator.filenames, tt.wantFilenames) {
+				t.Errorf("filenames = %v, want %v", creator.filenames, tt.wantFilenames)
+			}
+		})
+	}
+}
+
+func TestTask_StartWriteRotateFlushError(t *testing.T) {
+	file.UnregisterAllCreater()
+	writer := &mockStreamWriter{flushErr: errors.New("mock error")}
+	stream := &mockOutStream{
+		writer: writer,
+	}
+	creator := &mockCreater{
+		stream: stream,
+	}
+	file.RegisterCreator("mock", creator)
+
+	task := NewTask(func(conf *config.JSON) (Config, error) {
+		c, err := NewBaseConfig(conf)
+		if err != nil {
+			return nil, err
+		}
+		return c, nil
+	})
+	task.SetPluginConf(testJSONFromString(`{"creator":"mock"}`))
+	task.SetPluginJobConf(testJSONFromString(`{"path":"out.csv","content":{"maxRowsPerFile":1}}`))
+	defer task.Destroy(context.TODO())
+	if err := task.Init(context.TODO()); err != nil {
+		t.Fatalf("Task.Init() error = %v", err)
+	}
+	records := []element.Record{element.NewDefaultRecord(), element.NewDefaultRecord()}
+	if err := task.StartWrite(context.TODO(), &mockRecordsReceiver{records: records}); err == nil {
+		t.Fatalf("Task.StartWrite() error = %v, wantErr true", err)
+	}
+	if !reflect.DeepEqual(creator.filenames, []string{"out_0001.csv"}) {
+		t.Errorf("filenames = %v, want %v", creator.filenames, []string{"out_0001.csv"})
+	}
+	if writer.closed != 1 {
+		t.Errorf("writer closed %v times, want 1", writer.closed)
+	}
+}
+
+func Test_numberedFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		num      int
+		want     string
+	}{
+		{
+			name:     "1",
+			filename: "/data/out.csv.gz",
+			num:      1,
+			want:     "/data/out_0001.csv.gz",
+		},
+		{
+			name:     "2",
+			filename: "out.xlsx",
+			num:      12,
+			want:     "out_0012.xlsx",
+		},
+		{
+			name:     "3",
+			filename: "/data/out",
+			num:      2,
+			want:     "/data/out_0002",
+		},
+		{
+			name:     "4",
+			filename: "/data/.out.csv",
+			num:      3,
+			want:     "/data/.out_0003.csv",
+		},
+		{
+			name:     "5",
+			filename: "out.csv",
+			num:      10000,
+			want:     "out_10000.csv",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := numberedFilename(tt.filename, tt.num); got != tt.want {
+				t.Errorf("numberedFilename() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
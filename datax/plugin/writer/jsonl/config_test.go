@@ -0,0 +1,96 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/file"
+	"github.com/Breeze0806/go-etl/storage/stream/file/jsonl"
+)
+
+func TestNewConfig(t *testing.T) {
+	type args struct {
+		conf *config.JSON
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *Config
+		wantErr bool
+	}{
+		{
+			name: "1",
+			args: args{
+				conf: testJSONFromString(`{"timeFormat":1}`),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "2",
+			args: args{
+				conf: testJSONFromString(`{"path":[]}`),
+			},
+			want: &Config{
+				Path: []string{},
+			},
+		},
+		{
+			name: "3",
+			args: args{
+				conf: testJSONFromString(`{"path":["out.jsonl"],"column":[{"path":"a.b","type":"string"}],"compress":"gz","maxRowsPerFile":10}`),
+			},
+			want: &Config{
+				SingleConfig: SingleConfig{
+					OutConfig: jsonl.OutConfig{
+						Columns: []jsonl.Column{
+							{
+								Path: "a.b",
+								Type: "string",
+							},
+						},
+						Compress: "gz",
+					},
+					BaseConfig: file.BaseConfig{
+						MaxRowsPerFile: 10,
+					},
+				},
+				Path: []string{"out.jsonl"},
+			},
+		},
+		{
+			name: "4",
+			args: args{
+				conf: testJSONFromString(`{"path":["out.jsonl"],"column":[{"path":"a.b","type":"bytes"}]}`),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConfig(tt.args.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -28,6 +28,9 @@ const maxNumPlaceholder = 65535
 var execModeMap = map[string]string{
 	database.WriteModeInsert: dbms.ExecModeNormal,
 	mysql.WriteModeReplace:   dbms.ExecModeNormal,
+	database.WriteModeUpsert: dbms.ExecModeNormal,
+	database.WriteModeUpdate: dbms.ExecModeStmtTx,
+	database.WriteModeDelete: dbms.ExecModeStmtTx,
 }
 
 func execMode(writeMode string) string {
@@ -42,14 +45,22 @@ type Task struct {
 	*dbms.Task
 }
 
+// SupportFailOver 写入模式为replace时重复写入的结果也相同，支持故障转移
+func (t *Task) SupportFailOver() bool {
+	if t.Config != nil && t.Config.GetWriteMode() == mysql.WriteModeReplace {
+		return true
+	}
+	return t.Task.SupportFailOver()
+}
+
 type batchWriter struct {
 	*dbms.BaseBatchWriter
 }
 
 func (b *batchWriter) BatchSize() (size int) {
 	size = maxNumPlaceholder / len(b.Task.Table.Fields())
-	if b.Task.Config.GetBatchSize() < size {
-		size = b.Task.Config.GetBatchSize()
+	if b.BaseBatchWriter.BatchSize() < size {
+		size = b.BaseBatchWriter.BatchSize()
 	}
 	return
 }
@@ -17,11 +17,20 @@ package mysql
 import (
 	"testing"
 
+	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/datax/plugin/writer/dbms"
 	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/Breeze0806/go-etl/storage/database/mysql"
 )
 
+func testJSONFromString(s string) *config.JSON {
+	conf, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
 type mockTable struct {
 	*database.BaseTable
 
@@ -117,6 +126,20 @@ func Test_execMode(t *testing.T) {
 			},
 			want: dbms.ExecModeNormal,
 		},
+		{
+			name: "4",
+			args: args{
+				writeMode: database.WriteModeUpdate,
+			},
+			want: dbms.ExecModeStmtTx,
+		},
+		{
+			name: "5",
+			args: args{
+				writeMode: database.WriteModeDelete,
+			},
+			want: dbms.ExecModeStmtTx,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -126,3 +149,53 @@ func Test_execMode(t *testing.T) {
 		})
 	}
 }
+
+func TestTask_SupportFailOver(t *testing.T) {
+	tests := []struct {
+		name string
+		t    *Task
+		want bool
+	}{
+		{
+			name: "1",
+			t: &Task{
+				Task: dbms.NewTask(nil),
+			},
+			want: false,
+		},
+		{
+			name: "2",
+			t: &Task{
+				Task: &dbms.Task{
+					Config: &dbms.BaseConfig{WriteMode: mysql.WriteModeReplace},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "3",
+			t: &Task{
+				Task: &dbms.Task{
+					Config: &dbms.BaseConfig{WriteMode: database.WriteModeUpsert},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "4",
+			t: &Task{
+				Task: &dbms.Task{
+					Config: &dbms.BaseConfig{WriteMode: database.WriteModeInsert},
+				},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.SupportFailOver(); got != tt.want {
+				t.Errorf("Task.SupportFailOver() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,11 +21,15 @@ import (
 	"github.com/Breeze0806/go-etl/datax/plugin/writer/dbms"
 
 	//oracle dialect
+	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/Breeze0806/go-etl/storage/database/oracle"
 )
 
 var execModeMap = map[string]string{
-	oracle.WriteModeInsert: dbms.ExecModeNormal,
+	oracle.WriteModeInsert:   dbms.ExecModeNormal,
+	database.WriteModeUpsert: dbms.ExecModeNormal,
+	database.WriteModeUpdate: dbms.ExecModeStmtTx,
+	database.WriteModeDelete: dbms.ExecModeStmtTx,
 }
 
 func execMode(writeMode string) string {
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/Breeze0806/go-etl/datax/plugin/writer/dbms"
+	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/Breeze0806/go-etl/storage/database/oracle"
 )
 
@@ -44,6 +45,20 @@ func Test_execMode(t *testing.T) {
 			},
 			want: dbms.ExecModeNormal,
 		},
+		{
+			name: "3",
+			args: args{
+				writeMode: database.WriteModeUpdate,
+			},
+			want: dbms.ExecModeStmtTx,
+		},
+		{
+			name: "4",
+			args: args{
+				writeMode: database.WriteModeDelete,
+			},
+			want: dbms.ExecModeStmtTx,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
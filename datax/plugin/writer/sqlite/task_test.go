@@ -0,0 +1,134 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/plugin/writer/dbms"
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/Breeze0806/go-etl/storage/database/sqlite"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	conf, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func testTable(n int) *sqlite.Table {
+	table := sqlite.NewTable(database.NewBaseTable("", "", "name"))
+	table.SetConfig(testJSONFromString(`{"keyColumn":["f0"]}`))
+	for i := 0; i < n; i++ {
+		table.AppendField(sqlite.NewField(database.NewBaseField(i, "f"+string(rune('0'+i%10)), nil)))
+	}
+	return table
+}
+
+func Test_batchWriter_BatchSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		batchSize int
+		writeMode string
+		table     database.Table
+		wantSize  int
+	}{
+		{
+			name:      "1",
+			batchSize: 1000,
+			writeMode: database.WriteModeInsert,
+			table:     testTable(10),
+			wantSize:  1000,
+		},
+		{
+			name:      "2",
+			batchSize: 10000,
+			writeMode: database.WriteModeInsert,
+			table:     testTable(10),
+			wantSize:  maxNumPlaceholder / 10,
+		},
+		{
+			name:      "3",
+			batchSize: 10000,
+			writeMode: database.WriteModeUpdate,
+			table:     testTable(5),
+			wantSize:  maxNumPlaceholder / 5,
+		},
+		{
+			name:      "4",
+			batchSize: 1000,
+			writeMode: database.WriteModeInsert,
+			table:     testTable(0),
+			wantSize:  1000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &batchWriter{
+				BaseBatchWriter: &dbms.BaseBatchWriter{
+					Task: &dbms.Task{
+						Config: &dbms.BaseConfig{
+							BatchSize: tt.batchSize,
+							WriteMode: tt.writeMode,
+						},
+						Table: tt.table,
+					},
+				},
+			}
+			if gotSize := b.BatchSize(); gotSize != tt.wantSize {
+				t.Errorf("batchWriter.BatchSize() = %v, want %v", gotSize, tt.wantSize)
+			}
+		})
+	}
+}
+
+func Test_execMode(t *testing.T) {
+	tests := []struct {
+		name      string
+		writeMode string
+		want      string
+	}{
+		{
+			name:      "1",
+			writeMode: database.WriteModeInsert,
+			want:      dbms.ExecModeNormal,
+		},
+		{
+			name:      "2",
+			writeMode: database.WriteModeDelete,
+			want:      dbms.ExecModeStmtTx,
+		},
+		{
+			name:      "3",
+			writeMode: "",
+			want:      dbms.ExecModeNormal,
+		},
+		{
+			name:      "4",
+			writeMode: database.WriteModeUpdate,
+			want:      dbms.ExecModeStmtTx,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := execMode(tt.writeMode); got != tt.want {
+				t.Errorf("execMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -26,6 +26,9 @@ import (
 var execModeMap = map[string]string{
 	database.WriteModeInsert:  dbms.ExecModeNormal,
 	sqlserver.WriteModeCopyIn: dbms.ExecModeStmt,
+	database.WriteModeUpsert:  dbms.ExecModeNormal,
+	database.WriteModeUpdate:  dbms.ExecModeStmtTx,
+	database.WriteModeDelete:  dbms.ExecModeStmtTx,
 }
 
 func execMode(writeMode string) string {
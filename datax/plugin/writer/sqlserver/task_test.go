@@ -52,6 +52,20 @@ func Test_execMode(t *testing.T) {
 			},
 			want: dbms.ExecModeNormal,
 		},
+		{
+			name: "4",
+			args: args{
+				writeMode: database.WriteModeUpdate,
+			},
+			want: dbms.ExecModeStmtTx,
+		},
+		{
+			name: "5",
+			args: args{
+				writeMode: database.WriteModeDelete,
+			},
+			want: dbms.ExecModeStmtTx,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,208 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datax
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/datax/core/taskgroup"
+)
+
+// PrometheusContentType Prometheus文本格式的内容类型
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// taskMetrics 单个任务的指标以及标签
+type taskMetrics struct {
+	jobID       int64
+	taskGroupID int64
+	stats       taskgroup.Stats
+}
+
+// labels 生成任务的标签，包含工作、任务组、任务编号以及读取器和写入器名
+func (t *taskMetrics) labels() string {
+	return fmt.Sprintf(`job="%v",task_group="%v",task="%v",reader="%v",writer="%v"`,
+		t.jobID, t.taskGroupID, t.stats.TaskID,
+		escapeLabelValue(t.stats.Reader), escapeLabelValue(t.stats.Writer))
+}
+
+// metricsJSON 工作或者任务组的指标，任务组指标中存在taskGroupID，
+// 下级指标metrics以编号为下标，是数组或者对象
+type metricsJSON struct {
+	JobID       int64           `json:"jobID"`
+	TaskGroupID *int64          `json:"taskGroupID"`
+	Metrics     json.RawMessage `json:"metrics"`
+}
+
+// children 获取下级指标，跳过其中的空值
+func (m *metricsJSON) children() (children []json.RawMessage, err error) {
+	if len(m.Metrics) == 0 {
+		return nil, nil
+	}
+	var all []json.RawMessage
+	if err = json.Unmarshal(m.Metrics, &all); err != nil {
+		var kv map[string]json.RawMessage
+		if err = json.Unmarshal(m.Metrics, &kv); err != nil {
+			return nil, err
+		}
+		for _, v := range kv {
+			all = append(all, v)
+		}
+	}
+	for _, v := range all {
+		if string(v) != "null" {
+			children = append(children, v)
+		}
+	}
+	return
+}
+
+// parseTaskMetrics 从工作或者任务组的JSON指标data中解析出所有任务的指标，
+// 按任务组编号和任务编号排序
+func parseTaskMetrics(data []byte) (tasks []taskMetrics, err error) {
+	if err = appendTaskMetrics(&tasks, data); err != nil {
+		return nil, err
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].taskGroupID != tasks[j].taskGroupID {
+			return tasks[i].taskGroupID < tasks[j].taskGroupID
+		}
+		return tasks[i].stats.TaskID < tasks[j].stats.TaskID
+	})
+	return
+}
+
+func appendTaskMetrics(tasks *[]taskMetrics, data []byte) (err error) {
+	var m metricsJSON
+	if err = json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	var children []json.RawMessage
+	if children, err = m.children(); err != nil {
+		return err
+	}
+	for _, v := range children {
+		//工作指标中是各个任务组的指标
+		if m.TaskGroupID == nil {
+			if err = appendTaskMetrics(tasks, v); err != nil {
+				return err
+			}
+			continue
+		}
+		t := taskMetrics{
+			jobID:       m.JobID,
+			taskGroupID: *m.TaskGroupID,
+		}
+		if err = json.Unmarshal(v, &t.stats); err != nil {
+			return err
+		}
+		*tasks = append(*tasks, t)
+	}
+	return nil
+}
+
+// promCounter 由任务统计信息生成的单值指标
+type promCounter struct {
+	name  string
+	help  string
+	typ   string
+	value func(s *taskgroup.Stats) int64
+}
+
+var promCounters = []promCounter{
+	{"datax_task_read_records_total", "Records read by the reader.", "counter",
+		func(s *taskgroup.Stats) int64 { return s.Channel.TotalRecord }},
+	{"datax_task_read_bytes_total", "Bytes read by the reader.", "counter",
+		func(s *taskgroup.Stats) int64 { return s.Channel.TotalByte }},
+	{"datax_task_written_records_total", "Records handed to the writer.", "counter",
+		func(s *taskgroup.Stats) int64 { return s.Exchange.WrittenRecord }},
+	{"datax_task_written_bytes_total", "Bytes handed to the writer.", "counter",
+		func(s *taskgroup.Stats) int64 { return s.Exchange.WrittenByte }},
+	{"datax_task_filtered_records_total", "Records filtered by transformers.", "counter",
+		func(s *taskgroup.Stats) int64 { return s.Exchange.FilteredRecord }},
+	{"datax_task_failed_records_total", "Dirty records that failed to be transformed or written.", "counter",
+		func(s *taskgroup.Stats) int64 { return s.ErrorRecord }},
+	{"datax_task_retries_total", "Retries of writes.", "counter",
+		func(s *taskgroup.Stats) int64 { return s.RetryCount }},
+	{"datax_task_channel_records", "Records buffered in the channel.", "gauge",
+		func(s *taskgroup.Stats) int64 { return s.Channel.Record }},
+	{"datax_task_channel_bytes", "Bytes buffered in the channel.", "gauge",
+		func(s *taskgroup.Stats) int64 { return s.Channel.Byte }},
+}
+
+const promBatchWriteLatency = "datax_task_batch_write_latency_seconds"
+
+// writePrometheus 将工作或者任务组的JSON指标data以Prometheus文本格式写入w
+func writePrometheus(w io.Writer, data []byte) (err error) {
+	var tasks []taskMetrics
+	if tasks, err = parseTaskMetrics(data); err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	for _, c := range promCounters {
+		fmt.Fprintf(bw, "# HELP %v %v\n# TYPE %v %v\n", c.name, c.help, c.name, c.typ)
+		for i := range tasks {
+			fmt.Fprintf(bw, "%v{%v} %v\n", c.name, tasks[i].labels(), c.value(&tasks[i].stats))
+		}
+	}
+
+	fmt.Fprintf(bw, "# HELP %v %v\n# TYPE %v histogram\n", promBatchWriteLatency,
+		"Latency of batch writes in seconds.", promBatchWriteLatency)
+	for i := range tasks {
+		labels := tasks[i].labels()
+		h := &tasks[i].stats.BatchWriteLatency
+		for j, b := range h.Buckets {
+			var count int64
+			if j < len(h.Counts) {
+				count = h.Counts[j]
+			}
+			fmt.Fprintf(bw, "%v_bucket{%v,le=\"%v\"} %v\n", promBatchWriteLatency, labels, formatFloat(b), count)
+		}
+		fmt.Fprintf(bw, "%v_bucket{%v,le=\"+Inf\"} %v\n", promBatchWriteLatency, labels, h.Count)
+		fmt.Fprintf(bw, "%v_sum{%v} %v\n", promBatchWriteLatency, labels, formatFloat(h.Sum))
+		fmt.Fprintf(bw, "%v_count{%v} %v\n", promBatchWriteLatency, labels, h.Count)
+	}
+	return bw.Flush()
+}
+
+// WritePrometheus 将执行引擎的指标以Prometheus文本格式写入w，引擎未启动时不写入任何指标
+func (e *Engine) WritePrometheus(w io.Writer) error {
+	if e.Container == nil || e.Metrics() == nil || e.Metrics().JSON() == nil {
+		return nil
+	}
+	return writePrometheus(w, []byte(e.Metrics().JSON().String()))
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(s string) string {
+	return labelValueReplacer.Replace(s)
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datax
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const testPromTaskGroupMetrics = `{
+	"jobID":1,
+	"taskGroupID":0,
+	"metrics":[
+		null,
+		{
+			"taskID":1,
+			"reader":"mysqlreader",
+			"writer":"postgreswriter",
+			"channel":{"totalByte":300,"totalRecord":30,"byte":10,"record":1},
+			"exchange":{"filteredRecord":4,"writtenRecord":25,"writtenByte":250},
+			"errorRecord":2,
+			"batchWriteLatency":{"buckets":[0.1,1],"counts":[2,3],"count":4,"sum":2.5},
+			"retryCount":1
+		}
+	]
+}`
+
+func TestWritePrometheus(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []string
+		notWant []string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			data: testPromTaskGroupMetrics,
+			want: []string{
+				"# HELP datax_task_read_records_total Records read by the reader.\n" +
+					"# TYPE datax_task_read_records_total counter\n" +
+					`datax_task_read_records_total{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 30` + "\n",
+				`datax_task_read_bytes_total{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 300`,
+				`datax_task_written_records_total{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 25`,
+				`datax_task_written_bytes_total{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 250`,
+				`datax_task_filtered_records_total{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 4`,
+				`datax_task_failed_records_total{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 2`,
+				`datax_task_retries_total{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 1`,
+				"# TYPE datax_task_channel_records gauge\n" +
+					`datax_task_channel_records{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 1`,
+				`datax_task_channel_bytes{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 10`,
+				"# TYPE datax_task_batch_write_latency_seconds histogram\n" +
+					`datax_task_batch_write_latency_seconds_bucket{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter",le="0.1"} 2` + "\n" +
+					`datax_task_batch_write_latency_seconds_bucket{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter",le="1"} 3` + "\n" +
+					`datax_task_batch_write_latency_seconds_bucket{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter",le="+Inf"} 4` + "\n" +
+					`datax_task_batch_write_latency_seconds_sum{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 2.5` + "\n" +
+					`datax_task_batch_write_latency_seconds_count{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 4` + "\n",
+			},
+		},
+		{
+			name: "2",
+			data: `{"jobID":1,"metrics":{"0":` + testPromTaskGroupMetrics + `,
+				"1":{"jobID":1,"taskGroupID":1,"metrics":{"2":{"taskID":2,"reader":"a\"b","writer":"c\\d"}}}}}`,
+			want: []string{
+				`datax_task_read_records_total{job="1",task_group="0",task="1",reader="mysqlreader",writer="postgreswriter"} 30` + "\n" +
+					`datax_task_read_records_total{job="1",task_group="1",task="2",reader="a\"b",writer="c\\d"} 0` + "\n",
+				`datax_task_batch_write_latency_seconds_bucket{job="1",task_group="1",task="2",reader="a\"b",writer="c\\d",le="+Inf"} 0`,
+			},
+		},
+		{
+			name: "3",
+			data: `{"jobID":1}`,
+			want: []string{
+				"# TYPE datax_task_read_records_total counter\n# HELP datax_task_read_bytes_total",
+			},
+			notWant: []string{
+				"datax_task_read_records_total{",
+			},
+		},
+		{
+			name:    "4",
+			data:    `{"jobID":1,"metrics":{"0":1}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			if err := writePrometheus(buf, []byte(tt.data)); (err != nil) != tt.wantErr {
+				t.Fatalf("writePrometheus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := buf.String()
+			for _, v := range tt.want {
+				if !strings.Contains(got, v) {
+					t.Errorf("writePrometheus() = %v, want contains %v", got, v)
+				}
+			}
+			for _, v := range tt.notWant {
+				if strings.Contains(got, v) {
+					t.Errorf("writePrometheus() = %v, want not contains %v", got, v)
+				}
+			}
+		})
+	}
+}
+
+func TestEngine_WritePrometheus(t *testing.T) {
+	buf := &bytes.Buffer{}
+	if err := (&Engine{}).WritePrometheus(buf); err != nil {
+		t.Fatalf("Engine.WritePrometheus() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Engine.WritePrometheus() = %v, want empty", buf.String())
+	}
+}
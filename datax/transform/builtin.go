@@ -0,0 +1,174 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"encoding/json"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+)
+
+// 内置转化器名称
+const (
+	NameSubstr  = "dx_substr"  //截取字符串
+	NamePad     = "dx_pad"     //填充字符串
+	NameReplace = "dx_replace" //替换字符串
+	NameMask    = "dx_mask"    //遮盖字符串
+	NameFilter  = "dx_filter"  //过滤记录
+	NameDigest  = "dx_digest"  //摘要
+	NameExpr    = "dx_expr"    //表达式
+)
+
+func init() {
+	RegisterCreator(NameSubstr, CreatorFunc(NewSubstrTransformer))
+	RegisterCreator(NamePad, CreatorFunc(NewPadTransformer))
+	RegisterCreator(NameReplace, CreatorFunc(NewReplaceTransformer))
+	RegisterCreator(NameMask, CreatorFunc(NewMaskTransformer))
+	RegisterCreator(NameFilter, CreatorFunc(NewFilterTransformer))
+	RegisterCreator(NameDigest, CreatorFunc(NewDigestTransformer))
+	RegisterCreator(NameExpr, CreatorFunc(NewExprTransformer))
+}
+
+// Parameter 内置转化器参数，通过列编号columnIndex或者列名columnName选择列
+// 当两者都配置时以columnIndex为准
+type Parameter struct {
+	ColumnIndex *int     `json:"columnIndex"` //列编号,从0开始
+	ColumnName  string   `json:"columnName"`  //列名
+	Paras       []string `json:"paras"`       //转化参数
+}
+
+// NewParameter 从转化器配置conf中获取内置转化器参数，parameter.paras的个数需要在[min,max]内
+// 当列编号和列名都没有配置或者参数个数不对时会报错
+func NewParameter(conf *config.JSON, min, max int) (p *Parameter, err error) {
+	var paramConf *config.JSON
+	if paramConf, err = conf.GetConfig("parameter"); err != nil {
+		return nil, err
+	}
+	p = &Parameter{}
+	if err = json.Unmarshal([]byte(paramConf.String()), p); err != nil {
+		return nil, err
+	}
+	if p.ColumnIndex == nil && p.ColumnName == "" {
+		return nil, errors.New("columnIndex or columnName should be set")
+	}
+	if p.ColumnIndex != nil && *p.ColumnIndex < 0 {
+		return nil, errors.Errorf("columnIndex(%v) should not be negative", *p.ColumnIndex)
+	}
+	if len(p.Paras) < min || len(p.Paras) > max {
+		return nil, errors.Errorf("the number of paras(%v) should be in [%v,%v]", len(p.Paras), min, max)
+	}
+	return
+}
+
+// Column 从记录record中获取列以及其编号
+func (p *Parameter) Column(record element.Record) (int, element.Column, error) {
+	if p.ColumnIndex != nil {
+		c, err := record.GetByIndex(*p.ColumnIndex)
+		if err != nil {
+			return 0, nil, errors.Wrapf(err, "get column %v fail", *p.ColumnIndex)
+		}
+		return *p.ColumnIndex, c, nil
+	}
+	i, c, err := columnByName(record, p.ColumnName)
+	if err != nil {
+		return 0, nil, err
+	}
+	if c == nil {
+		return 0, nil, errors.Wrapf(element.ErrColumnNotExist, "get column %v fail", p.ColumnName)
+	}
+	return i, c, nil
+}
+
+// columnByName 从记录record中获取列名为name的列以及其编号，列不存在时返回的列为空
+func columnByName(record element.Record, name string) (int, element.Column, error) {
+	for i := 0; i < record.ColumnNumber(); i++ {
+		c, err := record.GetByIndex(i)
+		if err != nil {
+			return 0, nil, errors.Wrapf(err, "get column %v fail", i)
+		}
+		if c.Name() == name {
+			return i, c, nil
+		}
+	}
+	return 0, nil, nil
+}
+
+// Int 获取第i个参数的非负整数值
+func (p *Parameter) Int(i int) (int, error) {
+	v, err := strconv.Atoi(p.Paras[i])
+	if err != nil {
+		return 0, errors.Wrapf(err, "paras %v(%v) is not integer", i, p.Paras[i])
+	}
+	if v < 0 {
+		return 0, errors.Errorf("paras %v(%v) should not be negative", i, p.Paras[i])
+	}
+	return v, nil
+}
+
+// stringColumnTransformer 字符串列转化器，将列转化为字符串后进行转化,空值不转化
+type stringColumnTransformer struct {
+	param     *Parameter
+	transform func(s string) string
+}
+
+// DoTransform 转化记录record
+func (s *stringColumnTransformer) DoTransform(record element.Record) (element.Record, error) {
+	i, c, err := s.param.Column(record)
+	if err != nil {
+		return nil, err
+	}
+	if c.IsNil() {
+		return record, nil
+	}
+	var v string
+	if v, err = c.AsString(); err != nil {
+		return nil, err
+	}
+	v = s.transform(v)
+	if err = record.Set(i, element.NewDefaultColumn(element.NewStringColumnValue(v), c.Name(), len(v))); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// runeRange 获取字符串s从字符start开始长度为length的字符范围对应的字节范围
+// 超出字符串长度的部分会被忽略
+func runeRange(s string, start, length int) (begin, end int) {
+	begin, end = len(s), len(s)
+	n := 0
+	for i := range s {
+		if n == start {
+			begin = i
+		}
+		if n == start+length {
+			end = i
+			return
+		}
+		n++
+	}
+	if start >= n {
+		begin = len(s)
+	}
+	return
+}
+
+// runeLen 字符串s的字符个数
+func runeLen(s string) int {
+	return utf8.RuneCountInString(s)
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testRecord(values ...element.ColumnValue) element.Record {
+	r := element.NewDefaultRecord()
+	for i, v := range values {
+		r.Add(element.NewDefaultColumn(v, "c"+string(rune('0'+i)), 0))
+	}
+	return r
+}
+
+func testTransform(t *testing.T, conf string, record element.Record) (element.Record, error) {
+	t.Helper()
+	var tran Transformer
+	var err error
+	c := testJSONFromString(conf)
+	name, _ := c.GetString("name")
+	switch name {
+	case NameSubstr:
+		tran, err = NewSubstrTransformer(c)
+	case NamePad:
+		tran, err = NewPadTransformer(c)
+	case NameReplace:
+		tran, err = NewReplaceTransformer(c)
+	case NameMask:
+		tran, err = NewMaskTransformer(c)
+	case NameFilter:
+		tran, err = NewFilterTransformer(c)
+	case NameDigest:
+		tran, err = NewDigestTransformer(c)
+	case NameExpr:
+		tran, err = NewExprTransformer(c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tran.DoTransform(record)
+}
+
+func testColumnString(t *testing.T, record element.Record, i int) string {
+	t.Helper()
+	c, err := record.GetByIndex(i)
+	if err != nil {
+		t.Fatalf("GetByIndex(%v) error = %v", i, err)
+	}
+	return c.String()
+}
+
+func TestNewParameter(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		min     int
+		max     int
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{"parameter":{"columnIndex":0,"paras":["1"]}}`),
+			min:  1,
+			max:  1,
+		},
+		{
+			name: "2",
+			conf: testJSONFromString(`{"parameter":{"columnName":"c0","paras":["1","2"]}}`),
+			min:  1,
+			max:  2,
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"parameter":{"paras":["1"]}}`),
+			min:     1,
+			max:     1,
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    testJSONFromString(`{"parameter":{"columnIndex":-1,"paras":["1"]}}`),
+			min:     1,
+			max:     1,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			conf:    testJSONFromString(`{"parameter":{"columnIndex":0,"paras":["1"]}}`),
+			min:     2,
+			max:     2,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			conf:    testJSONFromString(`{"parameter":{"columnIndex":0,"paras":[1]}}`),
+			min:     1,
+			max:     1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParameter(tt.conf, tt.min, tt.max)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewParameter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParameter_Column(t *testing.T) {
+	index := func(i int) *int {
+		return &i
+	}
+	record := testRecord(element.NewStringColumnValue("a"), element.NewStringColumnValue("b"))
+	tests := []struct {
+		name    string
+		p       *Parameter
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "1",
+			p:    &Parameter{ColumnIndex: index(1)},
+			want: 1,
+		},
+		{
+			name: "2",
+			p:    &Parameter{ColumnName: "c1"},
+			want: 1,
+		},
+		{
+			name:    "3",
+			p:       &Parameter{ColumnIndex: index(2)},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			p:       &Parameter{ColumnName: "c2"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, c, err := tt.p.Column(record)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parameter.Column() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want || c.String() != "b" {
+				t.Errorf("Parameter.Column() = %v %v, want %v", got, c, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runeRange(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		start  int
+		length int
+		want   string
+	}{
+		{name: "1", s: "abcdef", start: 1, length: 3, want: "bcd"},
+		{name: "2", s: "abcdef", start: 1, length: 10, want: "bcdef"},
+		{name: "3", s: "abcdef", start: 6, length: 1, want: ""},
+		{name: "4", s: "abcdef", start: 10, length: 1, want: ""},
+		{name: "5", s: "abcdef", start: 2, length: 0, want: ""},
+		{name: "6", s: "中文字符串", start: 1, length: 2, want: "文字"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			begin, end := runeRange(tt.s, tt.start, tt.length)
+			if got := tt.s[begin:end]; got != tt.want {
+				t.Errorf("runeRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
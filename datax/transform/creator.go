@@ -0,0 +1,120 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/pingcap/errors"
+)
+
+// Creator 转化器创建器
+type Creator interface {
+	Create(conf *config.JSON) (Transformer, error) //通过转化器配置conf创建转化器
+}
+
+// CreatorFunc 转化器创建函数，用于将普通函数转化为转化器创建器
+type CreatorFunc func(conf *config.JSON) (Transformer, error)
+
+// Create 通过转化器配置conf创建转化器
+func (f CreatorFunc) Create(conf *config.JSON) (Transformer, error) {
+	return f(conf)
+}
+
+// RegisterCreator 通过转化器名称name注册转化器创建器creator
+// 当name重复或者creator为空会panic
+func RegisterCreator(name string, creator Creator) {
+	if err := creators.register(name, creator); err != nil {
+		panic(err)
+	}
+}
+
+// UnregisterAllCreator 注销所有转化器创建器
+func UnregisterAllCreator() {
+	creators.unregisterAll()
+}
+
+// NewTransformer 通过转化器配置数组confs创建转化器，每个转化器配置形如
+//
+//	{
+//	    "name":"dx_substr",
+//	    "parameter":{
+//	        "columnIndex":0,
+//	        "paras":["1","3"]
+//	    }
+//	}
+//
+// 当confs为空时返回空转化器，当转化器名称不存在或者创建失败时会报错
+func NewTransformer(confs []*config.JSON) (Transformer, error) {
+	if len(confs) == 0 {
+		return &NilTransformer{}, nil
+	}
+	chain := NewChainTransformer()
+	for i, conf := range confs {
+		name, err := conf.GetString("name")
+		if err != nil {
+			return nil, errors.Wrapf(err, "get %v transformer name fail", i)
+		}
+		creator, ok := creators.creator(name)
+		if !ok {
+			return nil, errors.Errorf("transformer %v does not exist", name)
+		}
+		t, err := creator.Create(conf)
+		if err != nil {
+			return nil, errors.Wrapf(err, "create transformer %v(%v) fail", i, name)
+		}
+		chain.Append(name, t)
+	}
+	return chain, nil
+}
+
+var creators = &creatorMap{
+	creators: make(map[string]Creator),
+}
+
+type creatorMap struct {
+	sync.RWMutex
+	creators map[string]Creator
+}
+
+func (o *creatorMap) register(name string, creator Creator) error {
+	if creator == nil {
+		return fmt.Errorf("transformer creator %v is nil", name)
+	}
+
+	o.Lock()
+	defer o.Unlock()
+	if _, ok := o.creators[name]; ok {
+		return fmt.Errorf("transformer creator %v exists", name)
+	}
+
+	o.creators[name] = creator
+	return nil
+}
+
+func (o *creatorMap) creator(name string) (creator Creator, ok bool) {
+	o.RLock()
+	defer o.RUnlock()
+	creator, ok = o.creators[name]
+	return
+}
+
+func (o *creatorMap) unregisterAll() {
+	o.Lock()
+	defer o.Unlock()
+	o.creators = make(map[string]Creator)
+}
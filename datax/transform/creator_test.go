@@ -0,0 +1,160 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	j, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return j
+}
+
+type mockTransformer struct {
+	err error
+}
+
+func (m *mockTransformer) DoTransform(record element.Record) (element.Record, error) {
+	return record, m.err
+}
+
+func TestRegisterCreator(t *testing.T) {
+	UnregisterAllCreator()
+	defer UnregisterAllCreator()
+	tests := []struct {
+		name      string
+		tName     string
+		creator   Creator
+		wantPanic bool
+	}{
+		{
+			name:  "1",
+			tName: "mock",
+			creator: CreatorFunc(func(conf *config.JSON) (Transformer, error) {
+				return &mockTransformer{}, nil
+			}),
+		},
+		{
+			name:  "2",
+			tName: "mock",
+			creator: CreatorFunc(func(conf *config.JSON) (Transformer, error) {
+				return &mockTransformer{}, nil
+			}),
+			wantPanic: true,
+		},
+		{
+			name:      "3",
+			tName:     "nil",
+			creator:   nil,
+			wantPanic: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); (r != nil) != tt.wantPanic {
+					t.Errorf("RegisterCreator() panic = %v, wantPanic %v", r, tt.wantPanic)
+				}
+			}()
+			RegisterCreator(tt.tName, tt.creator)
+		})
+	}
+}
+
+func TestNewTransformer(t *testing.T) {
+	UnregisterAllCreator()
+	defer UnregisterAllCreator()
+	RegisterCreator("mock", CreatorFunc(func(conf *config.JSON) (Transformer, error) {
+		return &mockTransformer{}, nil
+	}))
+	RegisterCreator("mockErr", CreatorFunc(func(conf *config.JSON) (Transformer, error) {
+		return nil, errors.New("mock error")
+	}))
+
+	tests := []struct {
+		name    string
+		confs   []*config.JSON
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			confs:   nil,
+			wantLen: 0,
+		},
+		{
+			name: "2",
+			confs: []*config.JSON{
+				testJSONFromString(`{"name":"mock"}`),
+				testJSONFromString(`{"name":"mock","parameter":{}}`),
+			},
+			wantLen: 2,
+		},
+		{
+			name: "3",
+			confs: []*config.JSON{
+				testJSONFromString(`{"parameter":{}}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "4",
+			confs: []*config.JSON{
+				testJSONFromString(`{"name":"mock1"}`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "5",
+			confs: []*config.JSON{
+				testJSONFromString(`{"name":"mock"}`),
+				testJSONFromString(`{"name":"mockErr"}`),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTransformer(tt.confs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTransformer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			switch g := got.(type) {
+			case *NilTransformer:
+				if tt.wantLen != 0 {
+					t.Errorf("NewTransformer() = %T, wantLen %v", got, tt.wantLen)
+				}
+			case *ChainTransformer:
+				if g.Len() != tt.wantLen {
+					t.Errorf("NewTransformer() len = %v, wantLen %v", g.Len(), tt.wantLen)
+				}
+			default:
+				t.Errorf("NewTransformer() = %T", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+)
+
+// NewDigestTransformer 通过配置conf创建摘要转化器，配置形如
+//
+//	{
+//	    "name":"dx_digest",
+//	    "parameter":{
+//	        "columnIndex":1,
+//	        "paras":["md5","toLowerCase"]
+//	    }
+//	}
+//
+// paras依次为摘要算法(md5,sha1,sha256)以及大小写(toUpperCase,toLowerCase，默认为toLowerCase)，
+// 列值会被替换为摘要的十六进制字符串，空值不转化
+func NewDigestTransformer(conf *config.JSON) (Transformer, error) {
+	param, err := NewParameter(conf, 1, 2)
+	if err != nil {
+		return nil, err
+	}
+	var newHash func() hash.Hash
+	switch param.Paras[0] {
+	case "md5":
+		newHash = md5.New
+	case "sha1":
+		newHash = sha1.New
+	case "sha256":
+		newHash = sha256.New
+	default:
+		return nil, errors.Errorf("paras 0(%v) should be md5, sha1 or sha256", param.Paras[0])
+	}
+	upper := false
+	if len(param.Paras) == 2 {
+		switch param.Paras[1] {
+		case "toUpperCase":
+			upper = true
+		case "toLowerCase":
+		default:
+			return nil, errors.Errorf("paras 1(%v) should be toUpperCase or toLowerCase", param.Paras[1])
+		}
+	}
+	return &digestTransformer{
+		param:   param,
+		newHash: newHash,
+		upper:   upper,
+	}, nil
+}
+
+type digestTransformer struct {
+	param   *Parameter
+	newHash func() hash.Hash
+	upper   bool
+}
+
+// DoTransform 转化记录record
+func (d *digestTransformer) DoTransform(record element.Record) (element.Record, error) {
+	i, c, err := d.param.Column(record)
+	if err != nil {
+		return nil, err
+	}
+	if c.IsNil() {
+		return record, nil
+	}
+	var b []byte
+	if c.Type() == element.TypeBytes {
+		b, err = c.AsBytes()
+	} else {
+		var s string
+		s, err = c.AsString()
+		b = []byte(s)
+	}
+	if err != nil {
+		return nil, err
+	}
+	h := d.newHash()
+	h.Write(b)
+	v := hex.EncodeToString(h.Sum(nil))
+	if d.upper {
+		v = strings.ToUpper(v)
+	}
+	if err = record.Set(i, element.NewDefaultColumn(element.NewStringColumnValue(v), c.Name(), len(v))); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
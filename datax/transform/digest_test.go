@@ -0,0 +1,83 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestNewDigestTransformer(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		value   element.ColumnValue
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			conf:  `{"name":"dx_digest","parameter":{"columnIndex":0,"paras":["md5"]}}`,
+			value: element.NewStringColumnValue("abc"),
+			want:  "900150983cd24fb0d6963f7d28e17f72",
+		},
+		{
+			name:  "2",
+			conf:  `{"name":"dx_digest","parameter":{"columnIndex":0,"paras":["sha1","toUpperCase"]}}`,
+			value: element.NewBytesColumnValue([]byte("abc")),
+			want:  "A9993E364706816ABA3E25717850C26C9CD0D89D",
+		},
+		{
+			name:  "3",
+			conf:  `{"name":"dx_digest","parameter":{"columnIndex":0,"paras":["sha256","toLowerCase"]}}`,
+			value: element.NewStringColumnValue("abc"),
+			want:  "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad",
+		},
+		{
+			name:  "4",
+			conf:  `{"name":"dx_digest","parameter":{"columnIndex":0,"paras":["md5"]}}`,
+			value: element.NewNilStringColumnValue(),
+			want:  "<nil>",
+		},
+		{
+			name:    "5",
+			conf:    `{"name":"dx_digest","parameter":{"columnIndex":0,"paras":["crc32"]}}`,
+			value:   element.NewStringColumnValue("abc"),
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			conf:    `{"name":"dx_digest","parameter":{"columnIndex":0,"paras":["md5","upper"]}}`,
+			value:   element.NewStringColumnValue("abc"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testTransform(t, tt.conf, testRecord(tt.value))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoTransform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if s := testColumnString(t, got, 0); s != tt.want {
+				t.Errorf("DoTransform() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expr 实现了用于转化器的简单表达式语言，表达式基于记录中的列计算出新的列值
+//
+// 表达式支持以下语法：
+//
+//	字面量:     123, 1.5, 'abc', "abc", true, false, null
+//	列:         列名如first_name，特殊列名使用反引号如`first name`，列编号如$0
+//	算术运算:   + - * / %，其中+在任意一侧为字符串时为字符串连接
+//	比较运算:   == != < <= > >=
+//	逻辑运算:   && || !，空值视为false
+//	函数:       if, coalesce, concat, upper, lower, trim, length, substr, replace,
+//	            format_time, parse_time, now, string, int, decimal, round, abs
+//
+// 除了concat和coalesce外，运算和函数的参数中存在空值时结果为空值
+package expr
@@ -0,0 +1,356 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+	"github.com/shopspring/decimal"
+)
+
+// ErrDivisionByZero 除零错误
+var ErrDivisionByZero = errors.New("division by zero")
+
+type node interface {
+	eval(record element.Record) (element.ColumnValue, error)
+}
+
+type literal struct {
+	value element.ColumnValue
+}
+
+func (l *literal) eval(record element.Record) (element.ColumnValue, error) {
+	return l.value, nil
+}
+
+type nameColumn struct {
+	name string
+}
+
+func (n *nameColumn) eval(record element.Record) (element.ColumnValue, error) {
+	c, err := record.GetByName(n.name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "column %v", n.name)
+	}
+	return c, nil
+}
+
+type indexColumn struct {
+	index int
+}
+
+func (i *indexColumn) eval(record element.Record) (element.ColumnValue, error) {
+	c, err := record.GetByIndex(i.index)
+	if err != nil {
+		return nil, errors.Wrapf(err, "column $%v", i.index)
+	}
+	return c, nil
+}
+
+type unary struct {
+	op string
+	x  node
+}
+
+func (u *unary) eval(record element.Record) (element.ColumnValue, error) {
+	x, err := u.x.eval(record)
+	if err != nil {
+		return nil, err
+	}
+	if u.op == "!" {
+		if x.IsNil() {
+			return element.NewBoolColumnValue(true), nil
+		}
+		b, err := x.AsBool()
+		if err != nil {
+			return nil, err
+		}
+		return element.NewBoolColumnValue(!b), nil
+	}
+
+	switch x.Type() {
+	case element.TypeBigInt:
+		if x.IsNil() {
+			return x, nil
+		}
+		i, err := x.AsBigInt()
+		if err != nil {
+			return nil, err
+		}
+		return element.NewBigIntColumnValue(new(big.Int).Neg(i.AsBigInt())), nil
+	default:
+		if x.IsNil() {
+			return element.NewNilDecimalColumnValue(), nil
+		}
+		d, err := x.AsDecimal()
+		if err != nil {
+			return nil, err
+		}
+		return element.NewDecimalColumnValue(d.AsDecimal().Neg()), nil
+	}
+}
+
+type binary struct {
+	op   string
+	x, y node
+}
+
+func newBinary(op string, x, y node) node {
+	return &binary{op: op, x: x, y: y}
+}
+
+func (b *binary) eval(record element.Record) (element.ColumnValue, error) {
+	x, err := b.x.eval(record)
+	if err != nil {
+		return nil, err
+	}
+
+	switch b.op {
+	case "&&", "||":
+		var ok bool
+		if ok, err = asBool(x); err != nil {
+			return nil, err
+		}
+		if ok == (b.op == "||") {
+			return element.NewBoolColumnValue(ok), nil
+		}
+		var y element.ColumnValue
+		if y, err = b.y.eval(record); err != nil {
+			return nil, err
+		}
+		if ok, err = asBool(y); err != nil {
+			return nil, err
+		}
+		return element.NewBoolColumnValue(ok), nil
+	}
+
+	var y element.ColumnValue
+	if y, err = b.y.eval(record); err != nil {
+		return nil, err
+	}
+
+	switch b.op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		if x.IsNil() || y.IsNil() {
+			return element.NewNilBoolColumnValue(), nil
+		}
+		var cmp int
+		if cmp, err = compare(x, y); err != nil {
+			return nil, err
+		}
+		return element.NewBoolColumnValue(compareResult(b.op, cmp)), nil
+	}
+	return arithmetic(b.op, x, y)
+}
+
+func compareResult(op string, cmp int) bool {
+	switch op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	}
+	return cmp >= 0
+}
+
+// asBool 转化为布尔值，空值视为false
+func asBool(v element.ColumnValue) (bool, error) {
+	if v.IsNil() {
+		return false, nil
+	}
+	return v.AsBool()
+}
+
+func isNumber(v element.ColumnValue) bool {
+	return v.Type() == element.TypeBigInt || v.Type() == element.TypeDecimal
+}
+
+// compare 比较x和y，存在数值时按数值比较，存在时间时按时间比较，存在布尔值时按布尔值比较，
+// 其余情况按字符串比较
+func compare(x, y element.ColumnValue) (int, error) {
+	switch {
+	case isNumber(x) || isNumber(y):
+		xd, err := x.AsDecimal()
+		if err != nil {
+			return 0, err
+		}
+		yd, err := y.AsDecimal()
+		if err != nil {
+			return 0, err
+		}
+		return xd.AsDecimal().Cmp(yd.AsDecimal()), nil
+	case x.Type() == element.TypeTime || y.Type() == element.TypeTime:
+		xt, err := x.AsTime()
+		if err != nil {
+			return 0, err
+		}
+		yt, err := y.AsTime()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case xt.Before(yt):
+			return -1, nil
+		case xt.After(yt):
+			return 1, nil
+		}
+		return 0, nil
+	case x.Type() == element.TypeBool || y.Type() == element.TypeBool:
+		xb, err := x.AsBool()
+		if err != nil {
+			return 0, err
+		}
+		yb, err := y.AsBool()
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case xb == yb:
+			return 0, nil
+		case xb:
+			return 1, nil
+		}
+		return -1, nil
+	}
+	xs, err := x.AsString()
+	if err != nil {
+		return 0, err
+	}
+	ys, err := y.AsString()
+	if err != nil {
+		return 0, err
+	}
+	return strings.Compare(xs, ys), nil
+}
+
+// arithmetic 算术运算，+在任意一侧为字符串时为字符串连接，两侧都是整数时除法以外结果为整数，
+// 其余情况结果为高精度实数
+func arithmetic(op string, x, y element.ColumnValue) (element.ColumnValue, error) {
+	if op == "+" && (x.Type() == element.TypeString || y.Type() == element.TypeString) {
+		if x.IsNil() || y.IsNil() {
+			return element.NewNilStringColumnValue(), nil
+		}
+		xs, err := x.AsString()
+		if err != nil {
+			return nil, err
+		}
+		ys, err := y.AsString()
+		if err != nil {
+			return nil, err
+		}
+		return element.NewStringColumnValue(xs + ys), nil
+	}
+
+	if x.Type() == element.TypeBigInt && y.Type() == element.TypeBigInt && op != "/" {
+		if x.IsNil() || y.IsNil() {
+			return element.NewNilBigIntColumnValue(), nil
+		}
+		xi, err := x.AsBigInt()
+		if err != nil {
+			return nil, err
+		}
+		yi, err := y.AsBigInt()
+		if err != nil {
+			return nil, err
+		}
+		a, b := xi.AsBigInt(), yi.AsBigInt()
+		switch op {
+		case "+":
+			return element.NewBigIntColumnValue(new(big.Int).Add(a, b)), nil
+		case "-":
+			return element.NewBigIntColumnValue(new(big.Int).Sub(a, b)), nil
+		case "*":
+			return element.NewBigIntColumnValue(new(big.Int).Mul(a, b)), nil
+		}
+		if b.Sign() == 0 {
+			return nil, ErrDivisionByZero
+		}
+		return element.NewBigIntColumnValue(new(big.Int).Rem(a, b)), nil
+	}
+
+	if x.IsNil() || y.IsNil() {
+		return element.NewNilDecimalColumnValue(), nil
+	}
+	xd, err := x.AsDecimal()
+	if err != nil {
+		return nil, err
+	}
+	yd, err := y.AsDecimal()
+	if err != nil {
+		return nil, err
+	}
+	a, b := xd.AsDecimal(), yd.AsDecimal()
+	var d decimal.Decimal
+	switch op {
+	case "+":
+		d = a.Add(b)
+	case "-":
+		d = a.Sub(b)
+	case "*":
+		d = a.Mul(b)
+	default:
+		if b.IsZero() {
+			return nil, ErrDivisionByZero
+		}
+		if op == "/" {
+			d = a.Div(b)
+		} else {
+			d = a.Mod(b)
+		}
+	}
+	return element.NewDecimalColumnValue(d), nil
+}
+
+type call struct {
+	name string
+	fn   *function
+	args []node
+}
+
+func (c *call) eval(record element.Record) (element.ColumnValue, error) {
+	if c.fn.lazy != nil {
+		v, err := c.fn.lazy(record, c.args)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%v", c.name)
+		}
+		return v, nil
+	}
+
+	args := make([]element.ColumnValue, len(c.args))
+	for i, a := range c.args {
+		v, err := a.eval(record)
+		if err != nil {
+			return nil, err
+		}
+		if v.IsNil() && !c.fn.acceptNil {
+			return c.fn.nilResult(), nil
+		}
+		args[i] = v
+	}
+	v, err := c.fn.call(args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v", c.name)
+	}
+	return v, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testRecord() element.Record {
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewStringColumnValue("John"), "first_name", 0))
+	r.Add(element.NewDefaultColumn(element.NewStringColumnValue("Smith"), "last_name", 0))
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(7), "qty", 0))
+	amount, _ := element.NewDecimalColumnValueFromString("12.34")
+	r.Add(element.NewDefaultColumn(amount, "amount", 0))
+	r.Add(element.NewDefaultColumn(element.NewNilStringColumnValue(), "nick", 0))
+	r.Add(element.NewDefaultColumn(element.NewTimeColumnValue(
+		time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)), "created at", 0))
+	r.Add(element.NewDefaultColumn(element.NewBoolColumnValue(true), "active", 0))
+	return r
+}
+
+func TestExpr_Eval(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		want     string
+		wantType element.ColumnType
+		wantErr  bool
+	}{
+		{name: "1", expr: "first_name + ' ' + last_name", want: "John Smith", wantType: element.TypeString},
+		{name: "2", expr: "concat(first_name, nick, \" \", last_name)", want: "John Smith", wantType: element.TypeString},
+		{name: "3", expr: "int(amount * 100)", want: "1234", wantType: element.TypeBigInt},
+		{name: "4", expr: "qty * 2 + 1", want: "15", wantType: element.TypeBigInt},
+		{name: "5", expr: "qty / 2", want: "3.5", wantType: element.TypeDecimal},
+		{name: "6", expr: "qty % 4", want: "3", wantType: element.TypeBigInt},
+		{name: "7", expr: "(qty - 10) * -1", want: "3", wantType: element.TypeBigInt},
+		{name: "8", expr: "amount - 0.34", want: "12", wantType: element.TypeDecimal},
+		{name: "9", expr: "coalesce(nick, first_name)", want: "John", wantType: element.TypeString},
+		{name: "10", expr: "if(qty > 5 && active, 'big', 'small')", want: "big", wantType: element.TypeString},
+		{name: "11", expr: "if(nick == 'x' || !active, 'a', 'b')", want: "b", wantType: element.TypeString},
+		{name: "12", expr: "format_time(`created at`, 'yyyy-MM-dd')", want: "2023-01-02", wantType: element.TypeString},
+		{name: "13", expr: "format_time(parse_time('20230405', 'yyyyMMdd'), 'yyyy/MM/dd')", want: "2023/04/05", wantType: element.TypeString},
+		{name: "14", expr: "upper($0) + lower(last_name) + trim('  x ')", want: "JOHNsmithx", wantType: element.TypeString},
+		{name: "15", expr: "length(first_name) + 1", want: "5", wantType: element.TypeBigInt},
+		{name: "16", expr: "substr(last_name, 1, 3) + substr(last_name, 3)", want: "mitth", wantType: element.TypeString},
+		{name: "17", expr: "replace(first_name, 'o', '0')", want: "J0hn", wantType: element.TypeString},
+		{name: "18", expr: "nick + 'x'", want: "<nil>", wantType: element.TypeString},
+		{name: "19", expr: "upper(nick)", want: "<nil>", wantType: element.TypeString},
+		{name: "20", expr: "qty + null", want: "<nil>", wantType: element.TypeString},
+		{name: "21", expr: "round(amount, 1) + abs(-2)", want: "14.3", wantType: element.TypeDecimal},
+		{name: "22", expr: "string(qty) + decimal('1.50')", want: "71.5", wantType: element.TypeString},
+		{name: "23", expr: "qty >= 7 == true", want: "true", wantType: element.TypeBool},
+		{name: "24", expr: "amount < '13'", want: "true", wantType: element.TypeBool},
+		{name: "25", expr: "`created at` > parse_time('2023-01-01', 'yyyy-MM-dd')", want: "true", wantType: element.TypeBool},
+		{name: "26", expr: "nick != 'a'", want: "<nil>", wantType: element.TypeBool},
+		{name: "27", expr: "1.5e2 + .5", want: "150.5", wantType: element.TypeDecimal},
+		{name: "28", expr: "'it\\'s' + \"\\t\"", want: "it's\t", wantType: element.TypeString},
+		{name: "29", expr: "-amount", want: "-12.34", wantType: element.TypeDecimal},
+		{name: "30", expr: "abs(-qty)", want: "7", wantType: element.TypeBigInt},
+		{name: "31", expr: "qty / 0", wantErr: true},
+		{name: "32", expr: "qty % 0", wantErr: true},
+		{name: "33", expr: "unknown + 1", wantErr: true},
+		{name: "34", expr: "$10", wantErr: true},
+		{name: "35", expr: "first_name * 2", wantErr: true},
+		{name: "36", expr: "substr(first_name, -1)", wantErr: true},
+		{name: "37", expr: "parse_time('x', 'yyyy')", wantErr: true},
+		{name: "38", expr: "if(first_name, 1, 2)", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			got, err := e.Eval(testRecord())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Eval() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.String() != tt.want {
+				t.Errorf("Eval() = %v, want %v", got, tt.want)
+			}
+			if got.Type() != tt.wantType {
+				t.Errorf("Eval() type = %v, want %v", got.Type(), tt.wantType)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "1", expr: "a + b * (c - 1)"},
+		{name: "2", expr: "now()"},
+		{name: "3", expr: "a +", wantErr: true},
+		{name: "4", expr: "(a + b", wantErr: true},
+		{name: "5", expr: "a b", wantErr: true},
+		{name: "6", expr: "foo(a)", wantErr: true},
+		{name: "7", expr: "if(a, b)", wantErr: true},
+		{name: "8", expr: "'abc", wantErr: true},
+		{name: "9", expr: "`abc", wantErr: true},
+		{name: "10", expr: "$", wantErr: true},
+		{name: "11", expr: "a # b", wantErr: true},
+		{name: "12", expr: "1e", wantErr: true},
+		{name: "13", expr: "concat(a b)", wantErr: true},
+		{name: "14", expr: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := Parse(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && e.String() != tt.expr {
+				t.Errorf("String() = %v, want %v", e.String(), tt.expr)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"math/big"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/jodaTime"
+	"github.com/pingcap/errors"
+)
+
+type function struct {
+	minArgs   int
+	maxArgs   int                                                                   //小于0代表不限个数
+	acceptNil bool                                                                  //参数为空值时仍执行函数
+	nilResult func() element.ColumnValue                                            //参数为空值时的结果
+	call      func(args []element.ColumnValue) (element.ColumnValue, error)         //执行函数
+	lazy      func(record element.Record, args []node) (element.ColumnValue, error) //按需计算参数的函数
+}
+
+var functions map[string]*function
+
+func init() {
+	functions = map[string]*function{
+		"if": {
+			minArgs: 3, maxArgs: 3,
+			lazy: func(record element.Record, args []node) (element.ColumnValue, error) {
+				cond, err := args[0].eval(record)
+				if err != nil {
+					return nil, err
+				}
+				var ok bool
+				if ok, err = asBool(cond); err != nil {
+					return nil, err
+				}
+				if ok {
+					return args[1].eval(record)
+				}
+				return args[2].eval(record)
+			},
+		},
+		"coalesce": {
+			minArgs: 1, maxArgs: -1,
+			lazy: func(record element.Record, args []node) (v element.ColumnValue, err error) {
+				for _, a := range args {
+					if v, err = a.eval(record); err != nil {
+						return nil, err
+					}
+					if !v.IsNil() {
+						return v, nil
+					}
+				}
+				return v, nil
+			},
+		},
+		"concat": {
+			minArgs: 1, maxArgs: -1, acceptNil: true,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				b := &strings.Builder{}
+				for _, a := range args {
+					if a.IsNil() {
+						continue
+					}
+					s, err := a.AsString()
+					if err != nil {
+						return nil, err
+					}
+					b.WriteString(s)
+				}
+				return element.NewStringColumnValue(b.String()), nil
+			},
+		},
+		"upper": stringFunction(strings.ToUpper),
+		"lower": stringFunction(strings.ToLower),
+		"trim":  stringFunction(strings.TrimSpace),
+		"length": {
+			minArgs: 1, maxArgs: 1, nilResult: element.NewNilBigIntColumnValue,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				s, err := args[0].AsString()
+				if err != nil {
+					return nil, err
+				}
+				return element.NewBigIntColumnValueFromInt64(int64(utf8.RuneCountInString(s))), nil
+			},
+		},
+		"substr": {
+			minArgs: 2, maxArgs: 3, nilResult: element.NewNilStringColumnValue,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				s, err := args[0].AsString()
+				if err != nil {
+					return nil, err
+				}
+				runes := []rune(s)
+				var start, length int64
+				if start, err = asInt64(args[1]); err != nil {
+					return nil, err
+				}
+				length = int64(len(runes))
+				if len(args) == 3 {
+					if length, err = asInt64(args[2]); err != nil {
+						return nil, err
+					}
+				}
+				if start < 0 || length < 0 {
+					return nil, errors.Errorf("start(%v) and length(%v) should not be negative", start, length)
+				}
+				if start > int64(len(runes)) {
+					start = int64(len(runes))
+				}
+				end := start + length
+				if end > int64(len(runes)) {
+					end = int64(len(runes))
+				}
+				return element.NewStringColumnValue(string(runes[start:end])), nil
+			},
+		},
+		"replace": {
+			minArgs: 3, maxArgs: 3, nilResult: element.NewNilStringColumnValue,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				var ss [3]string
+				for i := range ss {
+					var err error
+					if ss[i], err = args[i].AsString(); err != nil {
+						return nil, err
+					}
+				}
+				return element.NewStringColumnValue(strings.ReplaceAll(ss[0], ss[1], ss[2])), nil
+			},
+		},
+		"format_time": {
+			minArgs: 2, maxArgs: 2, nilResult: element.NewNilStringColumnValue,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				t, err := args[0].AsTime()
+				if err != nil {
+					return nil, err
+				}
+				var format string
+				if format, err = args[1].AsString(); err != nil {
+					return nil, err
+				}
+				return element.NewStringColumnValue(t.Format(jodaTime.GetLayout(format))), nil
+			},
+		},
+		"parse_time": {
+			minArgs: 2, maxArgs: 2, nilResult: element.NewNilTimeColumnValue,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				s, err := args[0].AsString()
+				if err != nil {
+					return nil, err
+				}
+				var format string
+				if format, err = args[1].AsString(); err != nil {
+					return nil, err
+				}
+				var t time.Time
+				if t, err = time.Parse(jodaTime.GetLayout(format), s); err != nil {
+					return nil, err
+				}
+				return element.NewTimeColumnValue(t), nil
+			},
+		},
+		"now": {
+			minArgs: 0, maxArgs: 0,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				return element.NewTimeColumnValue(time.Now()), nil
+			},
+		},
+		"string": {
+			minArgs: 1, maxArgs: 1, nilResult: element.NewNilStringColumnValue,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				s, err := args[0].AsString()
+				if err != nil {
+					return nil, err
+				}
+				return element.NewStringColumnValue(s), nil
+			},
+		},
+		"int": {
+			minArgs: 1, maxArgs: 1, nilResult: element.NewNilBigIntColumnValue,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				i, err := args[0].AsBigInt()
+				if err != nil {
+					return nil, err
+				}
+				return element.NewBigIntColumnValue(i.AsBigInt()), nil
+			},
+		},
+		"decimal": {
+			minArgs: 1, maxArgs: 1, nilResult: element.NewNilDecimalColumnValue,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				d, err := args[0].AsDecimal()
+				if err != nil {
+					return nil, err
+				}
+				return element.NewDecimalColumnValue(d.AsDecimal()), nil
+			},
+		},
+		"round": {
+			minArgs: 1, maxArgs: 2, nilResult: element.NewNilDecimalColumnValue,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				d, err := args[0].AsDecimal()
+				if err != nil {
+					return nil, err
+				}
+				var places int64
+				if len(args) == 2 {
+					if places, err = asInt64(args[1]); err != nil {
+						return nil, err
+					}
+				}
+				return element.NewDecimalColumnValue(d.AsDecimal().Round(int32(places))), nil
+			},
+		},
+		"abs": {
+			minArgs: 1, maxArgs: 1, nilResult: element.NewNilDecimalColumnValue,
+			call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+				if args[0].Type() == element.TypeBigInt {
+					i, err := args[0].AsBigInt()
+					if err != nil {
+						return nil, err
+					}
+					return element.NewBigIntColumnValue(new(big.Int).Abs(i.AsBigInt())), nil
+				}
+				d, err := args[0].AsDecimal()
+				if err != nil {
+					return nil, err
+				}
+				return element.NewDecimalColumnValue(d.AsDecimal().Abs()), nil
+			},
+		},
+	}
+}
+
+func stringFunction(f func(string) string) *function {
+	return &function{
+		minArgs: 1, maxArgs: 1, nilResult: element.NewNilStringColumnValue,
+		call: func(args []element.ColumnValue) (element.ColumnValue, error) {
+			s, err := args[0].AsString()
+			if err != nil {
+				return nil, err
+			}
+			return element.NewStringColumnValue(f(s)), nil
+		},
+	}
+}
+
+func asInt64(v element.ColumnValue) (int64, error) {
+	i, err := v.AsBigInt()
+	if err != nil {
+		return 0, err
+	}
+	return i.Int64()
+}
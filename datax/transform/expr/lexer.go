@@ -0,0 +1,188 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pingcap/errors"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF    tokenKind = iota //结束
+	tokenIdent                   //标识符
+	tokenIndex                   //列编号
+	tokenNumber                  //数字
+	tokenString                  //字符串
+	tokenOp                      //运算符以及标点
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q at %v", t.text, t.pos)
+}
+
+// 运算符按长度从长到短排列以便优先匹配
+var operators = []string{
+	"==", "!=", "<=", ">=", "&&", "||",
+	"+", "-", "*", "/", "%", "<", ">", "!", "(", ")", ",",
+}
+
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) tokens() (tokens []token, err error) {
+	for {
+		var t token
+		if t, err = l.next(); err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+		if t.kind == tokenEOF {
+			return
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(rune(l.input[l.pos])) {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: l.pos}, nil
+	}
+	start := l.pos
+	c := l.input[l.pos]
+	switch {
+	case c == '\'' || c == '"':
+		return l.str(c)
+	case c == '`':
+		end := strings.IndexByte(l.input[start+1:], '`')
+		if end < 0 {
+			return token{}, errors.Errorf("unterminated identifier at %v", start)
+		}
+		l.pos = start + end + 2
+		return token{kind: tokenIdent, text: l.input[start+1 : start+end+1], pos: start}, nil
+	case c == '$':
+		l.pos++
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+		if l.pos == start+1 {
+			return token{}, errors.Errorf("invalid column index at %v", start)
+		}
+		return token{kind: tokenIndex, text: l.input[start+1 : l.pos], pos: start}, nil
+	case isDigit(c) || (c == '.' && l.pos+1 < len(l.input) && isDigit(l.input[l.pos+1])):
+		return l.number()
+	case isIdentStart(c):
+		for l.pos < len(l.input) && isIdentPart(l.input[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenIdent, text: l.input[start:l.pos], pos: start}, nil
+	}
+	for _, op := range operators {
+		if strings.HasPrefix(l.input[l.pos:], op) {
+			l.pos += len(op)
+			return token{kind: tokenOp, text: op, pos: start}, nil
+		}
+	}
+	return token{}, errors.Errorf("unexpected character %q at %v", c, start)
+}
+
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos < len(l.input) && l.input[l.pos] == '.' {
+		l.pos++
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+	}
+	if l.pos < len(l.input) && (l.input[l.pos] == 'e' || l.input[l.pos] == 'E') {
+		l.pos++
+		if l.pos < len(l.input) && (l.input[l.pos] == '+' || l.input[l.pos] == '-') {
+			l.pos++
+		}
+		digits := l.pos
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.pos++
+		}
+		if digits == l.pos {
+			return token{}, errors.Errorf("invalid number at %v", start)
+		}
+	}
+	return token{kind: tokenNumber, text: l.input[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) str(quote byte) (token, error) {
+	start := l.pos
+	l.pos++
+	b := &strings.Builder{}
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch c {
+		case quote:
+			l.pos++
+			return token{kind: tokenString, text: b.String(), pos: start}, nil
+		case '\\':
+			if l.pos+1 >= len(l.input) {
+				return token{}, errors.Errorf("unterminated string at %v", start)
+			}
+			l.pos++
+			switch e := l.input[l.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			default:
+				b.WriteByte(e)
+			}
+		default:
+			b.WriteByte(c)
+		}
+		l.pos++
+	}
+	return token{}, errors.Errorf("unterminated string at %v", start)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+)
+
+// Expr 表达式
+type Expr struct {
+	source string
+	root   node
+}
+
+// Parse 解析表达式字符串s，语法错误或者函数不存在时会报错
+func Parse(s string) (*Expr, error) {
+	l := &lexer{input: s}
+	tokens, err := l.tokens()
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	var root node
+	if root, err = p.parse(0); err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, errors.Errorf("unexpected %v", t)
+	}
+	return &Expr{
+		source: s,
+		root:   root,
+	}, nil
+}
+
+// Eval 根据记录record计算表达式的值
+func (e *Expr) Eval(record element.Record) (element.ColumnValue, error) {
+	return e.root.eval(record)
+}
+
+// String 表达式字符串
+func (e *Expr) String() string {
+	return e.source
+}
+
+// 二元运算符优先级
+var precedences = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+const unaryPrecedence = 7
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(op string) error {
+	if t := p.advance(); t.kind != tokenOp || t.text != op {
+		return errors.Errorf("expect %q but got %v", op, t)
+	}
+	return nil
+}
+
+func (p *parser) parse(minPrecedence int) (left node, err error) {
+	if left, err = p.primary(); err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		if t.kind != tokenOp {
+			return left, nil
+		}
+		precedence, ok := precedences[t.text]
+		if !ok || precedence <= minPrecedence {
+			return left, nil
+		}
+		p.advance()
+		var right node
+		if right, err = p.parse(precedence); err != nil {
+			return nil, err
+		}
+		left = newBinary(t.text, left, right)
+	}
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokenNumber:
+		if strings.ContainsAny(t.text, ".eE") {
+			v, err := element.NewDecimalColumnValueFromString(t.text)
+			if err != nil {
+				return nil, errors.Errorf("invalid number %v", t)
+			}
+			return &literal{value: v}, nil
+		}
+		v, err := element.NewBigIntColumnValueFromString(t.text)
+		if err != nil {
+			return nil, errors.Errorf("invalid number %v", t)
+		}
+		return &literal{value: v}, nil
+	case tokenString:
+		return &literal{value: element.NewStringColumnValue(t.text)}, nil
+	case tokenIndex:
+		i, err := strconv.Atoi(t.text)
+		if err != nil {
+			return nil, errors.Errorf("invalid column index %v", t)
+		}
+		return &indexColumn{index: i}, nil
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return &literal{value: element.NewBoolColumnValue(true)}, nil
+		case "false":
+			return &literal{value: element.NewBoolColumnValue(false)}, nil
+		case "null":
+			return &literal{value: element.NewNilStringColumnValue()}, nil
+		}
+		if next := p.peek(); next.kind == tokenOp && next.text == "(" {
+			return p.call(t)
+		}
+		return &nameColumn{name: t.text}, nil
+	case tokenOp:
+		switch t.text {
+		case "(":
+			n, err := p.parse(0)
+			if err != nil {
+				return nil, err
+			}
+			if err = p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "-", "!":
+			x, err := p.parse(unaryPrecedence)
+			if err != nil {
+				return nil, err
+			}
+			return &unary{op: t.text, x: x}, nil
+		}
+	}
+	return nil, errors.Errorf("unexpected %v", t)
+}
+
+func (p *parser) call(name token) (node, error) {
+	fn, ok := functions[strings.ToLower(name.text)]
+	if !ok {
+		return nil, errors.Errorf("function %v does not exist", name)
+	}
+	p.advance()
+	var args []node
+	if t := p.peek(); t.kind == tokenOp && t.text == ")" {
+		p.advance()
+	} else {
+		for {
+			arg, err := p.parse(0)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			t := p.advance()
+			if t.kind == tokenOp && t.text == ")" {
+				break
+			}
+			if t.kind != tokenOp || t.text != "," {
+				return nil, errors.Errorf("expect \",\" or \")\" but got %v", t)
+			}
+		}
+	}
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		return nil, errors.Errorf("function %v has wrong number of arguments(%v)", name, len(args))
+	}
+	return &call{name: name.text, fn: fn, args: args}, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/datax/transform/expr"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+)
+
+// NewExprTransformer 通过配置conf创建表达式转化器，配置形如
+//
+//	{
+//	    "name":"dx_expr",
+//	    "parameter":{
+//	        "columnName":"full_name",
+//	        "expression":"concat(first_name, ' ', last_name)"
+//	    }
+//	}
+//
+// 表达式的计算结果会覆盖columnIndex或者columnName对应的列，当columnName对应的列
+// 不存在时会在记录末尾新增该列，表达式语法见expr包
+func NewExprTransformer(conf *config.JSON) (Transformer, error) {
+	param, err := NewParameter(conf, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	var s string
+	if s, err = conf.GetString("parameter.expression"); err != nil {
+		return nil, err
+	}
+	var e *expr.Expr
+	if e, err = expr.Parse(s); err != nil {
+		return nil, errors.Wrapf(err, "parse expression(%v) fail", s)
+	}
+	return &exprTransformer{
+		param: param,
+		expr:  e,
+	}, nil
+}
+
+type exprTransformer struct {
+	param *Parameter
+	expr  *expr.Expr
+}
+
+// DoTransform 转化记录record
+func (e *exprTransformer) DoTransform(record element.Record) (element.Record, error) {
+	v, err := e.expr.Eval(record)
+	if err != nil {
+		return nil, errors.Wrapf(err, "eval expression(%v) fail", e.expr)
+	}
+	size := 0
+	if !v.IsNil() {
+		size = len(v.String())
+	}
+
+	var i int
+	var c element.Column
+	if e.param.ColumnIndex != nil {
+		i, c, err = e.param.Column(record)
+	} else {
+		i, c, err = columnByName(record, e.param.ColumnName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if c == nil {
+		err = record.Add(element.NewDefaultColumn(v, e.param.ColumnName, size))
+	} else {
+		err = record.Set(i, element.NewDefaultColumn(v, c.Name(), size))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestNewExprTransformer(t *testing.T) {
+	tests := []struct {
+		name       string
+		conf       string
+		wantColumn string
+		want       string
+		wantNumber int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			conf:       `{"name":"dx_expr","parameter":{"columnName":"full","expression":"c0 + ' ' + c1"}}`,
+			wantColumn: "full",
+			want:       "John Smith",
+			wantNumber: 4,
+		},
+		{
+			name:       "2",
+			conf:       `{"name":"dx_expr","parameter":{"columnName":"c2","expression":"int(c2 * 100)"}}`,
+			wantColumn: "c2",
+			want:       "1234",
+			wantNumber: 3,
+		},
+		{
+			name:       "3",
+			conf:       `{"name":"dx_expr","parameter":{"columnIndex":0,"expression":"coalesce(null, upper(c0))"}}`,
+			wantColumn: "c0",
+			want:       "JOHN",
+			wantNumber: 3,
+		},
+		{
+			name:       "4",
+			conf:       `{"name":"dx_expr","parameter":{"columnName":"c1","expression":"null"}}`,
+			wantColumn: "c1",
+			want:       "<nil>",
+			wantNumber: 3,
+		},
+		{
+			name:    "5",
+			conf:    `{"name":"dx_expr","parameter":{"columnName":"c1"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			conf:    `{"name":"dx_expr","parameter":{"columnName":"c1","expression":"c0 +"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "7",
+			conf:    `{"name":"dx_expr","parameter":{"columnName":"c1","expression":"c0 * 2"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			conf:    `{"name":"dx_expr","parameter":{"columnIndex":3,"expression":"c0"}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, _ := element.NewDecimalColumnValueFromString("12.34")
+			got, err := testTransform(t, tt.conf, testRecord(element.NewStringColumnValue("John"),
+				element.NewStringColumnValue("Smith"), amount))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoTransform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.ColumnNumber() != tt.wantNumber {
+				t.Errorf("DoTransform() ColumnNumber = %v, want %v", got.ColumnNumber(), tt.wantNumber)
+			}
+			c, err := got.GetByName(tt.wantColumn)
+			if err != nil {
+				t.Fatalf("GetByName() error = %v", err)
+			}
+			if c.String() != tt.want {
+				t.Errorf("DoTransform() = %v, want %v", c.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+	"github.com/shopspring/decimal"
+)
+
+// NewFilterTransformer 通过配置conf创建过滤转化器，配置形如
+//
+//	{
+//	    "name":"dx_filter",
+//	    "parameter":{
+//	        "columnIndex":1,
+//	        "paras":[">=","100"]
+//	    }
+//	}
+//
+// paras依次为运算符(like,not like,>,<,=,!=,>=,<=)以及比较值，满足条件的记录会被过滤掉。
+// 其中like和not like使用正则表达式匹配，整数和高精度实数列按数值比较，其余列按字符串比较，
+// 空值列只与比较值null相等
+func NewFilterTransformer(conf *config.JSON) (Transformer, error) {
+	param, err := NewParameter(conf, 2, 2)
+	if err != nil {
+		return nil, err
+	}
+	f := &filterTransformer{
+		param: param,
+		op:    strings.ToLower(strings.TrimSpace(param.Paras[0])),
+		value: param.Paras[1],
+	}
+	switch f.op {
+	case "like", "not like":
+		if f.re, err = regexp.Compile(f.value); err != nil {
+			return nil, errors.Wrapf(err, "paras 1(%v) is not valid regexp", f.value)
+		}
+	case ">", "<", "=", "!=", ">=", "<=":
+		f.decimal, f.decimalErr = decimal.NewFromString(f.value)
+	default:
+		return nil, errors.Errorf("paras 0(%v) is not valid operator", param.Paras[0])
+	}
+	return f, nil
+}
+
+type filterTransformer struct {
+	param      *Parameter
+	op         string
+	value      string
+	re         *regexp.Regexp
+	decimal    decimal.Decimal
+	decimalErr error
+}
+
+// DoTransform 转化记录record，满足条件时返回空记录
+func (f *filterTransformer) DoTransform(record element.Record) (element.Record, error) {
+	_, c, err := f.param.Column(record)
+	if err != nil {
+		return nil, err
+	}
+	var ok bool
+	if ok, err = f.match(c); err != nil {
+		return nil, err
+	}
+	if ok {
+		return nil, nil
+	}
+	return record, nil
+}
+
+func (f *filterTransformer) match(c element.Column) (bool, error) {
+	if c.IsNil() {
+		switch f.op {
+		case "=":
+			return f.value == "null", nil
+		case "!=":
+			return f.value != "null", nil
+		}
+		return false, nil
+	}
+
+	if f.re != nil {
+		s, err := c.AsString()
+		if err != nil {
+			return false, err
+		}
+		return f.re.MatchString(s) == (f.op == "like"), nil
+	}
+
+	cmp := 0
+	switch c.Type() {
+	case element.TypeBigInt, element.TypeDecimal:
+		if f.decimalErr != nil {
+			return false, errors.Wrapf(f.decimalErr, "value(%v) is not number", f.value)
+		}
+		d, err := c.AsDecimal()
+		if err != nil {
+			return false, err
+		}
+		cmp = d.AsDecimal().Cmp(f.decimal)
+	default:
+		s, err := c.AsString()
+		if err != nil {
+			return false, err
+		}
+		cmp = strings.Compare(s, f.value)
+	}
+
+	switch f.op {
+	case ">":
+		return cmp > 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "=":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+	return cmp <= 0, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestNewFilterTransformer(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     string
+		value    element.ColumnValue
+		filtered bool
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			conf:     `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":[">","100"]}}`,
+			value:    element.NewBigIntColumnValueFromInt64(101),
+			filtered: true,
+		},
+		{
+			name:  "2",
+			conf:  `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":[">","100"]}}`,
+			value: element.NewBigIntColumnValueFromInt64(99),
+		},
+		{
+			name:     "3",
+			conf:     `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":["<=","1.5"]}}`,
+			value:    element.NewDecimalColumnValueFromFloat(1.5),
+			filtered: true,
+		},
+		{
+			name:     "4",
+			conf:     `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":["like","^test"]}}`,
+			value:    element.NewStringColumnValue("test_user"),
+			filtered: true,
+		},
+		{
+			name:  "5",
+			conf:  `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":["not like","^test"]}}`,
+			value: element.NewStringColumnValue("test_user"),
+		},
+		{
+			name:     "6",
+			conf:     `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":["=","null"]}}`,
+			value:    element.NewNilStringColumnValue(),
+			filtered: true,
+		},
+		{
+			name:  "7",
+			conf:  `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":[">","a"]}}`,
+			value: element.NewNilStringColumnValue(),
+		},
+		{
+			name:     "8",
+			conf:     `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":["!=","abc"]}}`,
+			value:    element.NewStringColumnValue("abd"),
+			filtered: true,
+		},
+		{
+			name:     "9",
+			conf:     `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":[">=","abc"]}}`,
+			value:    element.NewStringColumnValue("abc"),
+			filtered: true,
+		},
+		{
+			name:  "10",
+			conf:  `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":["<","abc"]}}`,
+			value: element.NewStringColumnValue("abc"),
+		},
+		{
+			name:     "11",
+			conf:     `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":["=","abc"]}}`,
+			value:    element.NewStringColumnValue("abc"),
+			filtered: true,
+		},
+		{
+			name:    "12",
+			conf:    `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":[">","abc"]}}`,
+			value:   element.NewBigIntColumnValueFromInt64(1),
+			wantErr: true,
+		},
+		{
+			name:    "13",
+			conf:    `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":["~","abc"]}}`,
+			value:   element.NewStringColumnValue("abc"),
+			wantErr: true,
+		},
+		{
+			name:    "14",
+			conf:    `{"name":"dx_filter","parameter":{"columnIndex":0,"paras":["like","("]}}`,
+			value:   element.NewStringColumnValue("abc"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record := testRecord(tt.value)
+			got, err := testTransform(t, tt.conf, record)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoTransform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if (got == nil) != tt.filtered {
+				t.Errorf("DoTransform() = %v, filtered %v", got, tt.filtered)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"strings"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/pingcap/errors"
+)
+
+// NewPadTransformer 通过配置conf创建填充字符串转化器，配置形如
+//
+//	{
+//	    "name":"dx_pad",
+//	    "parameter":{
+//	        "columnIndex":1,
+//	        "paras":["l","8","0"]
+//	    }
+//	}
+//
+// paras依次为填充方向(l代表左侧,r代表右侧)、填充后的字符个数以及填充字符串，
+// 当字符串长度超过填充后的字符个数时会截取左侧部分
+func NewPadTransformer(conf *config.JSON) (Transformer, error) {
+	param, err := NewParameter(conf, 3, 3)
+	if err != nil {
+		return nil, err
+	}
+	direction := param.Paras[0]
+	if direction != "l" && direction != "r" {
+		return nil, errors.Errorf("paras 0(%v) should be l or r", direction)
+	}
+	var length int
+	if length, err = param.Int(1); err != nil {
+		return nil, err
+	}
+	pad := param.Paras[2]
+	if pad == "" {
+		return nil, errors.New("paras 2 should not be empty")
+	}
+	return &stringColumnTransformer{
+		param: param,
+		transform: func(s string) string {
+			n := runeLen(s)
+			if n >= length {
+				_, end := runeRange(s, 0, length)
+				return s[:end]
+			}
+			padding := strings.Repeat(pad, (length-n)/runeLen(pad)+1)
+			_, end := runeRange(padding, 0, length-n)
+			if direction == "l" {
+				return padding[:end] + s
+			}
+			return s + padding[:end]
+		},
+	}, nil
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"strings"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+// NewReplaceTransformer 通过配置conf创建替换字符串转化器，配置形如
+//
+//	{
+//	    "name":"dx_replace",
+//	    "parameter":{
+//	        "columnIndex":1,
+//	        "paras":["3","4","****"]
+//	    }
+//	}
+//
+// paras依次为起始字符位置(从0开始)、被替换的字符个数以及替换字符串，超出字符串长度的部分会被忽略
+func NewReplaceTransformer(conf *config.JSON) (Transformer, error) {
+	param, err := NewParameter(conf, 3, 3)
+	if err != nil {
+		return nil, err
+	}
+	var start, length int
+	if start, err = param.Int(0); err != nil {
+		return nil, err
+	}
+	if length, err = param.Int(1); err != nil {
+		return nil, err
+	}
+	replace := param.Paras[2]
+	return &stringColumnTransformer{
+		param: param,
+		transform: func(s string) string {
+			begin, end := runeRange(s, start, length)
+			return s[:begin] + replace + s[end:]
+		},
+	}, nil
+}
+
+// NewMaskTransformer 通过配置conf创建遮盖字符串转化器，配置形如
+//
+//	{
+//	    "name":"dx_mask",
+//	    "parameter":{
+//	        "columnIndex":1,
+//	        "paras":["3","4","*"]
+//	    }
+//	}
+//
+// paras依次为起始字符位置(从0开始)、被遮盖的字符个数以及遮盖字符串(默认为*)，
+// 被遮盖的每个字符都会被替换成遮盖字符串，超出字符串长度的部分会被忽略
+func NewMaskTransformer(conf *config.JSON) (Transformer, error) {
+	param, err := NewParameter(conf, 2, 3)
+	if err != nil {
+		return nil, err
+	}
+	var start, length int
+	if start, err = param.Int(0); err != nil {
+		return nil, err
+	}
+	if length, err = param.Int(1); err != nil {
+		return nil, err
+	}
+	mask := "*"
+	if len(param.Paras) == 3 {
+		mask = param.Paras[2]
+	}
+	return &stringColumnTransformer{
+		param: param,
+		transform: func(s string) string {
+			begin, end := runeRange(s, start, length)
+			return s[:begin] + strings.Repeat(mask, runeLen(s[begin:end])) + s[end:]
+		},
+	}, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestNewReplaceTransformer(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		value   element.ColumnValue
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			conf:  `{"name":"dx_replace","parameter":{"columnIndex":0,"paras":["1","2","xy"]}}`,
+			value: element.NewStringColumnValue("abcdef"),
+			want:  "axydef",
+		},
+		{
+			name:  "2",
+			conf:  `{"name":"dx_replace","parameter":{"columnIndex":0,"paras":["4","10",""]}}`,
+			value: element.NewStringColumnValue("abcdef"),
+			want:  "abcd",
+		},
+		{
+			name:    "3",
+			conf:    `{"name":"dx_replace","parameter":{"columnIndex":0,"paras":["4","10"]}}`,
+			value:   element.NewStringColumnValue("abcdef"),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    `{"name":"dx_replace","parameter":{"columnIndex":0,"paras":["4","a",""]}}`,
+			value:   element.NewStringColumnValue("abcdef"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testTransform(t, tt.conf, testRecord(tt.value))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoTransform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if s := testColumnString(t, got, 0); s != tt.want {
+				t.Errorf("DoTransform() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMaskTransformer(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    string
+		value   element.ColumnValue
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "1",
+			conf:  `{"name":"dx_mask","parameter":{"columnIndex":0,"paras":["3","4"]}}`,
+			value: element.NewStringColumnValue("13812345678"),
+			want:  "138****5678",
+		},
+		{
+			name:  "2",
+			conf:  `{"name":"dx_mask","parameter":{"columnIndex":0,"paras":["1","10","#"]}}`,
+			value: element.NewStringColumnValue("张三丰"),
+			want:  "张##",
+		},
+		{
+			name:    "3",
+			conf:    `{"name":"dx_mask","parameter":{"columnIndex":0,"paras":["1"]}}`,
+			value:   element.NewStringColumnValue("abc"),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    `{"name":"dx_mask","parameter":{"columnIndex":0,"paras":["1","b"]}}`,
+			value:   element.NewStringColumnValue("abc"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := testTransform(t, tt.conf, testRecord(tt.value))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DoTransform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if s := testColumnString(t, got, 0); s != tt.want {
+				t.Errorf("DoTransform() = %v, want %v", s, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"github.com/Breeze0806/go-etl/config"
+)
+
+// NewSubstrTransformer 通过配置conf创建截取字符串转化器，配置形如
+//
+//	{
+//	    "name":"dx_substr",
+//	    "parameter":{
+//	        "columnIndex":1,
+//	        "paras":["1","3"]
+//	    }
+//	}
+//
+// paras依次为起始字符位置(从0开始)以及截取的字符个数，超出字符串长度的部分会被忽略
+func NewSubstrTransformer(conf *config.JSON) (Transformer, error) {
+	param, err := NewParameter(conf, 2, 2)
+	if err != nil {
+		return nil, err
+	}
+	var start, length int
+	if start, err = param.Int(0); err != nil {
+		return nil, err
+	}
+	if length, err = param.Int(1); err != nil {
+		return nil, err
+	}
+	return &stringColumnTransformer{
+		param: param,
+		transform: func(s string) string {
+			begin, end := runeRange(s, start, length)
+			return s[begin:end]
+		},
+	}, nil
+}
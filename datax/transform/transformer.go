@@ -14,9 +14,12 @@
 
 package transform
 
-import "github.com/Breeze0806/go-etl/element"
+import (
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+)
 
-// Transformer 转化器
+// Transformer 转化器，当转化后的记录为空时，该记录会被过滤掉
 type Transformer interface {
 	DoTransform(element.Record) (element.Record, error)
 }
@@ -28,3 +31,39 @@ type NilTransformer struct{}
 func (n *NilTransformer) DoTransform(record element.Record) (element.Record, error) {
 	return record, nil
 }
+
+// ChainTransformer 链式转化器，依次使用各个转化器转化记录
+type ChainTransformer struct {
+	names        []string
+	transformers []Transformer
+}
+
+// NewChainTransformer 创建空的链式转化器
+func NewChainTransformer() *ChainTransformer {
+	return &ChainTransformer{}
+}
+
+// Append 在链尾加入名为name的转化器t
+func (c *ChainTransformer) Append(name string, t Transformer) {
+	c.names = append(c.names, name)
+	c.transformers = append(c.transformers, t)
+}
+
+// Len 转化器个数
+func (c *ChainTransformer) Len() int {
+	return len(c.transformers)
+}
+
+// DoTransform 依次转化记录record，当某个转化器过滤掉记录时，不再执行后续转化器
+func (c *ChainTransformer) DoTransform(record element.Record) (element.Record, error) {
+	var err error
+	for i, t := range c.transformers {
+		if record, err = t.DoTransform(record); err != nil {
+			return nil, errors.Wrapf(err, "transformer %v(%v) fail", i, c.names[i])
+		}
+		if record == nil {
+			return nil, nil
+		}
+	}
+	return record, nil
+}
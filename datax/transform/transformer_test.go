@@ -15,6 +15,7 @@
 package transform
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/Breeze0806/go-etl/element"
@@ -55,3 +56,64 @@ func TestNilTransformer_DoTransform(t *testing.T) {
 		})
 	}
 }
+
+type mockFilterTransformer struct{}
+
+func (m *mockFilterTransformer) DoTransform(record element.Record) (element.Record, error) {
+	return nil, nil
+}
+
+func TestChainTransformer_DoTransform(t *testing.T) {
+	r := element.NewDefaultRecord()
+	tests := []struct {
+		name         string
+		transformers []Transformer
+		want         element.Record
+		wantErr      bool
+	}{
+		{
+			name: "1",
+			want: r,
+		},
+		{
+			name: "2",
+			transformers: []Transformer{
+				&NilTransformer{},
+				&mockTransformer{},
+			},
+			want: r,
+		},
+		{
+			name: "3",
+			transformers: []Transformer{
+				&mockFilterTransformer{},
+				&mockTransformer{err: errors.New("mock error")},
+			},
+			want: nil,
+		},
+		{
+			name: "4",
+			transformers: []Transformer{
+				&NilTransformer{},
+				&mockTransformer{err: errors.New("mock error")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChainTransformer()
+			for _, v := range tt.transformers {
+				c.Append("mock", v)
+			}
+			got, err := c.DoTransform(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ChainTransformer.DoTransform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ChainTransformer.DoTransform() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,14 +6,20 @@ require (
 	github.com/Breeze0806/go v0.0.0-20220514112848-d6ac64c7ff26
 	github.com/Breeze0806/jodaTime v1.0.1
 	github.com/denisenkom/go-mssqldb v0.12.3
+	github.com/dsnet/compress v0.0.1
 	github.com/go-sql-driver/mysql v1.7.1
 	github.com/godror/godror v0.33.3
 	github.com/google/uuid v1.3.0
 	github.com/gorilla/handlers v1.5.1
 	github.com/ibmdb/go_ibm_db v0.4.4
+	github.com/klauspost/compress v1.15.9
 	github.com/lib/pq v1.10.9
+	github.com/mattn/go-sqlite3 v1.14.17
 	github.com/pingcap/errors v0.11.4
 	github.com/shopspring/decimal v1.3.1
+	github.com/tidwall/gjson v1.14.1
+	github.com/tidwall/sjson v1.2.4
+	github.com/ulikunitz/xz v0.5.11
 	github.com/xuri/excelize/v2 v2.7.1
 	go.uber.org/atomic v1.9.0
 	golang.org/x/text v0.10.0
@@ -24,6 +24,7 @@ type RetryTask struct {
 	ctx      context.Context
 	task     Task
 	strategy RetryStrategy
+	retries  int
 }
 
 // NewRetryTask 通过上下文关系ctx，重试策略strategy以及任务task生成重试任务
@@ -64,8 +65,14 @@ func (r *RetryTask) Do() (err error) {
 
 		select {
 		case <-ticker.C:
+			r.retries++
 		case <-r.ctx.Done():
 			return
 		}
 	}
 }
+
+// RetryCount 执行Do时的重试次数
+func (r *RetryTask) RetryCount() int {
+	return r.retries
+}
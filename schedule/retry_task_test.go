@@ -138,3 +138,46 @@ func TestRetryTask_Do(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryTask_RetryCount(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy RetryStrategy
+		task     Task
+		want     int
+	}{
+		{
+			name:     "1",
+			strategy: NewNTimesRetryStrategy(&mockRetryJudger{}, 10, 1*time.Millisecond),
+			task: &mockNTimeTask{
+				n: 1,
+			},
+			want: 0,
+		},
+		{
+			name:     "2",
+			strategy: NewNTimesRetryStrategy(&mockRetryJudger{}, 10, 1*time.Millisecond),
+			task: &mockNTimeTask{
+				n: 3,
+			},
+			want: 2,
+		},
+		{
+			name:     "3",
+			strategy: NewNTimesRetryStrategy(&mockRetryJudger{}, 10, 1*time.Millisecond),
+			task: &mockNTimeTask{
+				n: 11,
+			},
+			want: 9,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRetryTask(context.TODO(), tt.strategy, tt.task)
+			r.Do()
+			if got := r.RetryCount(); got != tt.want {
+				t.Errorf("RetryTask.RetryCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"strings"
+)
+
+// TableCatalog Source的补充方法，用于从数据库目录中查询表
+type TableCatalog interface {
+	//获取查询基础表t所在的库或者模式下所有表名的查询语句以及参数，查询结果只有表名一列
+	TablesQuery(t *BaseTable) (query string, args []interface{})
+}
+
+// IsTablePattern 表名name是否为带有通配符*或者%的表名模式
+func IsTablePattern(name string) bool {
+	return strings.ContainsAny(name, "*%")
+}
+
+// MatchTableName 表名name是否匹配表名模式pattern，通配符*和%匹配任意多个字符，其他字符需要完全相同
+func MatchTableName(pattern, name string) bool {
+	i := strings.IndexAny(pattern, "*%")
+	if i < 0 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, pattern[:i]) {
+		return false
+	}
+	pattern, name = strings.TrimLeft(pattern[i:], "*%"), name[i:]
+	if pattern == "" {
+		return true
+	}
+	for j := 0; j <= len(name); j++ {
+		if MatchTableName(pattern, name[j:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import "testing"
+
+func TestIsTablePattern(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "orders", want: false},
+		{name: "orders_%", want: true},
+		{name: "*", want: true},
+		{name: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTablePattern(tt.name); got != tt.want {
+				t.Errorf("IsTablePattern() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchTableName(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{pattern: "*", name: "orders", want: true},
+		{pattern: "%", name: "", want: true},
+		{pattern: "orders", name: "orders", want: true},
+		{pattern: "orders", name: "Orders", want: false},
+		{pattern: "orders_%", name: "orders_2021", want: true},
+		{pattern: "orders_%", name: "orders2021", want: false},
+		{pattern: "orders_%", name: "orders_", want: true},
+		{pattern: "%_log", name: "access_log", want: true},
+		{pattern: "%_log", name: "access_logs", want: false},
+		{pattern: "t*_*_x", name: "t_a_b_x", want: true},
+		{pattern: "t*_*_x", name: "t_a_x_y", want: false},
+		{pattern: "a**b", name: "ab", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.name, func(t *testing.T) {
+			if got := MatchTableName(tt.pattern, tt.name); got != tt.want {
+				t.Errorf("MatchTableName(%v, %v) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -70,7 +70,13 @@ type ConfigSetter interface {
 }
 
 type BaseConfig struct {
-	TrimChar bool `json:"trimChar"`
+	TrimChar  bool     `json:"trimChar"`
+	KeyColumn []string `json:"keyColumn"` //键列，用于upsert等按键写入的写入模式
+}
+
+// KeyColumnsGetter Table的补充方法，用于获取配置的键列
+type KeyColumnsGetter interface {
+	KeyColumns() []string
 }
 
 // BaseConfigSetter 基础表配置设置
@@ -93,6 +99,11 @@ func (b *BaseConfigSetter) Config() *config.JSON {
 	return b.conf
 }
 
+// KeyColumns 获取配置的键列
+func (b *BaseConfigSetter) KeyColumns() []string {
+	return b.KeyColumn
+}
+
 // TrimStringChar 消除字符串 char 前后的空格
 func (b *BaseConfigSetter) TrimStringChar(char string) string {
 	if b.TrimChar {
@@ -323,3 +323,36 @@ func TestBaseConfigSetter_TrimByteChar(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseConfigSetter_KeyColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		conf *config.JSON
+		want []string
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{"keyColumn":["f1","f2"]}`),
+			want: []string{"f1", "f2"},
+		},
+		{
+			name: "2",
+			conf: testJSONFromString(`{}`),
+			want: nil,
+		},
+		{
+			name: "3",
+			conf: nil,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &BaseConfigSetter{}
+			b.SetConfig(tt.conf)
+			if got := b.KeyColumns(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BaseConfigSetter.KeyColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"sort"
 	"strconv"
 
 	"github.com/Breeze0806/go-etl/element"
@@ -27,6 +28,9 @@ import (
 // 写入数据库模式
 const (
 	WriteModeInsert = "insert"
+	WriteModeUpsert = "upsert"
+	WriteModeUpdate = "update"
+	WriteModeDelete = "delete"
 )
 
 // FetchHandler 获取记录句柄
@@ -128,7 +132,46 @@ func (d *DB) FetchTableWithParam(ctx context.Context, param Parameter) (Table, e
 	}
 	defer rows.Close()
 
-	return fetchTableByRows(rows, table)
+	if table, err = fetchTableByRows(rows, table); err != nil {
+		return nil, err
+	}
+	if fetcher, ok := table.(ColumnTypesFetcher); ok {
+		if err = fetcher.FetchColumnTypes(ctx, d); err != nil {
+			return nil, errors.Wrapf(err, "FetchColumnTypes fail")
+		}
+	}
+	return table, nil
+}
+
+// FetchTables 通过上下文ctx从数据库目录中获取与基础表t在同一库或者模式下，
+// 表名匹配t的表名模式的所有表，按照表名排序，数据源需要实现TableCatalog
+func (d *DB) FetchTables(ctx context.Context, t *BaseTable) (tables []*BaseTable, err error) {
+	catalog, ok := d.Source.(TableCatalog)
+	if !ok {
+		return nil, errors.Errorf("source %v does not support to fetch tables", d.Source.DriverName())
+	}
+	query, args := catalog.TablesQuery(t)
+	var rows *sql.Rows
+	if rows, err = d.QueryContext(ctx, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "QueryContext(%v) fail", query)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, errors.Wrapf(err, "Scan fail")
+		}
+		if MatchTableName(t.Name(), name) {
+			tables = append(tables, NewBaseTable(t.Instance(), t.Schema(), name))
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "rows.Err fail")
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name() < tables[j].Name()
+	})
+	return
 }
 
 // FetchRecord 通过上下文ctx，sql参数param以及记录处理函数onRecord
@@ -301,6 +344,9 @@ func (d *DB) batchExecStmt(ctx context.Context, param Parameter, records []eleme
 			return errors.Wrapf(err, "stmt.ExecContext(%v) fail", query)
 		}
 	}
+	if !needFlushStmt(param) {
+		return
+	}
 	if _, err = stmt.ExecContext(ctx); err != nil {
 		return errors.Wrapf(err, "stmt.ExecContext fail")
 	}
@@ -370,30 +416,52 @@ func (d *DB) batchExecStmtWithTx(ctx context.Context, param Parameter, records [
 			return errors.Wrapf(err, "stmt.ExecContext(%v) fail", query)
 		}
 	}
+	if !needFlushStmt(param) {
+		return
+	}
 	if _, err = stmt.ExecContext(ctx); err != nil {
 		return errors.Wrapf(err, "stmt.ExecContext fail")
 	}
 	return
 }
 
+// needFlushStmt 判断参数param逐条执行完所有记录后是否需要不带参数再执行一次
+func needFlushStmt(param Parameter) bool {
+	if flusher, ok := param.(StmtFlusher); ok {
+		return flusher.NeedFlushStmt()
+	}
+	return true
+}
+
 func execParam(opts *ParameterOptions) (param Parameter, err error) {
 	execParams, ok := opts.Table.(ExecParameter)
 	if !ok {
-		if opts.Mode != WriteModeInsert {
-			return nil, errors.Errorf("table is not ExecParameter and mode is not insert")
+		if param, ok = defaultExecParam(opts); !ok {
+			return nil, errors.Errorf("table is not ExecParameter and mode is not insert, update or delete")
 		}
-		param = NewInsertParam(opts.Table, opts.TxOptions)
 	} else {
 		if param, ok = execParams.ExecParam(opts.Mode, opts.TxOptions); !ok {
-			if opts.Mode != WriteModeInsert {
-				return nil, errors.Errorf("ExecParam is not exist and mode is not insert")
+			if param, ok = defaultExecParam(opts); !ok {
+				return nil, errors.Errorf("ExecParam is not exist and mode is not insert, update or delete")
 			}
-			param = NewInsertParam(opts.Table, opts.TxOptions)
 		}
 	}
 	return
 }
 
+// defaultExecParam 获取通用的插入，更新和删除执行参数
+func defaultExecParam(opts *ParameterOptions) (Parameter, bool) {
+	switch opts.Mode {
+	case WriteModeInsert:
+		return NewInsertParam(opts.Table, opts.TxOptions), true
+	case WriteModeUpdate:
+		return NewUpdateParam(opts.Table, opts.TxOptions), true
+	case WriteModeDelete:
+		return NewDeleteParam(opts.Table, opts.TxOptions), true
+	}
+	return nil, false
+}
+
 func getQueryAndAgrs(param Parameter, records []element.Record) (query string, agrs []interface{}, err error) {
 	if query, err = param.Query(records); err != nil {
 		err = errors.Errorf("param.Query() err: %v", err)
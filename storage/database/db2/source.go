@@ -79,6 +79,14 @@ func (s *Source) Table(b *database.BaseTable) database.Table {
 	return NewTable(b)
 }
 
+// TablesQuery 获取查询表b所在模式下所有表名的查询语句以及参数，模式为空时使用当前模式
+func (s *Source) TablesQuery(b *database.BaseTable) (string, []interface{}) {
+	if b.Schema() == "" {
+		return "select tabname from syscat.tables where tabschema = current schema and type = 'T'", nil
+	}
+	return "select tabname from syscat.tables where tabschema = ? and type = 'T'", []interface{}{b.Schema()}
+}
+
 // Quoted db2引用函数
 func Quoted(s string) string {
 	return `"` + s + `"`
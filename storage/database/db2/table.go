@@ -52,8 +52,12 @@ func (t *Table) AddField(baseField *database.BaseField) {
 	t.AppendField(f)
 }
 
-// ExecParam 获取执行参数
+// ExecParam 获取执行参数，其中upsert的参数方式以及被注册
 func (t *Table) ExecParam(mode string, txOpts *sql.TxOptions) (database.Parameter, bool) {
+	switch mode {
+	case database.WriteModeUpsert:
+		return NewMergeParam(t, txOpts), true
+	}
 	return nil, false
 }
 
@@ -67,3 +71,15 @@ func (t *Table) ShouldOneByOne(err error) bool {
 	_, ok := errors.Cause(err).(*go_ibm_db.Error)
 	return ok
 }
+
+// MergeParam merge into 参数
+type MergeParam struct {
+	*database.ValuesMergeParam
+}
+
+// NewMergeParam 通过表table和事务参数txOpts生成merge参数
+func NewMergeParam(t database.Table, txOpts *sql.TxOptions) *MergeParam {
+	return &MergeParam{
+		ValuesMergeParam: database.NewValuesMergeParam(t, txOpts),
+	}
+}
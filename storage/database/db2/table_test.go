@@ -20,6 +20,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/ibmdb/go_ibm_db"
 )
@@ -205,3 +206,30 @@ func TestTable_ShouldOneByOne(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeParam_Query(t *testing.T) {
+	table := NewTable(database.NewBaseTable("db", "schema", "table"))
+	table.SetConfig(testJSONFromString(`{"keyColumn":["f1"]}`))
+	table.AppendField(NewField(database.NewBaseField(0, "f1", newMockFieldType("BIGINT"))))
+	table.AppendField(NewField(database.NewBaseField(1, "f2", newMockFieldType("BIGINT"))))
+
+	param, ok := table.ExecParam(database.WriteModeUpsert, nil)
+	if !ok {
+		t.Fatalf("Table.ExecParam() ok = %v", ok)
+	}
+	if _, ok = param.(*MergeParam); !ok {
+		t.Fatalf("Table.ExecParam() = %T", param)
+	}
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "f1", 0))
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(2), "f2", 0))
+	gotQuery, err := param.Query([]element.Record{r})
+	if err != nil {
+		t.Fatalf("MergeParam.Query() error = %v", err)
+	}
+	want := `merge into "schema"."table" as t using (values(?,?)) as s("f1","f2") on (t."f1"=s."f1")` +
+		` when matched then update set t."f2"=s."f2" when not matched then insert("f1","f2") values(s."f1",s."f2")`
+	if gotQuery != want {
+		t.Errorf("MergeParam.Query() = %v, want %v", gotQuery, want)
+	}
+}
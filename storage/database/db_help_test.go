@@ -29,6 +29,42 @@ import (
 
 var once sync.Once
 
+var catalogOnce sync.Once
+
+type mockCatalogSource struct {
+	*mockSource
+}
+
+func (m *mockCatalogSource) TablesQuery(t *BaseTable) (string, []interface{}) {
+	return "select name from tables where schema = ?", []interface{}{t.Schema()}
+}
+
+func testCatalogDB() *DB {
+	catalogOnce.Do(func() {
+		sql.Register("mockCatalog", &mockDriver{
+			rows: &mockRows{
+				columns: []string{"name"},
+				types: []*mockFieldType{
+					newMockFieldType(GoTypeString),
+				},
+				columnValues: [][]driver.Value{
+					{"orders_2022"}, {"users"}, {"orders_2021"}, {"orders"},
+				},
+			},
+		})
+	})
+	db, err := NewDB(&mockCatalogSource{
+		mockSource: &mockSource{
+			BaseSource: NewBaseSource(testJSONFromString("{}")),
+			name:       "mockCatalog",
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
 type mockTableWithOther struct {
 	*mockTable
 	err        error
@@ -52,6 +88,17 @@ func (m *mockTableWithOther) ExecParam(mode string, txOpts *sql.TxOptions) (p Pa
 	return
 }
 
+type mockTableWithColumnTypes struct {
+	*mockTable
+	err     error
+	fetched bool
+}
+
+func (m *mockTableWithColumnTypes) FetchColumnTypes(ctx context.Context, db *DB) error {
+	m.fetched = true
+	return m.err
+}
+
 type mockTableWithNoAdder struct {
 	*BaseTable
 }
@@ -319,11 +319,54 @@ func TestDB_FetchTableWithParam(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "5",
+			d:    testMustDB("mock", testJSONFromString("{}")),
+			args: args{
+				ctx: context.TODO(),
+				param: NewTableQueryParam(&mockTableWithColumnTypes{
+					mockTable: &mockTable{
+						BaseTable: NewBaseTable("db", "schema", "table"),
+					},
+				}),
+			},
+			want: &mockTable{
+				BaseTable: &BaseTable{
+					instance: "db",
+					schema:   "schema",
+					name:     "table",
+					fields: []Field{
+						newMockField(NewBaseField(0, "f1", newMockFieldType(GoTypeBool)), newMockFieldType(GoTypeBool)),
+						newMockField(NewBaseField(1, "f2", newMockFieldType(GoTypeInt64)), newMockFieldType(GoTypeInt64)),
+						newMockField(NewBaseField(2, "f3", newMockFieldType(GoTypeFloat64)), newMockFieldType(GoTypeFloat64)),
+						newMockField(NewBaseField(3, "f4", newMockFieldType(GoTypeString)), newMockFieldType(GoTypeString)),
+					},
+				},
+			},
+		},
+		{
+			name: "6",
+			d:    testMustDB("mock", testJSONFromString("{}")),
+			args: args{
+				ctx: context.TODO(),
+				param: NewTableQueryParam(&mockTableWithColumnTypes{
+					mockTable: &mockTable{
+						BaseTable: NewBaseTable("db", "schema", "table"),
+					},
+					err: errors.New("mock error"),
+				}),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			defer tt.d.Close()
 			got, err := tt.d.FetchTableWithParam(tt.args.ctx, tt.args.param)
+			if m, ok := tt.args.param.Table().(*mockTableWithColumnTypes); ok && !m.fetched {
+				t.Errorf("DB.FetchTableWithParam() does not fetch column types")
+				return
+			}
 			if (err != nil) != tt.wantErr {
 				t.Errorf("DB.FetchTableWithParam() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -516,6 +559,36 @@ func Test_execParam(t *testing.T) {
 				}, nil),
 			},
 		},
+		{
+			name: "6",
+			args: args{
+				opts: &ParameterOptions{
+					Table: &mockTable{
+						BaseTable: NewBaseTable("db", "schema", "table"),
+					},
+					Mode: WriteModeUpdate,
+				},
+			},
+			wantParam: NewUpdateParam(&mockTable{
+				BaseTable: NewBaseTable("db", "schema", "table"),
+			}, nil),
+		},
+		{
+			name: "7",
+			args: args{
+				opts: &ParameterOptions{
+					Table: &mockTableWithOther{
+						mockTable: &mockTable{
+							BaseTable: NewBaseTable("db", "schema", "table"),
+						},
+					},
+					Mode: WriteModeDelete,
+				},
+			},
+			wantParam: NewDeleteParam(&mockTable{
+				BaseTable: NewBaseTable("db", "schema", "table"),
+			}, nil),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -964,6 +1037,65 @@ func TestDB_BatchExecStmtWithTx(t *testing.T) {
 	}
 }
 
+func TestDB_FetchTables(t *testing.T) {
+	registerMock()
+	db := testCatalogDB()
+	defer db.Close()
+	mockDB := testMustDB("mock", testJSONFromString("{}"))
+	defer mockDB.Close()
+	tests := []struct {
+		name    string
+		d       *DB
+		t       *BaseTable
+		want    []*BaseTable
+		wantErr bool
+	}{
+		{
+			name: "1",
+			d:    db,
+			t:    NewBaseTable("db", "schema", "orders_%"),
+			want: []*BaseTable{
+				NewBaseTable("db", "schema", "orders_2021"),
+				NewBaseTable("db", "schema", "orders_2022"),
+			},
+		},
+		{
+			name: "2",
+			d:    db,
+			t:    NewBaseTable("", "schema", "*"),
+			want: []*BaseTable{
+				NewBaseTable("", "schema", "orders"),
+				NewBaseTable("", "schema", "orders_2021"),
+				NewBaseTable("", "schema", "orders_2022"),
+				NewBaseTable("", "schema", "users"),
+			},
+		},
+		{
+			name: "3",
+			d:    db,
+			t:    NewBaseTable("", "schema", "goods_%"),
+		},
+		{
+			name:    "4",
+			d:       mockDB,
+			t:       NewBaseTable("", "schema", "*"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.d.FetchTables(context.TODO(), tt.t)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DB.FetchTables() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DB.FetchTables() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDB_FetchRecord(t *testing.T) {
 	registerMock()
 	db := testMustDB("mock", testJSONFromString("{}"))
@@ -1213,3 +1345,36 @@ func TestDB_PingContext(t *testing.T) {
 		})
 	}
 }
+
+func Test_needFlushStmt(t *testing.T) {
+	tests := []struct {
+		name  string
+		param Parameter
+		want  bool
+	}{
+		{
+			name: "1",
+			param: &mockParameter{
+				BaseParam: NewBaseParam(nil, nil),
+			},
+			want: true,
+		},
+		{
+			name:  "2",
+			param: NewUpdateParam(nil, nil),
+			want:  false,
+		},
+		{
+			name:  "3",
+			param: NewDeleteParam(nil, nil),
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needFlushStmt(tt.param); got != tt.want {
+				t.Errorf("needFlushStmt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+)
+
+// ColumnKind 列类别，用于屏蔽各数据库方言的类型名差异，方便生成其他数据库的建表语句
+type ColumnKind uint8
+
+// 列类别枚举
+const (
+	ColumnKindUnknown   ColumnKind = iota //未知类别
+	ColumnKindBool                        //布尔
+	ColumnKindInt                         //32位及以下的整数
+	ColumnKindBigInt                      //64位整数
+	ColumnKindDecimal                     //定点数
+	ColumnKindFloat                       //浮点数
+	ColumnKindString                      //字符串
+	ColumnKindBytes                       //字节流
+	ColumnKindDate                        //日期
+	ColumnKindTime                        //时间
+	ColumnKindTimestamp                   //日期时间
+)
+
+// 列类别枚举字符串
+var columnKindMap = map[ColumnKind]string{
+	ColumnKindUnknown:   "unknown",
+	ColumnKindBool:      "bool",
+	ColumnKindInt:       "int",
+	ColumnKindBigInt:    "bigInt",
+	ColumnKindDecimal:   "decimal",
+	ColumnKindFloat:     "float",
+	ColumnKindString:    "string",
+	ColumnKindBytes:     "bytes",
+	ColumnKindDate:      "date",
+	ColumnKindTime:      "time",
+	ColumnKindTimestamp: "timestamp",
+}
+
+// String 列类别枚举字符串描述
+func (k ColumnKind) String() string {
+	if s, ok := columnKindMap[k]; ok {
+		return s
+	}
+	return "unknown"
+}
+
+// 各数据库方言的类型名对应的列类别，无符号整数按照能够容纳的类别处理
+var typeNameColumnKinds = map[string]ColumnKind{
+	"BOOL":    ColumnKindBool,
+	"BOOLEAN": ColumnKindBool,
+	"BIT":     ColumnKindBool,
+
+	"TINYINT":            ColumnKindInt,
+	"SMALLINT":           ColumnKindInt,
+	"MEDIUMINT":          ColumnKindInt,
+	"INT":                ColumnKindInt,
+	"INT2":               ColumnKindInt,
+	"INT4":               ColumnKindInt,
+	"YEAR":               ColumnKindInt,
+	"BINARY_INTEGER":     ColumnKindInt,
+	"UNSIGNED TINYINT":   ColumnKindInt,
+	"UNSIGNED SMALLINT":  ColumnKindInt,
+	"UNSIGNED MEDIUMINT": ColumnKindInt,
+	"UNSIGNED INT":       ColumnKindBigInt,
+	"BIGINT":             ColumnKindBigInt,
+	"INT8":               ColumnKindBigInt,
+	"INTEGER":            ColumnKindBigInt,
+	"UNSIGNED BIGINT":    ColumnKindDecimal,
+
+	"DECIMAL":    ColumnKindDecimal,
+	"NUMERIC":    ColumnKindDecimal,
+	"NUMBER":     ColumnKindDecimal,
+	"MONEY":      ColumnKindDecimal,
+	"SMALLMONEY": ColumnKindDecimal,
+
+	"FLOAT":         ColumnKindFloat,
+	"DOUBLE":        ColumnKindFloat,
+	"REAL":          ColumnKindFloat,
+	"FLOAT4":        ColumnKindFloat,
+	"FLOAT8":        ColumnKindFloat,
+	"BINARY_FLOAT":  ColumnKindFloat,
+	"BINARY_DOUBLE": ColumnKindFloat,
+
+	"CHAR":             ColumnKindString,
+	"NCHAR":            ColumnKindString,
+	"BPCHAR":           ColumnKindString,
+	"VARCHAR":          ColumnKindString,
+	"NVARCHAR":         ColumnKindString,
+	"VARCHAR2":         ColumnKindString,
+	"NVARCHAR2":        ColumnKindString,
+	"TEXT":             ColumnKindString,
+	"NTEXT":            ColumnKindString,
+	"TINYTEXT":         ColumnKindString,
+	"MEDIUMTEXT":       ColumnKindString,
+	"LONGTEXT":         ColumnKindString,
+	"CLOB":             ColumnKindString,
+	"NCLOB":            ColumnKindString,
+	"LONG":             ColumnKindString,
+	"ENUM":             ColumnKindString,
+	"SET":              ColumnKindString,
+	"JSON":             ColumnKindString,
+	"UUID":             ColumnKindString,
+	"UNIQUEIDENTIFIER": ColumnKindString,
+
+	"BINARY":     ColumnKindBytes,
+	"VARBINARY":  ColumnKindBytes,
+	"BLOB":       ColumnKindBytes,
+	"TINYBLOB":   ColumnKindBytes,
+	"MEDIUMBLOB": ColumnKindBytes,
+	"LONGBLOB":   ColumnKindBytes,
+	"BYTEA":      ColumnKindBytes,
+	"RAW":        ColumnKindBytes,
+	"IMAGE":      ColumnKindBytes,
+
+	"DATE": ColumnKindDate,
+
+	"TIME":   ColumnKindTime,
+	"TIMETZ": ColumnKindTime,
+
+	"DATETIME":                       ColumnKindTimestamp,
+	"DATETIME2":                      ColumnKindTimestamp,
+	"SMALLDATETIME":                  ColumnKindTimestamp,
+	"DATETIMEOFFSET":                 ColumnKindTimestamp,
+	"TIMESTAMP":                      ColumnKindTimestamp,
+	"TIMESTAMPTZ":                    ColumnKindTimestamp,
+	"TIMESTAMP WITH TIME ZONE":       ColumnKindTimestamp,
+	"TIMESTAMP WITH LOCAL TIME ZONE": ColumnKindTimestamp,
+}
+
+// FieldColumnKind 根据字段类型typ的数据库类型名获取列类别，
+// 类型名中的长度以及精度等修饰会被忽略，如VARCHAR(255)视为VARCHAR
+func FieldColumnKind(typ FieldType) ColumnKind {
+	name := strings.ToUpper(strings.TrimSpace(typ.DatabaseTypeName()))
+	if i := strings.Index(name, "("); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+	return typeNameColumnKinds[name]
+}
+
+// DDLTypeFunc 根据源字段类型typ生成目标数据库建表语句中的列类型
+type DDLTypeFunc func(typ FieldType) string
+
+// DDLTypeMap 数据库方言的类型映射表，将列类别映射为建表语句中的列类型
+type DDLTypeMap map[ColumnKind]DDLTypeFunc
+
+// DDLType 固定的列类型name
+func DDLType(name string) DDLTypeFunc {
+	return func(FieldType) string {
+		return name
+	}
+}
+
+// DDLLengthType 源字段类型有长度且不超过max时为name(length)，否则为列类型other
+func DDLLengthType(name string, max int64, other string) DDLTypeFunc {
+	return func(typ FieldType) string {
+		if length, ok := typ.Length(); ok && length > 0 && length <= max {
+			return fmt.Sprintf("%s(%d)", name, length)
+		}
+		return other
+	}
+}
+
+// DDLDecimalType 源字段类型有精度且精度不超过max时为name(precision,scale)，否则为列类型other
+func DDLDecimalType(name string, max int64, other string) DDLTypeFunc {
+	return func(typ FieldType) string {
+		if precision, scale, ok := typ.DecimalSize(); ok && precision > 0 && precision <= max && scale >= 0 && scale <= precision {
+			return fmt.Sprintf("%s(%d,%d)", name, precision, scale)
+		}
+		return other
+	}
+}
+
+// TableColumn 建表时的列
+type TableColumn struct {
+	Name string     //列名
+	Type FieldType  //源字段类型
+	Kind ColumnKind //列类别，未知时根据源字段类型获取
+}
+
+// TableCreator Table的补充方法，用于根据列生成建表语句
+type TableCreator interface {
+	CreateTableQuery(columns []TableColumn, m *TypeMapping) (string, error) //根据列columns和类型映射m生成建表语句
+	IsTableNotExist(err error) bool                                         //获取表结构的错误err是否由表不存在导致
+}
+
+// CreateTableQuery 根据引用的表名quotedTable，列columns，引用列名函数quoted以及类型映射m生成建表语句，
+// 源字段类型明确不为空时列会带有not null
+func CreateTableQuery(quotedTable string, columns []TableColumn, quoted func(string) string, m *TypeMapping) (string, error) {
+	if len(columns) == 0 {
+		return "", errors.NewNoStackError("columns is empty")
+	}
+	buf := bytes.NewBufferString("create table ")
+	buf.WriteString(quotedTable)
+	buf.WriteString("(")
+	for i, c := range columns {
+		kind := c.Kind
+		if kind == ColumnKindUnknown {
+			kind = m.FieldKind(c.Name, c.Type)
+		}
+		fn, ok := m.DDLTypes[kind]
+		if !ok {
+			return "", errors.Errorf("column %v type %v(%v) is not supported", c.Name, c.Type.DatabaseTypeName(), kind)
+		}
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(quoted(c.Name))
+		buf.WriteString(" ")
+		buf.WriteString(fn(c.Type))
+		if nullable, ok := c.Type.Nullable(); ok && !nullable {
+			buf.WriteString(" not null")
+		}
+	}
+	buf.WriteString(")")
+	return buf.String(), nil
+}
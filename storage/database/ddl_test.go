@@ -0,0 +1,231 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"reflect"
+	"testing"
+)
+
+type mockDDLFieldType struct {
+	*BaseFieldType
+
+	name      string
+	length    int64
+	precision int64
+	scale     int64
+	nullable  int //0表示未知，1表示可以为空，2表示不能为空
+}
+
+func (m *mockDDLFieldType) DatabaseTypeName() string {
+	return m.name
+}
+
+func (m *mockDDLFieldType) Length() (int64, bool) {
+	return m.length, m.length != 0
+}
+
+func (m *mockDDLFieldType) DecimalSize() (int64, int64, bool) {
+	return m.precision, m.scale, m.precision != 0
+}
+
+func (m *mockDDLFieldType) Nullable() (bool, bool) {
+	return m.nullable == 1, m.nullable != 0
+}
+
+func TestColumnKind_String(t *testing.T) {
+	tests := []struct {
+		name string
+		k    ColumnKind
+		want string
+	}{
+		{
+			name: "1",
+			k:    ColumnKindDecimal,
+			want: "decimal",
+		},
+		{
+			name: "2",
+			k:    ColumnKind(255),
+			want: "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.k.String(); got != tt.want {
+				t.Errorf("ColumnKind.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldColumnKind(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  FieldType
+		want ColumnKind
+	}{
+		{
+			name: "1",
+			typ:  &mockDDLFieldType{name: "VARCHAR2"},
+			want: ColumnKindString,
+		},
+		{
+			name: "2",
+			typ:  &mockDDLFieldType{name: "varchar(255)"},
+			want: ColumnKindString,
+		},
+		{
+			name: "3",
+			typ:  &mockDDLFieldType{name: "UNSIGNED BIGINT"},
+			want: ColumnKindDecimal,
+		},
+		{
+			name: "4",
+			typ:  &mockDDLFieldType{name: "TIMESTAMP WITH TIME ZONE"},
+			want: ColumnKindTimestamp,
+		},
+		{
+			name: "5",
+			typ:  &mockDDLFieldType{name: "GEOMETRY"},
+			want: ColumnKindUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FieldColumnKind(tt.typ); got != tt.want {
+				t.Errorf("FieldColumnKind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDDLTypeFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   DDLTypeFunc
+		typ  FieldType
+		want string
+	}{
+		{
+			name: "1",
+			fn:   DDLType("bigint"),
+			typ:  &mockDDLFieldType{name: "INT8"},
+			want: "bigint",
+		},
+		{
+			name: "2",
+			fn:   DDLLengthType("varchar", 100, "text"),
+			typ:  &mockDDLFieldType{name: "VARCHAR", length: 50},
+			want: "varchar(50)",
+		},
+		{
+			name: "3",
+			fn:   DDLLengthType("varchar", 100, "text"),
+			typ:  &mockDDLFieldType{name: "VARCHAR", length: 200},
+			want: "text",
+		},
+		{
+			name: "4",
+			fn:   DDLLengthType("varchar", 100, "text"),
+			typ:  &mockDDLFieldType{name: "TEXT"},
+			want: "text",
+		},
+		{
+			name: "5",
+			fn:   DDLDecimalType("decimal", 38, "numeric"),
+			typ:  &mockDDLFieldType{name: "DECIMAL", precision: 10, scale: 2},
+			want: "decimal(10,2)",
+		},
+		{
+			name: "6",
+			fn:   DDLDecimalType("decimal", 38, "numeric"),
+			typ:  &mockDDLFieldType{name: "DECIMAL", precision: 65, scale: 2},
+			want: "numeric",
+		},
+		{
+			name: "7",
+			fn:   DDLDecimalType("decimal", 38, "numeric"),
+			typ:  &mockDDLFieldType{name: "NUMBER"},
+			want: "numeric",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.typ); got != tt.want {
+				t.Errorf("DDLTypeFunc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTableQuery(t *testing.T) {
+	quoted := func(s string) string {
+		return `"` + s + `"`
+	}
+	m := &TypeMapping{
+		DDLTypes: DDLTypeMap{
+			ColumnKindBigInt: DDLType("bigint"),
+			ColumnKindString: DDLLengthType("varchar", 100, "text"),
+		},
+	}
+	tests := []struct {
+		name    string
+		columns []TableColumn
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			columns: []TableColumn{
+				{Name: "id", Type: &mockDDLFieldType{name: "BIGINT", nullable: 2}},
+				{Name: "name", Type: &mockDDLFieldType{name: "VARCHAR", length: 20, nullable: 1}},
+				{Name: "remark", Type: &mockDDLFieldType{name: "TEXT"}},
+			},
+			want: `create table "s"."t"("id" bigint not null,"name" varchar(20),"remark" text)`,
+		},
+		{
+			name:    "2",
+			wantErr: true,
+		},
+		{
+			name: "4",
+			columns: []TableColumn{
+				{Name: "id", Type: &mockDDLFieldType{name: "NUMBER"}, Kind: ColumnKindBigInt},
+			},
+			want: `create table "s"."t"("id" bigint)`,
+		},
+		{
+			name: "3",
+			columns: []TableColumn{
+				{Name: "id", Type: &mockDDLFieldType{name: "BIGINT"}},
+				{Name: "c", Type: &mockDDLFieldType{name: "DATE"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CreateTableQuery(`"s"."t"`, tt.columns, quoted, m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateTableQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CreateTableQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
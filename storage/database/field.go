@@ -111,6 +111,29 @@ type ValuerGoType interface {
 	GoType() GoType
 }
 
+// goTypeColumnTypeMap golang的类型对应的列类型
+var goTypeColumnTypeMap = map[GoType]element.ColumnType{
+	GoTypeBool:    element.TypeBool,
+	GoTypeInt64:   element.TypeBigInt,
+	GoTypeFloat64: element.TypeDecimal,
+	GoTypeString:  element.TypeString,
+	GoTypeBytes:   element.TypeBytes,
+	GoTypeTime:    element.TypeTime,
+}
+
+// FieldColumnType 根据字段f的golang类型获取对应的列类型，
+// 字段类型不是ValuerGoType或者golang类型未知时返回未知类型
+func FieldColumnType(f Field) element.ColumnType {
+	typ, ok := f.Type().(ValuerGoType)
+	if !ok {
+		return element.TypeUnknown
+	}
+	if c, ok := goTypeColumnTypeMap[typ.GoType()]; ok {
+		return c
+	}
+	return element.TypeUnknown
+}
+
 // BaseField 基础字段，主要存储列名name和列类型fieldType
 type BaseField struct {
 	index     int
@@ -306,3 +306,62 @@ func TestBaseFieldType_IsSupportted(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldColumnType(t *testing.T) {
+	newField := func(typ FieldType) Field {
+		return newMockField(NewBaseField(1, "f1", NewBaseFieldType(&sql.ColumnType{})), typ)
+	}
+	tests := []struct {
+		name string
+		f    Field
+		want element.ColumnType
+	}{
+		{
+			name: "1",
+			f:    newField(newMockFieldType(GoTypeBool)),
+			want: element.TypeBool,
+		},
+		{
+			name: "2",
+			f:    newField(newMockFieldType(GoTypeInt64)),
+			want: element.TypeBigInt,
+		},
+		{
+			name: "3",
+			f:    newField(newMockFieldType(GoTypeFloat64)),
+			want: element.TypeDecimal,
+		},
+		{
+			name: "4",
+			f:    newField(newMockFieldType(GoTypeString)),
+			want: element.TypeString,
+		},
+		{
+			name: "5",
+			f:    newField(newMockFieldType(GoTypeBytes)),
+			want: element.TypeBytes,
+		},
+		{
+			name: "6",
+			f:    newField(newMockFieldType(GoTypeTime)),
+			want: element.TypeTime,
+		},
+		{
+			name: "7",
+			f:    newField(newMockFieldType(GoTypeUnknown)),
+			want: element.TypeUnknown,
+		},
+		{
+			name: "8",
+			f:    newField(NewBaseFieldType(&sql.ColumnType{})),
+			want: element.TypeUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FieldColumnType(tt.f); got != tt.want {
+				t.Errorf("FieldColumnType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+)
+
+// typeMapping mysql的类型映射，其中BIT为字节流，TINYINT(1)为布尔
+var typeMapping = &database.TypeMapping{
+	Kinds: map[string]database.ColumnKind{
+		"BIT":        database.ColumnKindBytes,
+		"TINYINT(1)": database.ColumnKindBool,
+	},
+	DDLTypes: database.DDLTypeMap{
+		database.ColumnKindBool:      database.DDLType("tinyint(1)"),
+		database.ColumnKindInt:       database.DDLType("int"),
+		database.ColumnKindBigInt:    database.DDLType("bigint"),
+		database.ColumnKindDecimal:   database.DDLDecimalType("decimal", 65, "decimal(65,30)"),
+		database.ColumnKindFloat:     database.DDLType("double"),
+		database.ColumnKindString:    database.DDLLengthType("varchar", 4000, "longtext"),
+		database.ColumnKindBytes:     database.DDLType("longblob"),
+		database.ColumnKindDate:      database.DDLType("date"),
+		database.ColumnKindTime:      database.DDLType("time"),
+		database.ColumnKindTimestamp: database.DDLType("datetime(6)"),
+	},
+}
+
+// CreateTableQuery 根据列columns和类型映射m生成建表语句
+func (t *Table) CreateTableQuery(columns []database.TableColumn, m *database.TypeMapping) (string, error) {
+	return database.CreateTableQuery(t.Quoted(), columns, Quoted, m)
+}
+
+// IsTableNotExist 获取表结构的错误err是否由表不存在导致
+func (t *Table) IsTableNotExist(err error) bool {
+	cause, ok := errors.Cause(err).(*mysql.MySQLError)
+	//1146: ER_NO_SUCH_TABLE
+	return ok && cause.Number == 1146
+}
@@ -0,0 +1,162 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mysql
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
+)
+
+type mockDDLFieldType struct {
+	database.ColumnType
+
+	length    int64
+	precision int64
+	scale     int64
+	notNull   bool
+}
+
+func (m *mockDDLFieldType) IsSupportted() bool {
+	return true
+}
+
+func (m *mockDDLFieldType) Length() (int64, bool) {
+	return m.length, m.length != 0
+}
+
+func (m *mockDDLFieldType) DecimalSize() (int64, int64, bool) {
+	return m.precision, m.scale, m.precision != 0
+}
+
+func (m *mockDDLFieldType) Nullable() (bool, bool) {
+	return !m.notNull, m.notNull
+}
+
+func TestTable_CreateTableQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		t       *Table
+		columns []database.TableColumn
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			t:    NewTable(database.NewBaseTable("db", "", "t")),
+			columns: []database.TableColumn{
+				{Name: "id", Type: &mockDDLFieldType{ColumnType: newMockFieldType("BIGINT"), notNull: true}},
+				{Name: "name", Type: &mockDDLFieldType{ColumnType: newMockFieldType("VARCHAR"), length: 20}},
+				{Name: "amount", Type: &mockDDLFieldType{ColumnType: newMockFieldType("DECIMAL"), precision: 10, scale: 2}},
+				{Name: "remark", Type: &mockDDLFieldType{ColumnType: newMockFieldType("TEXT")}},
+			},
+			want: "create table `db`.`t`(`id` bigint not null,`name` varchar(20),`amount` decimal(10,2),`remark` longtext)",
+		},
+		{
+			name: "2",
+			t:    NewTable(database.NewBaseTable("db", "", "t")),
+			columns: []database.TableColumn{
+				{Name: "shape", Type: &mockDDLFieldType{ColumnType: newMockFieldType("GEOMETRY")}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.t.CreateTableQuery(tt.columns, typeMapping)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Table.CreateTableQuery() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Table.CreateTableQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_IsTableNotExist(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "1",
+			err:  errors.Wrapf(&mysql.MySQLError{Number: 1146}, "mock"),
+			want: true,
+		},
+		{
+			name: "2",
+			err:  &mysql.MySQLError{Number: 1045},
+			want: false,
+		},
+		{
+			name: "3",
+			err:  errors.New("mock error"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(database.NewBaseTable("db", "schema", "table"))
+			if got := table.IsTableNotExist(tt.err); got != tt.want {
+				t.Errorf("Table.IsTableNotExist() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeMapping_FieldKind(t *testing.T) {
+	table := NewTable(database.NewBaseTable("db", "", "t"))
+	table.AddField(database.NewBaseField(0, "flag", newMockFieldType("TINYINT")))
+	table.AddField(database.NewBaseField(1, "level", newMockFieldType("TINYINT")))
+	table.AddField(database.NewBaseField(2, "data", newMockFieldType("BIT")))
+	table.AddField(database.NewBaseField(3, "other", newMockFieldType("TINYINT")))
+	table.setColumnTypes(map[string]string{
+		"flag":  "tinyint(1)",
+		"level": "tinyint(4) unsigned",
+		"data":  "bit(1)",
+	})
+	want := map[string]database.ColumnKind{
+		"flag":  database.ColumnKindBool,
+		"level": database.ColumnKindInt,
+		"data":  database.ColumnKindBytes,
+		"other": database.ColumnKindInt,
+	}
+	for _, f := range table.Fields() {
+		if got := typeMapping.FieldKind(f.Name(), f.Type()); got != want[f.Name()] {
+			t.Errorf("FieldKind(%v) = %v, want %v", f.Name(), got, want[f.Name()])
+		}
+	}
+
+	m, err := typeMapping.Override(&database.TypeMappingConfig{
+		Types: map[string]string{
+			"tinyint(4)": "bool",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.FieldKind("level", table.Fields()[1].Type()); got != database.ColumnKindBool {
+		t.Errorf("FieldKind(level) = %v, want %v", got, database.ColumnKindBool)
+	}
+	if got := m.FieldKind("other", table.Fields()[3].Type()); got != database.ColumnKindInt {
+		t.Errorf("FieldKind(other) = %v, want %v", got, database.ColumnKindInt)
+	}
+}
@@ -16,6 +16,8 @@ package mysql
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Breeze0806/go-etl/element"
@@ -32,6 +34,8 @@ var (
 type Field struct {
 	*database.BaseField
 	database.BaseConfigSetter
+
+	columnType string
 }
 
 // NewField 通过基本列属性生成字段
@@ -58,7 +62,9 @@ func (f *Field) Select() string {
 
 // Type 字段类型
 func (f *Field) Type() database.FieldType {
-	return NewFieldType(f.FieldType())
+	typ := NewFieldType(f.FieldType())
+	typ.columnType = f.columnType
+	return typ
 }
 
 // Scanner 扫描器，用于读取数据
@@ -75,7 +81,8 @@ func (f *Field) Valuer(c element.Column) database.Valuer {
 type FieldType struct {
 	*database.BaseFieldType
 
-	goType database.GoType
+	goType     database.GoType
+	columnType string //表结构中列声明的类型，如tinyint(1) unsigned
 }
 
 // NewFieldType 创建新的字段类型
@@ -112,6 +119,29 @@ func (f *FieldType) GoType() database.GoType {
 	return f.goType
 }
 
+// Length 长度，驱动无法获取时使用列声明的类型中的长度，
+// 如tinyint(1)的长度为1，用于按照带有长度的类型名进行类型映射
+func (f *FieldType) Length() (length int64, ok bool) {
+	if length, ok = f.BaseFieldType.Length(); ok {
+		return
+	}
+	return columnTypeLength(f.columnType)
+}
+
+// columnTypeLength 获取列声明的类型typ中括号内的长度，如tinyint(1) unsigned的长度为1
+func columnTypeLength(typ string) (length int64, ok bool) {
+	i := strings.Index(typ, "(")
+	j := strings.Index(typ, ")")
+	if i < 0 || j < i {
+		return
+	}
+	var err error
+	if length, err = strconv.ParseInt(strings.TrimSpace(typ[i+1:j]), 10, 64); err != nil {
+		return 0, false
+	}
+	return length, true
+}
+
 // Scanner 扫描器
 type Scanner struct {
 	f *Field
@@ -778,3 +778,50 @@ func TestFieldType_IsSupportted(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldType_Length(t *testing.T) {
+	tests := []struct {
+		name       string
+		columnType string
+		wantLength int64
+		wantOk     bool
+	}{
+		{
+			name: "1",
+		},
+		{
+			name:       "2",
+			columnType: "tinyint(1)",
+			wantLength: 1,
+			wantOk:     true,
+		},
+		{
+			name:       "3",
+			columnType: "tinyint( 3 ) unsigned",
+			wantLength: 3,
+			wantOk:     true,
+		},
+		{
+			name:       "4",
+			columnType: "decimal(10,2)",
+		},
+		{
+			name:       "5",
+			columnType: "enum('a','b')",
+		},
+		{
+			name:       "6",
+			columnType: "int",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewField(database.NewBaseField(0, "f1", newMockFieldType("TINYINT")))
+			f.columnType = tt.columnType
+			gotLength, gotOk := f.Type().Length()
+			if gotLength != tt.wantLength || gotOk != tt.wantOk {
+				t.Errorf("FieldType.Length() = (%v, %v), want (%v, %v)", gotLength, gotOk, tt.wantLength, tt.wantOk)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 func init() {
 	var d Dialect
 	database.RegisterDialect(d.Name(), d)
+	database.RegisterTypeMapping(d.Name(), typeMapping)
 }
 
 // Dialect mysql数据库方言
@@ -89,6 +90,16 @@ func (s *Source) Connector() (driver.Connector, error) {
 	return mysql.NewConnector(s.mysqlConf)
 }
 
+// TablesQuery 获取查询表b所在库下所有表名的查询语句以及参数，库为空时使用当前库
+func (s *Source) TablesQuery(b *database.BaseTable) (string, []interface{}) {
+	if b.Instance() == "" {
+		return "select table_name from information_schema.tables " +
+			"where table_schema = database() and table_type = 'BASE TABLE'", nil
+	}
+	return "select table_name from information_schema.tables " +
+		"where table_schema = ? and table_type = 'BASE TABLE'", []interface{}{b.Instance()}
+}
+
 // Quoted mysql引用函数
 func Quoted(s string) string {
 	return "`" + s + "`"
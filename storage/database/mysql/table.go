@@ -16,6 +16,7 @@ package mysql
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
@@ -59,11 +60,52 @@ func (t *Table) AddField(baseField *database.BaseField) {
 	t.AppendField(f)
 }
 
-// ExecParam 获取执行参数，其中replace into的参数方式以及被注册
+// FetchColumnTypes 从information_schema.columns中获取列声明的类型，
+// 驱动获取的类型名中没有整数类型的长度，无法区分tinyint(1)和tinyint，表名为空时不获取
+func (t *Table) FetchColumnTypes(ctx context.Context, db *database.DB) (err error) {
+	if t.Name() == "" {
+		return nil
+	}
+	var rows *sql.Rows
+	if rows, err = db.QueryContext(ctx, columnTypesQuery, t.Instance(), t.Name()); err != nil {
+		return errors.Wrapf(err, "QueryContext(%v) fail", columnTypesQuery)
+	}
+	defer rows.Close()
+	types := make(map[string]string)
+	for rows.Next() {
+		var name, typ string
+		if err = rows.Scan(&name, &typ); err != nil {
+			return errors.Wrapf(err, "Scan fail")
+		}
+		types[name] = typ
+	}
+	if err = rows.Err(); err != nil {
+		return errors.Wrapf(err, "rows.Err fail")
+	}
+	t.setColumnTypes(types)
+	return nil
+}
+
+// columnTypesQuery 获取列声明的类型的查询语句，库名为空时使用当前库
+const columnTypesQuery = "select COLUMN_NAME, COLUMN_TYPE from information_schema.columns " +
+	"where TABLE_SCHEMA = coalesce(nullif(?, ''), database()) and TABLE_NAME = ?"
+
+// setColumnTypes 通过列名对应的列声明的类型types设置各列声明的类型
+func (t *Table) setColumnTypes(types map[string]string) {
+	for _, v := range t.Fields() {
+		if f, ok := v.(*Field); ok {
+			f.columnType = types[f.Name()]
+		}
+	}
+}
+
+// ExecParam 获取执行参数，其中replace into和upsert的参数方式以及被注册
 func (t *Table) ExecParam(mode string, txOpts *sql.TxOptions) (database.Parameter, bool) {
 	switch mode {
 	case "replace":
 		return NewReplaceParam(t, txOpts), true
+	case database.WriteModeUpsert:
+		return NewUpsertParam(t, txOpts), true
 	}
 	return nil, false
 }
@@ -84,6 +126,17 @@ func (t *Table) ShouldOneByOne(err error) bool {
 	return ok
 }
 
+// ShouldShrinkBatch 批量过大，超过max_allowed_packet或者占位符个数上限时缩小批量
+func (t *Table) ShouldShrinkBatch(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case *mysql.MySQLError:
+		//1153: ER_NET_PACKET_TOO_LARGE, 1390: ER_PS_MANY_PARAM
+		return cause.Number == 1153 || cause.Number == 1390
+	default:
+		return cause == mysql.ErrPktTooLarge
+	}
+}
+
 // ReplaceParam Replace into 参数
 type ReplaceParam struct {
 	*database.BaseParam
@@ -144,3 +197,40 @@ func (rp *ReplaceParam) Agrs(records []element.Record) (valuers []interface{}, e
 	}
 	return
 }
+
+// UpsertParam insert into ... on duplicate key update 参数
+type UpsertParam struct {
+	*database.InsertParam
+}
+
+// NewUpsertParam 通过表table和事务参数txOpts生成upsert参数
+func NewUpsertParam(t database.Table, txOpts *sql.TxOptions) *UpsertParam {
+	return &UpsertParam{
+		InsertParam: database.NewInsertParam(t, txOpts),
+	}
+}
+
+// Query 通过多条记录 records生成批量insert into ... on duplicate key update语句，
+// 键列冲突时更新非键列，没有非键列时用键列本身更新
+func (up *UpsertParam) Query(records []element.Record) (query string, err error) {
+	var keys, others []int
+	if keys, others, err = database.KeyFieldIndexes(up.Table()); err != nil {
+		return
+	}
+	if len(others) == 0 {
+		others = keys
+	}
+	if query, err = up.InsertParam.Query(records); err != nil {
+		return
+	}
+	buf := bytes.NewBufferString(query)
+	buf.WriteString(" on duplicate key update ")
+	for i, fi := range others {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		f := up.Table().Fields()[fi]
+		buf.WriteString(f.Quoted() + "=values(" + f.Quoted() + ")")
+	}
+	return buf.String(), nil
+}
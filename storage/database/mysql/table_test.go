@@ -24,6 +24,7 @@ import (
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
 	"github.com/go-sql-driver/mysql"
+	"github.com/pingcap/errors"
 )
 
 func TestTable_Quoted(t *testing.T) {
@@ -484,3 +485,126 @@ func TestTable_ShouldOneByOne(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_ShouldShrinkBatch(t *testing.T) {
+	type args struct {
+		err error
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want bool
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: nil,
+			},
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: driver.ErrBadConn,
+			},
+		},
+		{
+			name: "3",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: &mysql.MySQLError{Number: 1062},
+			},
+		},
+		{
+			name: "4",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: &mysql.MySQLError{Number: 1153},
+			},
+			want: true,
+		},
+		{
+			name: "5",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: &mysql.MySQLError{Number: 1390},
+			},
+			want: true,
+		},
+		{
+			name: "6",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: errors.Wrapf(mysql.ErrPktTooLarge, "mock"),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.ShouldShrinkBatch(tt.args.err); got != tt.want {
+				t.Errorf("Table.ShouldShrinkBatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertParam_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyColumn string
+		wantQuery string
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			keyColumn: `["f1"]`,
+			wantQuery: "insert into `db`.`table`(`f1`,`f2`,`f3`) values(?,?,?),(?,?,?) on duplicate key update `f2`=values(`f2`),`f3`=values(`f3`)",
+		},
+		{
+			name:      "2",
+			keyColumn: `["F1","f2","f3","f1"]`,
+			wantQuery: "insert into `db`.`table`(`f1`,`f2`,`f3`) values(?,?,?),(?,?,?) on duplicate key update `f1`=values(`f1`),`f2`=values(`f2`),`f3`=values(`f3`)",
+		},
+		{
+			name:      "3",
+			keyColumn: `[]`,
+			wantErr:   true,
+		},
+		{
+			name:      "4",
+			keyColumn: `["f4"]`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(database.NewBaseTable("db", "schema", "table"))
+			table.SetConfig(testJSONFromString(`{"keyColumn":` + tt.keyColumn + `}`))
+			table.AppendField(NewField(database.NewBaseField(0, "f1", newMockFieldType("BIGINT"))))
+			table.AppendField(NewField(database.NewBaseField(1, "f2", newMockFieldType("BIGINT"))))
+			table.AppendField(NewField(database.NewBaseField(2, "f3", newMockFieldType("BIGINT"))))
+
+			param, ok := table.ExecParam(database.WriteModeUpsert, nil)
+			if !ok {
+				t.Fatalf("Table.ExecParam() ok = %v", ok)
+			}
+			if _, ok = param.(*UpsertParam); !ok {
+				t.Fatalf("Table.ExecParam() = %T", param)
+			}
+			gotQuery, err := param.Query([]element.Record{
+				element.NewDefaultRecord(),
+				element.NewDefaultRecord(),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpsertParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("UpsertParam.Query() = %v, want %v", gotQuery, tt.wantQuery)
+			}
+		})
+	}
+}
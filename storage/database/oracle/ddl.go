@@ -0,0 +1,66 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oracle
+
+import (
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/godror/godror"
+	"github.com/pingcap/errors"
+)
+
+// typeMapping oracle的类型映射，其中DATE带有时间，标度为0的NUMBER按照精度映射为整数
+var typeMapping = &database.TypeMapping{
+	Kinds: numberKinds(map[string]database.ColumnKind{
+		"DATE": database.ColumnKindTimestamp,
+	}),
+	DDLTypes: database.DDLTypeMap{
+		database.ColumnKindBool:      database.DDLType("NUMBER(1)"),
+		database.ColumnKindInt:       database.DDLType("NUMBER(10)"),
+		database.ColumnKindBigInt:    database.DDLType("NUMBER(19)"),
+		database.ColumnKindDecimal:   database.DDLDecimalType("NUMBER", 38, "NUMBER"),
+		database.ColumnKindFloat:     database.DDLType("BINARY_DOUBLE"),
+		database.ColumnKindString:    database.DDLLengthType("VARCHAR2", 4000, "CLOB"),
+		database.ColumnKindBytes:     database.DDLType("BLOB"),
+		database.ColumnKindDate:      database.DDLType("DATE"),
+		database.ColumnKindTime:      database.DDLType("VARCHAR2(32)"),
+		database.ColumnKindTimestamp: database.DDLType("TIMESTAMP"),
+	},
+}
+
+// CreateTableQuery 根据列columns和类型映射m生成建表语句
+func (t *Table) CreateTableQuery(columns []database.TableColumn, m *database.TypeMapping) (string, error) {
+	return database.CreateTableQuery(t.Quoted(), columns, Quoted, m)
+}
+
+// numberKinds 在类型映射kinds中加入标度为0的NUMBER，精度不超过9时为32位整数，不超过18时为64位整数
+func numberKinds(kinds map[string]database.ColumnKind) map[string]database.ColumnKind {
+	for p := 1; p <= 18; p++ {
+		kind := database.ColumnKindBigInt
+		if p <= 9 {
+			kind = database.ColumnKindInt
+		}
+		kinds[fmt.Sprintf("NUMBER(%d,0)", p)] = kind
+	}
+	return kinds
+}
+
+// IsTableNotExist 获取表结构的错误err是否由表不存在导致
+func (t *Table) IsTableNotExist(err error) bool {
+	cause, ok := errors.Cause(err).(*godror.OraErr)
+	//ORA-00942: 表或视图不存在
+	return ok && cause.Code() == 942
+}
@@ -22,6 +22,7 @@ import (
 func init() {
 	var d Dialect
 	database.RegisterDialect(d.Name(), d)
+	database.RegisterTypeMapping(d.Name(), typeMapping)
 }
 
 // Dialect oracle数据库方言
@@ -81,6 +82,14 @@ func (s *Source) Table(b *database.BaseTable) database.Table {
 	return NewTable(b)
 }
 
+// TablesQuery 获取查询表b所在模式下所有表名的查询语句以及参数，模式为空时使用当前用户
+func (s *Source) TablesQuery(b *database.BaseTable) (string, []interface{}) {
+	if b.Schema() == "" {
+		return "select table_name from user_tables", nil
+	}
+	return "select table_name from all_tables where owner = :1", []interface{}{b.Schema()}
+}
+
 // Quoted db2引用函数
 func Quoted(s string) string {
 	return `"` + s + `"`
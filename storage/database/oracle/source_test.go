@@ -275,3 +275,36 @@ func TestSource_Table(t *testing.T) {
 		})
 	}
 }
+
+func TestSource_TablesQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		b         *database.BaseTable
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "1",
+			b:         database.NewBaseTable("", "schema", "*"),
+			wantQuery: "select table_name from all_tables where owner = :1",
+			wantArgs:  []interface{}{"schema"},
+		},
+		{
+			name:      "2",
+			b:         database.NewBaseTable("", "", "*"),
+			wantQuery: "select table_name from user_tables",
+			wantArgs:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs := (&Source{}).TablesQuery(tt.b)
+			if gotQuery != tt.wantQuery {
+				t.Errorf("Source.TablesQuery() query = %v, want %v", gotQuery, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("Source.TablesQuery() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -58,11 +58,13 @@ func (t *Table) AddField(baseField *database.BaseField) {
 	t.AppendField(f)
 }
 
-// ExecParam 获取执行参数，其中replace into的参数方式以及被注册
+// ExecParam 获取执行参数，其中insert和upsert的参数方式以及被注册
 func (t *Table) ExecParam(mode string, txOpts *sql.TxOptions) (database.Parameter, bool) {
 	switch mode {
 	case WriteModeInsert:
 		return NewInsertParam(t, txOpts), true
+	case database.WriteModeUpsert:
+		return NewMergeParam(t, txOpts), true
 	}
 	return nil, false
 }
@@ -150,3 +152,35 @@ func (ip *InsertParam) Agrs(records []element.Record) (valuers []interface{}, er
 	}
 	return
 }
+
+// MergeParam merge into 参数，和insert into一样采用数组绑定批量写入
+type MergeParam struct {
+	*InsertParam
+}
+
+// NewMergeParam 通过表table和事务参数txOpts生成merge参数
+func NewMergeParam(t database.Table, txOpts *sql.TxOptions) *MergeParam {
+	return &MergeParam{
+		InsertParam: NewInsertParam(t, txOpts),
+	}
+}
+
+// Query 生成merge into语句，键列匹配时更新非键列，否则插入
+func (mp *MergeParam) Query(_ []element.Record) (query string, err error) {
+	var keys, others []int
+	if keys, others, err = database.KeyFieldIndexes(mp.Table()); err != nil {
+		return
+	}
+	buf := bytes.NewBufferString("merge into ")
+	buf.WriteString(mp.Table().Quoted())
+	buf.WriteString(" t using (select ")
+	for fi, f := range mp.Table().Fields() {
+		if fi > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(f.BindVar(fi+1) + " " + f.Quoted())
+	}
+	buf.WriteString(" from dual) s")
+	database.WriteMergeClause(buf, mp.Table(), keys, others)
+	return buf.String(), nil
+}
@@ -476,3 +476,63 @@ func TestTable_ShouldOneByOne(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeParam_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyColumn string
+		wantQuery string
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			keyColumn: `["f1"]`,
+			wantQuery: `merge into "schema"."table" t using (select :1 "f1",:2 "f2",:3 "f3" from dual) s on (t."f1"=s."f1")` +
+				` when matched then update set t."f2"=s."f2",t."f3"=s."f3" when not matched then insert("f1","f2","f3") values(s."f1",s."f2",s."f3")`,
+		},
+		{
+			name:      "2",
+			keyColumn: `["F1","f2","f3","f1"]`,
+			wantQuery: `merge into "schema"."table" t using (select :1 "f1",:2 "f2",:3 "f3" from dual) s on (t."f1"=s."f1" and t."f2"=s."f2" and t."f3"=s."f3")` +
+				` when not matched then insert("f1","f2","f3") values(s."f1",s."f2",s."f3")`,
+		},
+		{
+			name:      "3",
+			keyColumn: `[]`,
+			wantErr:   true,
+		},
+		{
+			name:      "4",
+			keyColumn: `["f4"]`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(database.NewBaseTable("db", "schema", "table"))
+			table.SetConfig(testJSONFromString(`{"keyColumn":` + tt.keyColumn + `}`))
+			table.AppendField(NewField(database.NewBaseField(0, "f1", newMockColumnType("VARCHAR2"))))
+			table.AppendField(NewField(database.NewBaseField(1, "f2", newMockColumnType("VARCHAR2"))))
+			table.AppendField(NewField(database.NewBaseField(2, "f3", newMockColumnType("VARCHAR2"))))
+
+			param, ok := table.ExecParam(database.WriteModeUpsert, nil)
+			if !ok {
+				t.Fatalf("Table.ExecParam() ok = %v", ok)
+			}
+			if _, ok = param.(*MergeParam); !ok {
+				t.Fatalf("Table.ExecParam() = %T", param)
+			}
+			gotQuery, err := param.Query([]element.Record{
+				element.NewDefaultRecord(),
+				element.NewDefaultRecord(),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MergeParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("MergeParam.Query() = %v, want %v", gotQuery, tt.wantQuery)
+			}
+		})
+	}
+}
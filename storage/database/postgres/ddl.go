@@ -0,0 +1,54 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/lib/pq"
+	"github.com/pingcap/errors"
+)
+
+// typeMapping postgres的类型映射
+var typeMapping = &database.TypeMapping{
+	Kinds: map[string]database.ColumnKind{
+		"JSONB":    database.ColumnKindString,
+		"XML":      database.ColumnKindString,
+		"INTERVAL": database.ColumnKindString,
+	},
+	DDLTypes: database.DDLTypeMap{
+		database.ColumnKindBool:      database.DDLType("boolean"),
+		database.ColumnKindInt:       database.DDLType("integer"),
+		database.ColumnKindBigInt:    database.DDLType("bigint"),
+		database.ColumnKindDecimal:   database.DDLDecimalType("numeric", 1000, "numeric"),
+		database.ColumnKindFloat:     database.DDLType("double precision"),
+		database.ColumnKindString:    database.DDLLengthType("varchar", 10485760, "text"),
+		database.ColumnKindBytes:     database.DDLType("bytea"),
+		database.ColumnKindDate:      database.DDLType("date"),
+		database.ColumnKindTime:      database.DDLType("time"),
+		database.ColumnKindTimestamp: database.DDLType("timestamp"),
+	},
+}
+
+// CreateTableQuery 根据列columns和类型映射m生成建表语句
+func (t *Table) CreateTableQuery(columns []database.TableColumn, m *database.TypeMapping) (string, error) {
+	return database.CreateTableQuery(t.Quoted(), columns, Quoted, m)
+}
+
+// IsTableNotExist 获取表结构的错误err是否由表不存在导致
+func (t *Table) IsTableNotExist(err error) bool {
+	cause, ok := errors.Cause(err).(*pq.Error)
+	//42P01: undefined_table
+	return ok && cause.Code == "42P01"
+}
@@ -25,6 +25,7 @@ import (
 func init() {
 	var d Dialect
 	database.RegisterDialect(d.Name(), d)
+	database.RegisterTypeMapping(d.Name(), typeMapping)
 }
 
 // Dialect postgres数据库方言
@@ -83,6 +84,16 @@ func (s *Source) Table(b *database.BaseTable) database.Table {
 	return NewTable(b)
 }
 
+// TablesQuery 获取查询表b所在模式下所有表名的查询语句以及参数，模式为空时使用当前模式
+func (s *Source) TablesQuery(b *database.BaseTable) (string, []interface{}) {
+	if b.Schema() == "" {
+		return "select table_name from information_schema.tables " +
+			"where table_schema = current_schema() and table_type = 'BASE TABLE'", nil
+	}
+	return "select table_name from information_schema.tables " +
+		"where table_schema = $1 and table_type = 'BASE TABLE'", []interface{}{b.Schema()}
+}
+
 // Quoted postgres引用函数
 func Quoted(s string) string {
 	return pq.QuoteIdentifier(s)
@@ -219,3 +219,36 @@ func TestSource_Table(t *testing.T) {
 		})
 	}
 }
+
+func TestSource_TablesQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		b         *database.BaseTable
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "1",
+			b:         database.NewBaseTable("db", "schema", "*"),
+			wantQuery: "select table_name from information_schema.tables where table_schema = $1 and table_type = 'BASE TABLE'",
+			wantArgs:  []interface{}{"schema"},
+		},
+		{
+			name:      "2",
+			b:         database.NewBaseTable("db", "", "*"),
+			wantQuery: "select table_name from information_schema.tables where table_schema = current_schema() and table_type = 'BASE TABLE'",
+			wantArgs:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs := (&Source{}).TablesQuery(tt.b)
+			if gotQuery != tt.wantQuery {
+				t.Errorf("Source.TablesQuery() query = %v, want %v", gotQuery, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("Source.TablesQuery() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/storage/database"
@@ -58,11 +59,13 @@ func (t *Table) AddField(baseField *database.BaseField) {
 	t.AppendField(f)
 }
 
-// ExecParam 获取执行参数，其中copy in的参数方式以及被注册
+// ExecParam 获取执行参数，其中copy in和upsert的参数方式以及被注册
 func (t *Table) ExecParam(mode string, txOpts *sql.TxOptions) (database.Parameter, bool) {
 	switch mode {
 	case WriteModeCopyIn:
 		return NewCopyInParam(t, txOpts), true
+	case database.WriteModeUpsert:
+		return NewUpsertParam(t, txOpts), true
 	}
 	return nil, false
 }
@@ -83,6 +86,19 @@ func (t *Table) ShouldOneByOne(err error) bool {
 	return ok
 }
 
+// ShouldShrinkBatch 批量过大，超过65535个参数或者数据库程序限制时缩小批量
+func (t *Table) ShouldShrinkBatch(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case *pq.Error:
+		//54: program_limit_exceeded
+		return cause.Code.Class() == "54"
+	case nil:
+		return false
+	default:
+		return strings.Contains(cause.Error(), "PostgreSQL only supports 65535 parameters")
+	}
+}
+
 // CopyInParam copy in 参数
 type CopyInParam struct {
 	*database.BaseParam
@@ -123,3 +139,15 @@ func (ci *CopyInParam) Agrs(records []element.Record) (valuers []interface{}, er
 	}
 	return
 }
+
+// UpsertParam insert into ... on conflict 参数
+type UpsertParam struct {
+	*database.OnConflictUpsertParam
+}
+
+// NewUpsertParam 通过表table和事务参数txOpts生成upsert参数
+func NewUpsertParam(t database.Table, txOpts *sql.TxOptions) *UpsertParam {
+	return &UpsertParam{
+		OnConflictUpsertParam: database.NewOnConflictUpsertParam(t, txOpts),
+	}
+}
@@ -17,6 +17,7 @@ package postgres
 import (
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"net"
 	"reflect"
 	"testing"
@@ -444,3 +445,153 @@ func TestTable_ShouldOneByOne(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_ShouldShrinkBatch(t *testing.T) {
+	type args struct {
+		err error
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want bool
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: nil,
+			},
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: driver.ErrBadConn,
+			},
+		},
+		{
+			name: "3",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: &pq.Error{Code: "23505"},
+			},
+		},
+		{
+			name: "4",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: &pq.Error{Code: "54000"},
+			},
+			want: true,
+		},
+		{
+			name: "5",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: &pq.Error{Code: "54011"},
+			},
+			want: true,
+		},
+		{
+			name: "6",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: errors.New("pq: got 70000 parameters but PostgreSQL only supports 65535 parameters"),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.ShouldShrinkBatch(tt.args.err); got != tt.want {
+				t.Errorf("Table.ShouldShrinkBatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertParam_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyColumn string
+		records   [][]int64
+		wantQuery string
+		wantArgs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			keyColumn: `["f1"]`,
+			wantQuery: `insert into "schema"."table"("f1","f2","f3") values($1,$2,$3),($4,$5,$6) on conflict ("f1") do update set "f2"=excluded."f2","f3"=excluded."f3"`,
+		},
+		{
+			name:      "2",
+			keyColumn: `["F1","f2","f3","f1"]`,
+			wantQuery: `insert into "schema"."table"("f1","f2","f3") values($1,$2,$3),($4,$5,$6) on conflict ("f1","f2","f3") do nothing`,
+		},
+		{
+			name:      "3",
+			keyColumn: `[]`,
+			wantErr:   true,
+		},
+		{
+			name:      "4",
+			keyColumn: `["f4"]`,
+			wantErr:   true,
+		},
+		{
+			name:      "5",
+			keyColumn: `["f1"]`,
+			records:   [][]int64{{1, 2, 3}, {2, 3, 4}, {1, 5, 6}},
+			wantQuery: `insert into "schema"."table"("f1","f2","f3") values($1,$2,$3),($4,$5,$6) on conflict ("f1") do update set "f2"=excluded."f2","f3"=excluded."f3"`,
+			wantArgs:  []interface{}{int64(2), int64(3), int64(4), int64(1), int64(5), int64(6)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(database.NewBaseTable("db", "schema", "table"))
+			table.SetConfig(testJSONFromString(`{"keyColumn":` + tt.keyColumn + `}`))
+			table.AppendField(NewField(database.NewBaseField(0, "f1", NewFieldType(newMockColumnType("INT8")))))
+			table.AppendField(NewField(database.NewBaseField(1, "f2", NewFieldType(newMockColumnType("INT8")))))
+			table.AppendField(NewField(database.NewBaseField(2, "f3", NewFieldType(newMockColumnType("INT8")))))
+
+			param, ok := table.ExecParam(database.WriteModeUpsert, nil)
+			if !ok {
+				t.Fatalf("Table.ExecParam() ok = %v", ok)
+			}
+			if _, ok = param.(*UpsertParam); !ok {
+				t.Fatalf("Table.ExecParam() = %T", param)
+			}
+			if tt.records == nil {
+				tt.records = [][]int64{{1, 2, 3}, {2, 3, 4}}
+			}
+			var records []element.Record
+			for _, v := range tt.records {
+				r := element.NewDefaultRecord()
+				for i, name := range []string{"f1", "f2", "f3"} {
+					r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(v[i]), name, 0))
+				}
+				records = append(records, r)
+			}
+			gotQuery, err := param.Query(records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpsertParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("UpsertParam.Query() = %v, want %v", gotQuery, tt.wantQuery)
+			}
+			if tt.wantArgs == nil {
+				return
+			}
+			gotArgs, err := param.Agrs(records)
+			if err != nil {
+				t.Fatalf("UpsertParam.Agrs() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("UpsertParam.Agrs() = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
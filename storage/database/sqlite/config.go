@@ -0,0 +1,45 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+// Config sqlite配置，sqlite没有用户名和密码
+type Config struct {
+	URL string `json:"url"` //数据库url，数据库文件路径或者file:开头的uri，包含数据库其他参数
+}
+
+// NewConfig 创建sqlite配置，如果格式不符合要求，就会报错
+func NewConfig(conf *config.JSON) (c *Config, err error) {
+	c = &Config{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+	if c.URL == "" {
+		return nil, errors.New("url is empty")
+	}
+	return
+}
+
+// FormatDSN 生成数据源连接信息
+func (c *Config) FormatDSN() string {
+	return c.URL
+}
@@ -0,0 +1,72 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+)
+
+func testJSONFromString(s string) *config.JSON {
+	json, err := config.NewJSONFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return json
+}
+
+func TestNewConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		wantC   *Config
+		wantDSN string
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			conf:    testJSONFromString(`{"url":"file:test.db?_busy_timeout=5000"}`),
+			wantC:   &Config{URL: "file:test.db?_busy_timeout=5000"},
+			wantDSN: "file:test.db?_busy_timeout=5000",
+		},
+		{
+			name:    "2",
+			conf:    testJSONFromString(`{"url":""}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"url":1}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotC, err := NewConfig(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotC, tt.wantC) {
+				t.Errorf("NewConfig() = %v, want %v", gotC, tt.wantC)
+			}
+			if err == nil && gotC.FormatDSN() != tt.wantDSN {
+				t.Errorf("FormatDSN() = %v, want %v", gotC.FormatDSN(), tt.wantDSN)
+			}
+		})
+	}
+}
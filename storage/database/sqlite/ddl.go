@@ -0,0 +1,53 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"strings"
+
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/mattn/go-sqlite3"
+	"github.com/pingcap/errors"
+)
+
+// typeMapping sqlite的类型映射，其中INT按照类型亲和性为64位整数
+var typeMapping = &database.TypeMapping{
+	Kinds: map[string]database.ColumnKind{
+		"INT": database.ColumnKindBigInt,
+	},
+	DDLTypes: database.DDLTypeMap{
+		database.ColumnKindBool:      database.DDLType("BOOLEAN"),
+		database.ColumnKindInt:       database.DDLType("INTEGER"),
+		database.ColumnKindBigInt:    database.DDLType("INTEGER"),
+		database.ColumnKindDecimal:   database.DDLDecimalType("DECIMAL", 1000, "NUMERIC"),
+		database.ColumnKindFloat:     database.DDLType("REAL"),
+		database.ColumnKindString:    database.DDLType("TEXT"),
+		database.ColumnKindBytes:     database.DDLType("BLOB"),
+		database.ColumnKindDate:      database.DDLType("DATE"),
+		database.ColumnKindTime:      database.DDLType("TEXT"),
+		database.ColumnKindTimestamp: database.DDLType("DATETIME"),
+	},
+}
+
+// CreateTableQuery 根据列columns和类型映射m生成建表语句
+func (t *Table) CreateTableQuery(columns []database.TableColumn, m *database.TypeMapping) (string, error) {
+	return database.CreateTableQuery(t.Quoted(), columns, Quoted, m)
+}
+
+// IsTableNotExist 获取表结构的错误err是否由表不存在导致
+func (t *Table) IsTableNotExist(err error) bool {
+	cause, ok := errors.Cause(err).(sqlite3.Error)
+	return ok && strings.Contains(cause.Error(), "no such table")
+}
@@ -0,0 +1,27 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlite 实现了sqlite的数据库方言Dialect，支持sqlite 3.24+ 对应数据库
+// 驱动为github.com/mattn/go-sqlite3
+// 数据源Source使用BaseSource来简化实现, 对github.com/mattn/go-sqlite3
+// 驱动进行包装.对于数据库配置，需要和Config一致，其中url为数据库文件路径
+// 表Table使用BaseTable来简化实现,也是基于github.com/mattn/go-sqlite3的
+// 封装,Table实现了FieldAdder的方式去获取列,在ExecParameter中实现写入模式为
+// upsert的insert into ... on conflict批量数据处理模式,写入模式为insert，update
+// 和delete的模式复用已有的database中的参数
+// 列Field使用BaseField来简化实现,其中FieldType采用了原来的sql.ColumnType，
+// 按照sqlite的类型亲和性规则判断类型，并实现了ValuerGoType
+// 扫描器Scanner使用BaseScanner来简化实现
+// 赋值器Valuer 使用了GoValuer的实现方式
+package sqlite
@@ -0,0 +1,289 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/mattn/go-sqlite3"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	dateLayout     = element.DefaultTimeFormat[:10]
+	datetimeLayout = element.DefaultTimeFormat[:26]
+)
+
+// Field 字段
+type Field struct {
+	*database.BaseField
+	database.BaseConfigSetter
+}
+
+// NewField 通过基本列属性生成字段
+func NewField(bf *database.BaseField) *Field {
+	return &Field{
+		BaseField: bf,
+	}
+}
+
+// Quoted 引用，用于SQL语句
+func (f *Field) Quoted() string {
+	return Quoted(f.Name())
+}
+
+// BindVar SQL占位符，用于SQL语句
+func (f *Field) BindVar(_ int) string {
+	return "?"
+}
+
+// Select 查询时字段，用于SQL查询语句
+func (f *Field) Select() string {
+	return Quoted(f.Name())
+}
+
+// Type 字段类型
+func (f *Field) Type() database.FieldType {
+	return NewFieldType(f.FieldType())
+}
+
+// Scanner 扫描器，用于读取数据
+func (f *Field) Scanner() database.Scanner {
+	return NewScanner(f)
+}
+
+// Valuer 赋值器，采用GoValuer处理数据
+func (f *Field) Valuer(c element.Column) database.Valuer {
+	return database.NewGoValuer(f, c)
+}
+
+// FieldType 字段类型
+type FieldType struct {
+	*database.BaseFieldType
+
+	typ    element.ColumnType
+	goType database.GoType
+}
+
+// NewFieldType 创建新的字段类型
+func NewFieldType(typ database.ColumnType) *FieldType {
+	f := &FieldType{
+		BaseFieldType: database.NewBaseFieldType(typ),
+	}
+	f.typ = columnType(f.DatabaseTypeName())
+	switch f.typ {
+	case element.TypeBool:
+		f.goType = database.GoTypeBool
+	case element.TypeBigInt:
+		f.goType = database.GoTypeInt64
+	case element.TypeDecimal, element.TypeString:
+		f.goType = database.GoTypeString
+	case element.TypeBytes:
+		f.goType = database.GoTypeBytes
+	case element.TypeTime:
+		f.goType = database.GoTypeTime
+	}
+	return f
+}
+
+// columnType 根据声明的类型名typeName获取列类型，除了布尔和时间类型外按照sqlite的类型亲和性规则处理，
+// 其中包含INT的为整数，包含CHAR，CLOB，TEXT的以及没有声明类型的为字符串，包含BLOB的为字节流，
+// 其余的例如REAL，DOUBLE，NUMERIC，DECIMAL为高精度实数
+func columnType(typeName string) element.ColumnType {
+	name := declaredType(typeName)
+	switch name {
+	case "BOOLEAN", "BOOL":
+		return element.TypeBool
+	case "DATE", "DATETIME", "TIMESTAMP":
+		return element.TypeTime
+	case "":
+		return element.TypeString
+	}
+	switch {
+	case strings.Contains(name, "INT"):
+		return element.TypeBigInt
+	case strings.Contains(name, "CHAR"), strings.Contains(name, "CLOB"),
+		strings.Contains(name, "TEXT"):
+		return element.TypeString
+	case strings.Contains(name, "BLOB"):
+		return element.TypeBytes
+	}
+	return element.TypeDecimal
+}
+
+// declaredType 获取去掉长度精度等参数的大写声明类型名
+func declaredType(typeName string) string {
+	name := strings.ToUpper(strings.TrimSpace(typeName))
+	if i := strings.Index(name, "("); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+	return name
+}
+
+// IsSupportted 是否支持解析
+func (f *FieldType) IsSupportted() bool {
+	return f.GoType() != database.GoTypeUnknown
+}
+
+// GoType 返回处理数值时的Golang类型
+func (f *FieldType) GoType() database.GoType {
+	return f.goType
+}
+
+// Scanner 扫描器
+type Scanner struct {
+	f *Field
+	database.BaseScanner
+}
+
+// NewScanner 根据列类型生成扫描器
+func NewScanner(f *Field) *Scanner {
+	return &Scanner{
+		f: f,
+	}
+}
+
+// Scan 根据列类型读取数据，由于sqlite的列可以存储任意类型的值，每种列类型会兼容多种存储的值
+// "BOOLEAN", "BOOL"作为布尔处理
+// 包含"INT"的作为整形处理
+// "REAL", "DOUBLE", "FLOAT", "NUMERIC", "DECIMAL"等作为高精度实数处理
+// "DATE", "DATETIME", "TIMESTAMP" 作为时间处理
+// 包含"CHAR", "CLOB", "TEXT"的以及没有声明类型的作为字符串处理
+// 包含"BLOB"的作为字节流处理
+func (s *Scanner) Scan(src interface{}) (err error) {
+	var cv element.ColumnValue
+	byteSize := element.ByteSize(src)
+
+	switch columnType(s.f.Type().DatabaseTypeName()) {
+	case element.TypeBool:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilBoolColumnValue()
+		case bool:
+			cv = element.NewBoolColumnValue(data)
+		case int64:
+			cv = element.NewBoolColumnValue(data != 0)
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeBool)
+		}
+	case element.TypeBigInt:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilBigIntColumnValue()
+		case int64:
+			cv = element.NewBigIntColumnValueFromInt64(data)
+		case []byte:
+			if cv, err = element.NewBigIntColumnValueFromString(string(data)); err != nil {
+				return
+			}
+		case string:
+			if cv, err = element.NewBigIntColumnValueFromString(data); err != nil {
+				return
+			}
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeBigInt)
+		}
+	case element.TypeDecimal:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilDecimalColumnValue()
+		case float64:
+			cv = element.NewDecimalColumnValueFromFloat(data)
+		case int64:
+			cv = element.NewDecimalColumnValue(decimal.NewFromInt(data))
+		case []byte:
+			if cv, err = element.NewDecimalColumnValueFromString(string(data)); err != nil {
+				return
+			}
+		case string:
+			if cv, err = element.NewDecimalColumnValueFromString(data); err != nil {
+				return
+			}
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeDecimal)
+		}
+	case element.TypeTime:
+		layout := datetimeLayout
+		if declaredType(s.f.Type().DatabaseTypeName()) == "DATE" {
+			layout = dateLayout
+		}
+		var t time.Time
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilTimeColumnValue()
+		case time.Time:
+			t = data
+		case int64:
+			t = time.Unix(data, 0)
+		case []byte:
+			if t, err = parseTime(string(data)); err != nil {
+				return
+			}
+		case string:
+			if t, err = parseTime(data); err != nil {
+				return
+			}
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeTime)
+		}
+		if src != nil {
+			cv = element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(layout))
+		}
+	case element.TypeString:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilStringColumnValue()
+		case string:
+			cv = element.NewStringColumnValue(s.f.TrimStringChar(data))
+		case []byte:
+			cv = element.NewStringColumnValue(s.f.TrimStringChar(string(data)))
+		case int64:
+			cv = element.NewStringColumnValue(strconv.FormatInt(data, 10))
+		case float64:
+			cv = element.NewStringColumnValue(strconv.FormatFloat(data, 'f', -1, 64))
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeString)
+		}
+	case element.TypeBytes:
+		switch data := src.(type) {
+		case nil:
+			cv = element.NewNilBytesColumnValue()
+		case []byte:
+			cv = element.NewBytesColumnValue(data)
+		case string:
+			cv = element.NewBytesColumnValue([]byte(data))
+		default:
+			return fmt.Errorf("src is %v(%T), but not %v", src, src, element.TypeBytes)
+		}
+	}
+	s.SetColumn(element.NewDefaultColumn(cv, s.f.Name(), byteSize))
+	return
+}
+
+// parseTime 使用github.com/mattn/go-sqlite3支持的时间格式解析字符串s
+func parseTime(s string) (t time.Time, err error) {
+	s = strings.TrimSuffix(s, "Z")
+	for _, layout := range sqlite3.SQLiteTimestampFormats {
+		if t, err = time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return
+		}
+	}
+	return t, fmt.Errorf("%v is not a valid time", s)
+}
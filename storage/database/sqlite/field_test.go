@@ -0,0 +1,237 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+type mockFieldType struct {
+	name string
+}
+
+func newMockFieldType(name string) *mockFieldType {
+	return &mockFieldType{
+		name: name,
+	}
+}
+
+func (m *mockFieldType) Name() string {
+	return ""
+}
+
+func (m *mockFieldType) ScanType() reflect.Type {
+	return nil
+}
+
+func (m *mockFieldType) Length() (length int64, ok bool) {
+	return
+}
+
+func (m *mockFieldType) DecimalSize() (precision, scale int64, ok bool) {
+	return
+}
+
+func (m *mockFieldType) Nullable() (nullable, ok bool) {
+	return
+}
+
+func (m *mockFieldType) DatabaseTypeName() string {
+	return m.name
+}
+
+func (m *mockFieldType) IsSupportted() bool {
+	return true
+}
+
+func TestNewFieldType(t *testing.T) {
+	tests := []struct {
+		name       string
+		typeName   string
+		wantGoType database.GoType
+	}{
+		{name: "1", typeName: "BOOLEAN", wantGoType: database.GoTypeBool},
+		{name: "2", typeName: "integer", wantGoType: database.GoTypeInt64},
+		{name: "3", typeName: "UNSIGNED BIG INT", wantGoType: database.GoTypeInt64},
+		{name: "4", typeName: "VARCHAR(255)", wantGoType: database.GoTypeString},
+		{name: "5", typeName: "CLOB", wantGoType: database.GoTypeString},
+		{name: "6", typeName: "", wantGoType: database.GoTypeString},
+		{name: "7", typeName: "BLOB", wantGoType: database.GoTypeBytes},
+		{name: "8", typeName: "REAL", wantGoType: database.GoTypeString},
+		{name: "9", typeName: "DECIMAL(10, 5)", wantGoType: database.GoTypeString},
+		{name: "10", typeName: "datetime", wantGoType: database.GoTypeTime},
+		{name: "11", typeName: "DATE", wantGoType: database.GoTypeTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFieldType(newMockFieldType(tt.typeName))
+			if got := f.GoType(); got != tt.wantGoType {
+				t.Errorf("FieldType.GoType() = %v, want %v", got, tt.wantGoType)
+			}
+			if !f.IsSupportted() {
+				t.Errorf("FieldType.IsSupportted() = false")
+			}
+		})
+	}
+}
+
+func TestField(t *testing.T) {
+	f := NewField(database.NewBaseField(0, "f1", newMockFieldType("INTEGER")))
+	if got := f.Quoted(); got != `"f1"` {
+		t.Errorf("Field.Quoted() = %v", got)
+	}
+	if got := f.Select(); got != `"f1"` {
+		t.Errorf("Field.Select() = %v", got)
+	}
+	if got := f.BindVar(1); got != "?" {
+		t.Errorf("Field.BindVar() = %v", got)
+	}
+	want := database.NewGoValuer(f, element.NewDefaultColumn(element.NewNilBigIntColumnValue(), "f1", 0))
+	if got := f.Valuer(element.NewDefaultColumn(element.NewNilBigIntColumnValue(), "f1", 0)); !reflect.DeepEqual(got, want) {
+		t.Errorf("Field.Valuer() = %v, want %v", got, want)
+	}
+}
+
+func TestScanner_Scan(t *testing.T) {
+	now := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name     string
+		typeName string
+		src      interface{}
+		want     element.Column
+		wantErr  bool
+	}{
+		{
+			name:     "1",
+			typeName: "BOOLEAN",
+			src:      int64(1),
+			want:     element.NewDefaultColumn(element.NewBoolColumnValue(true), "f1", element.ByteSize(int64(1))),
+		},
+		{
+			name:     "2",
+			typeName: "BOOL",
+			src:      "true",
+			wantErr:  true,
+		},
+		{
+			name:     "3",
+			typeName: "INTEGER",
+			src:      int64(100),
+			want:     element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(100), "f1", element.ByteSize(int64(100))),
+		},
+		{
+			name:     "4",
+			typeName: "INTEGER",
+			src:      "abc",
+			wantErr:  true,
+		},
+		{
+			name:     "5",
+			typeName: "INTEGER",
+			src:      nil,
+			want:     element.NewDefaultColumn(element.NewNilBigIntColumnValue(), "f1", 0),
+		},
+		{
+			name:     "6",
+			typeName: "REAL",
+			src:      float64(1.5),
+			want:     element.NewDefaultColumn(element.NewDecimalColumnValueFromFloat(1.5), "f1", element.ByteSize(float64(1.5))),
+		},
+		{
+			name:     "7",
+			typeName: "NUMERIC",
+			src:      "1.25",
+			want:     element.NewDefaultColumn(testDecimalColumnValue("1.25"), "f1", element.ByteSize("1.25")),
+		},
+		{
+			name:     "8",
+			typeName: "TEXT",
+			src:      int64(12),
+			want:     element.NewDefaultColumn(element.NewStringColumnValue("12"), "f1", element.ByteSize(int64(12))),
+		},
+		{
+			name:     "9",
+			typeName: "",
+			src:      float64(1.5),
+			want:     element.NewDefaultColumn(element.NewStringColumnValue("1.5"), "f1", element.ByteSize(float64(1.5))),
+		},
+		{
+			name:     "10",
+			typeName: "BLOB",
+			src:      "abc",
+			want:     element.NewDefaultColumn(element.NewBytesColumnValue([]byte("abc")), "f1", element.ByteSize("abc")),
+		},
+		{
+			name:     "11",
+			typeName: "DATETIME",
+			src:      now,
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(now,
+				element.NewStringTimeDecoder(datetimeLayout)), "f1", element.ByteSize(now)),
+		},
+		{
+			name:     "12",
+			typeName: "DATE",
+			src:      "2022-01-02 03:04:05",
+			want: element.NewDefaultColumn(element.NewTimeColumnValueWithDecoder(now,
+				element.NewStringTimeDecoder(dateLayout)), "f1", element.ByteSize("2022-01-02 03:04:05")),
+		},
+		{
+			name:     "13",
+			typeName: "TIMESTAMP",
+			src:      "abc",
+			wantErr:  true,
+		},
+		{
+			name:     "14",
+			typeName: "TIMESTAMP",
+			src:      nil,
+			want:     element.NewDefaultColumn(element.NewNilTimeColumnValue(), "f1", 0),
+		},
+		{
+			name:     "15",
+			typeName: "BLOB",
+			src:      int64(1),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewScanner(NewField(database.NewBaseField(0, "f1", newMockFieldType(tt.typeName))))
+			if err := s.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Errorf("Scanner.Scan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(s.Column(), tt.want) {
+				t.Errorf("Scanner.Column() = %v, want %v", s.Column(), tt.want)
+			}
+		})
+	}
+}
+
+func testDecimalColumnValue(s string) element.ColumnValue {
+	cv, err := element.NewDecimalColumnValueFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return cv
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/storage/database"
+)
+
+func TestDialect_Source(t *testing.T) {
+	tests := []struct {
+		name    string
+		bs      *database.BaseSource
+		want    database.Source
+		wantErr bool
+	}{
+		{
+			name: "1",
+			bs:   database.NewBaseSource(testJSONFromString(`{"url":"test.db"}`)),
+			want: &Source{
+				BaseSource: database.NewBaseSource(testJSONFromString(`{"url":"test.db"}`)),
+				dsn:        "test.db",
+			},
+		},
+		{
+			name:    "2",
+			bs:      database.NewBaseSource(testJSONFromString(`{}`)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Dialect{}.Source(tt.bs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Dialect.Source() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Dialect.Source() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSource(t *testing.T) {
+	s, err := NewSource(database.NewBaseSource(testJSONFromString(`{"url":"file:test.db?mode=memory"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.DriverName(); got != "sqlite3" {
+		t.Errorf("Source.DriverName() = %v", got)
+	}
+	if got := s.ConnectName(); got != "file:test.db?mode=memory" {
+		t.Errorf("Source.ConnectName() = %v", got)
+	}
+	if got := s.Key(); got != "file:test.db?mode=memory" {
+		t.Errorf("Source.Key() = %v", got)
+	}
+	want := NewTable(database.NewBaseTable("", "main", "table"))
+	if got := s.Table(database.NewBaseTable("", "main", "table")); !reflect.DeepEqual(got, want) {
+		t.Errorf("Source.Table() = %v, want %v", got, want)
+	}
+	if got := (Dialect{}).Name(); got != "sqlite" {
+		t.Errorf("Dialect.Name() = %v", got)
+	}
+}
+
+func TestSource_TablesQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		b         *database.BaseTable
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "1",
+			b:         database.NewBaseTable("", "main", "*"),
+			wantQuery: `select name from "main".sqlite_master where type = 'table' and name not like 'sqlite_%'`,
+			wantArgs:  nil,
+		},
+		{
+			name:      "2",
+			b:         database.NewBaseTable("", "", "*"),
+			wantQuery: "select name from sqlite_master where type = 'table' and name not like 'sqlite_%'",
+			wantArgs:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs := (&Source{}).TablesQuery(tt.b)
+			if gotQuery != tt.wantQuery {
+				t.Errorf("Source.TablesQuery() query = %v, want %v", gotQuery, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("Source.TablesQuery() args = %v, want %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/mattn/go-sqlite3"
+	"github.com/pingcap/errors"
+)
+
+// Table sqlite表
+type Table struct {
+	*database.BaseTable
+	database.BaseConfigSetter
+}
+
+// NewTable 创建sqlite表，注意此时BaseTable中的instance参数不使用，schema为附加数据库名，可以为空，而name是表名
+func NewTable(b *database.BaseTable) *Table {
+	return &Table{
+		BaseTable: b,
+	}
+}
+
+// Quoted 表引用全名
+func (t *Table) Quoted() string {
+	if t.Schema() == "" {
+		return Quoted(t.Name())
+	}
+	return Quoted(t.Schema()) + "." + Quoted(t.Name())
+}
+
+func (t *Table) String() string {
+	return t.Quoted()
+}
+
+// AddField 新增列
+func (t *Table) AddField(baseField *database.BaseField) {
+	f := NewField(baseField)
+	f.SetConfig(t.Config())
+	t.AppendField(f)
+}
+
+// ExecParam 获取执行参数，其中upsert的参数方式以及被注册
+func (t *Table) ExecParam(mode string, txOpts *sql.TxOptions) (database.Parameter, bool) {
+	switch mode {
+	case database.WriteModeUpsert:
+		return NewUpsertParam(t, txOpts), true
+	}
+	return nil, false
+}
+
+// ShouldRetry 重试，数据库被锁时重试
+func (t *Table) ShouldRetry(err error) bool {
+	switch cause := errors.Cause(err).(type) {
+	case sqlite3.Error:
+		return cause.Code == sqlite3.ErrBusy || cause.Code == sqlite3.ErrLocked
+	default:
+		return cause == driver.ErrBadConn
+	}
+}
+
+// ShouldOneByOne 单个重试
+func (t *Table) ShouldOneByOne(err error) bool {
+	cause, ok := errors.Cause(err).(sqlite3.Error)
+	return ok && cause.Code != sqlite3.ErrBusy && cause.Code != sqlite3.ErrLocked
+}
+
+// ShouldShrinkBatch 批量过大，超过单条sql语句占位符个数上限时缩小批量
+func (t *Table) ShouldShrinkBatch(err error) bool {
+	cause, ok := errors.Cause(err).(sqlite3.Error)
+	return ok && strings.Contains(cause.Error(), "too many SQL variables")
+}
+
+// UpsertParam insert into ... on conflict 参数
+type UpsertParam struct {
+	*database.OnConflictUpsertParam
+}
+
+// NewUpsertParam 通过表table和事务参数txOpts生成upsert参数
+func NewUpsertParam(t database.Table, txOpts *sql.TxOptions) *UpsertParam {
+	return &UpsertParam{
+		OnConflictUpsertParam: database.NewOnConflictUpsertParam(t, txOpts),
+	}
+}
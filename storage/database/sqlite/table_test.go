@@ -0,0 +1,374 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/database"
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestTable_Quoted(t *testing.T) {
+	tests := []struct {
+		name string
+		t    *Table
+		want string
+	}{
+		{
+			name: "1",
+			t:    NewTable(database.NewBaseTable("db", "", "table")),
+			want: `"table"`,
+		},
+		{
+			name: "2",
+			t:    NewTable(database.NewBaseTable("db", "main", "table")),
+			want: `"main"."table"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.t.Quoted(); got != tt.want {
+				t.Errorf("Table.Quoted() = %v, want %v", got, tt.want)
+			}
+			if got := tt.t.String(); got != tt.want {
+				t.Errorf("Table.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_ShouldRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantRetry    bool
+		wantOneByOne bool
+	}{
+		{
+			name:      "1",
+			err:       sqlite3.Error{Code: sqlite3.ErrBusy},
+			wantRetry: true,
+		},
+		{
+			name:      "2",
+			err:       sqlite3.Error{Code: sqlite3.ErrLocked},
+			wantRetry: true,
+		},
+		{
+			name:         "3",
+			err:          sqlite3.Error{Code: sqlite3.ErrConstraint},
+			wantOneByOne: true,
+		},
+		{
+			name:      "4",
+			err:       driver.ErrBadConn,
+			wantRetry: true,
+		},
+		{
+			name: "5",
+			err:  errors.New("mock error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(database.NewBaseTable("", "", "table"))
+			if got := table.ShouldRetry(tt.err); got != tt.wantRetry {
+				t.Errorf("Table.ShouldRetry() = %v, want %v", got, tt.wantRetry)
+			}
+			if got := table.ShouldOneByOne(tt.err); got != tt.wantOneByOne {
+				t.Errorf("Table.ShouldOneByOne() = %v, want %v", got, tt.wantOneByOne)
+			}
+		})
+	}
+}
+
+func TestTable_ShouldShrinkBatch(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	//sqlite单条sql语句最多32766个占位符
+	args := make([]interface{}, 32767)
+	query := "select ?" + strings.Repeat(",?", len(args)-1)
+	_, tooManyErr := db.Exec(query, args...)
+	if tooManyErr == nil {
+		t.Fatal("want too many SQL variables error")
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "1",
+			err:  tooManyErr,
+			want: true,
+		},
+		{
+			name: "2",
+			err:  sqlite3.Error{Code: sqlite3.ErrConstraint},
+		},
+		{
+			name: "3",
+			err:  errors.New("mock error"),
+		},
+		{
+			name: "4",
+			err:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(database.NewBaseTable("", "", "table"))
+			if got := table.ShouldShrinkBatch(tt.err); got != tt.want {
+				t.Errorf("Table.ShouldShrinkBatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertParam_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyColumn string
+		wantQuery string
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			keyColumn: `["f1"]`,
+			wantQuery: `insert into "table"("f1","f2","f3") values(?,?,?),(?,?,?) on conflict ("f1") do update set "f2"=excluded."f2","f3"=excluded."f3"`,
+		},
+		{
+			name:      "2",
+			keyColumn: `["f1","f2","f3"]`,
+			wantQuery: `insert into "table"("f1","f2","f3") values(?,?,?),(?,?,?) on conflict ("f1","f2","f3") do nothing`,
+		},
+		{
+			name:      "3",
+			keyColumn: `[]`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := NewTable(database.NewBaseTable("", "", "table"))
+			table.SetConfig(testJSONFromString(`{"keyColumn":` + tt.keyColumn + `}`))
+			table.AppendField(NewField(database.NewBaseField(0, "f1", newMockFieldType("INTEGER"))))
+			table.AppendField(NewField(database.NewBaseField(1, "f2", newMockFieldType("TEXT"))))
+			table.AppendField(NewField(database.NewBaseField(2, "f3", newMockFieldType("TEXT"))))
+
+			param, ok := table.ExecParam(database.WriteModeUpsert, nil)
+			if !ok {
+				t.Fatalf("Table.ExecParam() ok = %v", ok)
+			}
+			if _, ok = param.(*UpsertParam); !ok {
+				t.Fatalf("Table.ExecParam() = %T", param)
+			}
+			if _, ok = table.ExecParam(database.WriteModeInsert, nil); ok {
+				t.Fatalf("Table.ExecParam() ok = %v", ok)
+			}
+			var records []element.Record
+			for _, v := range []int64{1, 2} {
+				r := element.NewDefaultRecord()
+				for _, name := range []string{"f1", "f2", "f3"} {
+					r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(v), name, 0))
+				}
+				records = append(records, r)
+			}
+			gotQuery, err := param.Query(records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpsertParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("UpsertParam.Query() = %v, want %v", gotQuery, tt.wantQuery)
+			}
+		})
+	}
+}
+
+type testSelectParam struct {
+	*database.BaseParam
+}
+
+func (t *testSelectParam) Query(_ []element.Record) (string, error) {
+	return "select * from " + t.Table().Quoted() + " order by " + t.Table().Fields()[0].Quoted(), nil
+}
+
+func (t *testSelectParam) Agrs(_ []element.Record) ([]interface{}, error) {
+	return nil, nil
+}
+
+func testRecord(id int64, name string, amount string, created time.Time, flag bool, data string) element.Record {
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(id), "id", 0))
+	r.Add(element.NewDefaultColumn(element.NewStringColumnValue(name), "name", 0))
+	r.Add(element.NewDefaultColumn(testDecimalColumnValue(amount), "amount", 0))
+	r.Add(element.NewDefaultColumn(element.NewTimeColumnValue(created), "created", 0))
+	r.Add(element.NewDefaultColumn(element.NewBoolColumnValue(flag), "flag", 0))
+	r.Add(element.NewDefaultColumn(element.NewBytesColumnValue([]byte(data)), "data", 0))
+	return r
+}
+
+func testRecordString(r element.Record) string {
+	var values []string
+	for i := 0; i < r.ColumnNumber(); i++ {
+		c, _ := r.GetByIndex(i)
+		if c.IsNil() {
+			values = append(values, "<nil>")
+			continue
+		}
+		if c.Type() == element.TypeTime {
+			tm, _ := c.AsTime()
+			values = append(values, tm.UTC().Format(time.RFC3339))
+			continue
+		}
+		s, _ := c.AsString()
+		values = append(values, s)
+	}
+	return strings.Join(values, ",")
+}
+
+func TestTable_WriteModes(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	ctx := context.Background()
+	db, err := database.Open("sqlite", testJSONFromString(fmt.Sprintf(`{"url":%q}`,
+		filepath.Join(tmpDir, "test.db"))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err = db.ExecContext(ctx, `create table "t"(
+		"id" integer primary key,
+		"name" varchar(20),
+		"amount" decimal(10,2),
+		"created" datetime,
+		"flag" boolean,
+		"data" blob)`); err != nil {
+		t.Fatal(err)
+	}
+
+	table := NewTable(database.NewBaseTable("", "", "t"))
+	table.SetConfig(testJSONFromString(`{"keyColumn":["id"]}`))
+	var fetched database.Table
+	if fetched, err = db.FetchTableWithParam(ctx, database.NewTableQueryParam(table)); err != nil {
+		t.Fatal(err)
+	}
+
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	steps := []struct {
+		mode    string
+		records []element.Record
+		want    []string
+	}{
+		{
+			mode: database.WriteModeInsert,
+			records: []element.Record{
+				testRecord(1, "a", "1.5", created, true, "x"),
+				testRecord(2, "b", "2.25", created, false, "y"),
+			},
+			want: []string{
+				"1,a,1.5,2022-01-02T03:04:05Z,true,x",
+				"2,b,2.25,2022-01-02T03:04:05Z,false,y",
+			},
+		},
+		{
+			mode: database.WriteModeUpsert,
+			records: []element.Record{
+				testRecord(2, "bb", "3", created, true, "yy"),
+				testRecord(3, "c", "4", created, false, "z"),
+			},
+			want: []string{
+				"1,a,1.5,2022-01-02T03:04:05Z,true,x",
+				"2,bb,3,2022-01-02T03:04:05Z,true,yy",
+				"3,c,4,2022-01-02T03:04:05Z,false,z",
+			},
+		},
+		{
+			mode: database.WriteModeUpdate,
+			records: []element.Record{
+				testRecord(1, "aa", "5", created, false, "xx"),
+				testRecord(3, "c3", "7", created, false, "z3"),
+				testRecord(3, "cc", "6", created, true, "zz"),
+			},
+			want: []string{
+				"1,aa,5,2022-01-02T03:04:05Z,false,xx",
+				"2,bb,3,2022-01-02T03:04:05Z,true,yy",
+				"3,cc,6,2022-01-02T03:04:05Z,true,zz",
+			},
+		},
+		{
+			mode: database.WriteModeDelete,
+			records: []element.Record{
+				testRecord(1, "", "0", created, false, ""),
+				testRecord(2, "", "0", created, false, ""),
+			},
+			want: []string{
+				"3,cc,6,2022-01-02T03:04:05Z,true,zz",
+			},
+		},
+	}
+	for _, step := range steps {
+		exec := db.BatchExec
+		switch step.mode {
+		case database.WriteModeUpdate, database.WriteModeDelete:
+			exec = db.BatchExecStmtWithTx
+		}
+		if err = exec(ctx, &database.ParameterOptions{
+			Table:   fetched,
+			Mode:    step.mode,
+			Records: step.records,
+		}); err != nil {
+			t.Fatalf("BatchExec(%v) error = %v", step.mode, err)
+		}
+
+		var got []string
+		handler := database.NewBaseFetchHandler(func() (element.Record, error) {
+			return element.NewDefaultRecord(), nil
+		}, func(r element.Record) error {
+			got = append(got, testRecordString(r))
+			return nil
+		})
+		if err = db.FetchRecord(ctx, &testSelectParam{
+			BaseParam: database.NewBaseParam(fetched, nil),
+		}, handler); err != nil {
+			t.Fatalf("FetchRecord() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, step.want) {
+			t.Errorf("mode: %v got = %v, want %v", step.mode, got, step.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlserver
+
+import (
+	"github.com/Breeze0806/go-etl/storage/database"
+	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/pingcap/errors"
+)
+
+// typeMapping sqlserver的类型映射
+var typeMapping = &database.TypeMapping{
+	Kinds: map[string]database.ColumnKind{
+		"XML": database.ColumnKindString,
+	},
+	DDLTypes: database.DDLTypeMap{
+		database.ColumnKindBool:      database.DDLType("bit"),
+		database.ColumnKindInt:       database.DDLType("int"),
+		database.ColumnKindBigInt:    database.DDLType("bigint"),
+		database.ColumnKindDecimal:   database.DDLDecimalType("decimal", 38, "decimal(38,10)"),
+		database.ColumnKindFloat:     database.DDLType("float"),
+		database.ColumnKindString:    database.DDLLengthType("nvarchar", 4000, "nvarchar(max)"),
+		database.ColumnKindBytes:     database.DDLType("varbinary(max)"),
+		database.ColumnKindDate:      database.DDLType("date"),
+		database.ColumnKindTime:      database.DDLType("time"),
+		database.ColumnKindTimestamp: database.DDLType("datetime2"),
+	},
+}
+
+// CreateTableQuery 根据列columns和类型映射m生成建表语句
+func (t *Table) CreateTableQuery(columns []database.TableColumn, m *database.TypeMapping) (string, error) {
+	return database.CreateTableQuery(t.Quoted(), columns, Quoted, m)
+}
+
+// IsTableNotExist 获取表结构的错误err是否由表不存在导致
+func (t *Table) IsTableNotExist(err error) bool {
+	var number int32
+	switch cause := errors.Cause(err).(type) {
+	case mssql.Error:
+		number = cause.Number
+	case *mssql.Error:
+		number = cause.Number
+	}
+	//208: 对象名无效
+	return number == 208
+}
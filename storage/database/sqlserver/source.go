@@ -21,6 +21,7 @@ import (
 func init() {
 	var d Dialect
 	database.RegisterDialect(d.Name(), d)
+	database.RegisterTypeMapping(d.Name(), typeMapping)
 }
 
 // Dialect mssql数据库方言
@@ -80,6 +81,20 @@ func (s *Source) Table(b *database.BaseTable) database.Table {
 	return t
 }
 
+// TablesQuery 获取查询表b所在库和模式下所有表名的查询语句以及参数，库为空时使用当前库，模式为空时使用默认模式
+func (s *Source) TablesQuery(b *database.BaseTable) (string, []interface{}) {
+	catalog := "information_schema.tables"
+	if b.Instance() != "" {
+		catalog = Quoted(b.Instance()) + "." + catalog
+	}
+	if b.Schema() == "" {
+		return "select table_name from " + catalog +
+			" where table_schema = schema_name() and table_type = 'BASE TABLE'", nil
+	}
+	return "select table_name from " + catalog +
+		" where table_schema = @p1 and table_type = 'BASE TABLE'", []interface{}{b.Schema()}
+}
+
 // Quoted mysql引用函数
 func Quoted(s string) string {
 	return `[` + s + `]`
@@ -60,11 +60,13 @@ func (t *Table) AddField(baseField *database.BaseField) {
 	t.AppendField(f)
 }
 
-// ExecParam 获取执行参数，其中replace into的参数方式以及被注册
+// ExecParam 获取执行参数，其中copy in和upsert的参数方式以及被注册
 func (t *Table) ExecParam(mode string, txOpts *sql.TxOptions) (database.Parameter, bool) {
 	switch mode {
 	case WriteModeCopyIn:
 		return NewCopyInParam(t, txOpts), true
+	case database.WriteModeUpsert:
+		return NewMergeParam(t, txOpts), true
 	}
 	return nil, false
 }
@@ -85,6 +87,19 @@ func (t *Table) ShouldOneByOne(err error) bool {
 	return ok
 }
 
+// ShouldShrinkBatch 批量过大，超过2100个参数或者查询处理器资源上限时缩小批量
+func (t *Table) ShouldShrinkBatch(err error) bool {
+	var number int32
+	switch cause := errors.Cause(err).(type) {
+	case mssql.Error:
+		number = cause.Number
+	case *mssql.Error:
+		number = cause.Number
+	}
+	//8003: 参数过多, 8623: 查询处理器资源耗尽, 8632: 达到表达式服务上限
+	return number == 8003 || number == 8623 || number == 8632
+}
+
 // CopyInParam copy in 参数
 type CopyInParam struct {
 	*database.BaseParam
@@ -138,3 +153,23 @@ func (ci *CopyInParam) Agrs(records []element.Record) (valuers []interface{}, er
 	}
 	return
 }
+
+// MergeParam merge into 参数
+type MergeParam struct {
+	*database.ValuesMergeParam
+}
+
+// NewMergeParam 通过表table和事务参数txOpts生成merge参数
+func NewMergeParam(t database.Table, txOpts *sql.TxOptions) *MergeParam {
+	return &MergeParam{
+		ValuesMergeParam: database.NewValuesMergeParam(t, txOpts),
+	}
+}
+
+// Query 通过多条记录 records生成批量merge into语句，sqlserver的merge语句必须以分号结尾
+func (mp *MergeParam) Query(records []element.Record) (query string, err error) {
+	if query, err = mp.ValuesMergeParam.Query(records); err != nil {
+		return
+	}
+	return query + ";", nil
+}
@@ -491,3 +491,103 @@ func TestTable_ShouldOneByOne(t *testing.T) {
 		})
 	}
 }
+
+func TestTable_ShouldShrinkBatch(t *testing.T) {
+	type args struct {
+		err error
+	}
+	tests := []struct {
+		name string
+		tr   *Table
+		args args
+		want bool
+	}{
+		{
+			name: "1",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: nil,
+			},
+		},
+		{
+			name: "2",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: driver.ErrBadConn,
+			},
+		},
+		{
+			name: "3",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: mssql.Error{Number: 2627},
+			},
+		},
+		{
+			name: "4",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: mssql.Error{Number: 8003},
+			},
+			want: true,
+		},
+		{
+			name: "5",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: &mssql.Error{Number: 8003},
+			},
+			want: true,
+		},
+		{
+			name: "6",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: mssql.Error{Number: 8623},
+			},
+			want: true,
+		},
+		{
+			name: "7",
+			tr:   NewTable(database.NewBaseTable("db", "schema", "table")),
+			args: args{
+				err: mssql.Error{Number: 8632},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.ShouldShrinkBatch(tt.args.err); got != tt.want {
+				t.Errorf("Table.ShouldShrinkBatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeParam_Query(t *testing.T) {
+	table := NewTable(database.NewBaseTable("db", "schema", "table"))
+	table.SetConfig(testJSONFromString(`{"keyColumn":["f1"]}`))
+	table.AppendField(NewField(database.NewBaseField(0, "f1", newMockFieldType("BIGINT"))))
+	table.AppendField(NewField(database.NewBaseField(1, "f2", newMockFieldType("BIGINT"))))
+
+	param, ok := table.ExecParam(database.WriteModeUpsert, nil)
+	if !ok {
+		t.Fatalf("Table.ExecParam() ok = %v", ok)
+	}
+	if _, ok = param.(*MergeParam); !ok {
+		t.Fatalf("Table.ExecParam() = %T", param)
+	}
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "f1", 0))
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(2), "f2", 0))
+	gotQuery, err := param.Query([]element.Record{r})
+	if err != nil {
+		t.Fatalf("MergeParam.Query() error = %v", err)
+	}
+	want := "merge into [db].[schema].[table] as t using (values(@p1,@p2)) as s([f1],[f2]) on (t.[f1]=s.[f1])" +
+		" when matched then update set t.[f2]=s.[f2] when not matched then insert([f1],[f2]) values(s.[f1],s.[f2]);"
+	if gotQuery != want {
+		t.Errorf("MergeParam.Query() = %v, want %v", gotQuery, want)
+	}
+}
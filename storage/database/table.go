@@ -20,6 +20,7 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"strings"
 
 	"github.com/Breeze0806/go-etl/element"
 	"github.com/Breeze0806/go-etl/schedule"
@@ -45,6 +46,12 @@ type Parameter interface {
 	Agrs([]element.Record) ([]interface{}, error) //prepare参数
 }
 
+// StmtFlusher 用于判断prepare语句逐条执行完所有记录后，是否需要不带参数再执行一次，
+// 例如copy in需要以此写入数据，而逐条更新和删除不需要，未实现时默认需要
+type StmtFlusher interface {
+	NeedFlushStmt() bool
+}
+
 // ParameterOptions 参数选项
 type ParameterOptions struct {
 	Table     Table            //表或者视图
@@ -58,6 +65,12 @@ type FieldsFetcher interface {
 	FetchFields(ctx context.Context, db *DB) error //获取具体列
 }
 
+// ColumnTypesFetcher Table的补充方法，用于在获取表结构后从数据库目录中补充列声明的类型，
+// 如mysql驱动获取的类型名中没有整数类型的长度
+type ColumnTypesFetcher interface {
+	FetchColumnTypes(ctx context.Context, db *DB) error //获取列声明的类型
+}
+
 // FieldAdder Table的补充方法，用于新增表的列
 type FieldAdder interface {
 	AddField(*BaseField) //新增具体列
@@ -75,6 +88,12 @@ type Judger interface {
 	ShouldOneByOne(err error) bool
 }
 
+// BatchSizeJudger Table的补充方法，用于判断错误是否由单次批量写入的记录过多导致，
+// 如超过数据库单条sql语句的参数个数上限或者数据包大小上限
+type BatchSizeJudger interface {
+	ShouldShrinkBatch(err error) bool
+}
+
 // BaseTable 基本表，用于嵌入各种数据库Table的实现
 type BaseTable struct {
 	instance string
@@ -212,6 +231,369 @@ func (i *InsertParam) Agrs(records []element.Record) (valuers []interface{}, err
 	return
 }
 
+// KeyFieldIndexes 获取表table中键列和非键列在列中的序号，键列通过KeyColumnsGetter获取，
+// 列名不区分大小写，当未配置键列或者键列不存在时报错
+func KeyFieldIndexes(table Table) (keys []int, others []int, err error) {
+	getter, ok := table.(KeyColumnsGetter)
+	if !ok || len(getter.KeyColumns()) == 0 {
+		return nil, nil, fmt.Errorf("keyColumn of table %v is empty", table.Quoted())
+	}
+	isKey := make([]bool, len(table.Fields()))
+	for _, name := range getter.KeyColumns() {
+		found := false
+		for fi, f := range table.Fields() {
+			if strings.EqualFold(f.Name(), name) {
+				if !isKey[fi] {
+					keys = append(keys, fi)
+				}
+				isKey[fi] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, nil, fmt.Errorf("keyColumn %v does not exist in table %v", name, table.Quoted())
+		}
+	}
+	for fi := range table.Fields() {
+		if !isKey[fi] {
+			others = append(others, fi)
+		}
+	}
+	return
+}
+
+// UpdateParam 更新参数
+type UpdateParam struct {
+	*BaseParam
+}
+
+// NewUpdateParam 通过表table和事务参数txOps生成更新参数
+func NewUpdateParam(t Table, txOps *sql.TxOptions) *UpdateParam {
+	return &UpdateParam{
+		BaseParam: NewBaseParam(t, txOps),
+	}
+}
+
+// Query 生成按键列更新单条记录非键列的prepare语句，形如
+// update t set c1 = ?,c2 = ? where k1 = ? and k2 = ?，
+// 每条记录通过Agrs生成参数后逐条执行，避免同一批次中键列重复时丢失后面的更新以及占位符超限
+func (u *UpdateParam) Query(_ []element.Record) (query string, err error) {
+	var keys, others []int
+	if keys, others, err = KeyFieldIndexes(u.Table()); err != nil {
+		return
+	}
+	if len(others) == 0 {
+		return "", fmt.Errorf("table %v has no column to update except keyColumn", u.Table().Quoted())
+	}
+	fields := u.Table().Fields()
+	n := 0
+	bindVar := func(f Field) string {
+		n++
+		return f.BindVar(n)
+	}
+	buf := bytes.NewBufferString("update ")
+	buf.WriteString(u.Table().Quoted())
+	buf.WriteString(" set ")
+	for oi, o := range others {
+		if oi > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(fields[o].Quoted() + " = " + bindVar(fields[o]))
+	}
+	buf.WriteString(" where ")
+	writeKeyCondition(buf, fields, keys, bindVar)
+	return buf.String(), nil
+}
+
+// Agrs 通过记录 records依次生成每条记录的非键列和键列参数，顺序和Query中的占位符一致
+func (u *UpdateParam) Agrs(records []element.Record) (valuers []interface{}, err error) {
+	var keys, others []int
+	if keys, others, err = KeyFieldIndexes(u.Table()); err != nil {
+		return
+	}
+	indexes := append(append([]int{}, others...), keys...)
+	for _, r := range records {
+		if valuers, err = appendValues(valuers, u.Table(), r, indexes); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// NeedFlushStmt 逐条更新在执行完所有记录后无需再次执行
+func (u *UpdateParam) NeedFlushStmt() bool {
+	return false
+}
+
+// DeleteParam 删除参数
+type DeleteParam struct {
+	*BaseParam
+}
+
+// NewDeleteParam 通过表table和事务参数txOps生成删除参数
+func NewDeleteParam(t Table, txOps *sql.TxOptions) *DeleteParam {
+	return &DeleteParam{
+		BaseParam: NewBaseParam(t, txOps),
+	}
+}
+
+// Query 生成按键列删除单条记录的prepare语句，形如
+// delete from t where k1 = ? and k2 = ?
+func (d *DeleteParam) Query(_ []element.Record) (query string, err error) {
+	var keys []int
+	if keys, _, err = KeyFieldIndexes(d.Table()); err != nil {
+		return
+	}
+	n := 0
+	bindVar := func(f Field) string {
+		n++
+		return f.BindVar(n)
+	}
+	buf := bytes.NewBufferString("delete from ")
+	buf.WriteString(d.Table().Quoted())
+	buf.WriteString(" where ")
+	writeKeyCondition(buf, d.Table().Fields(), keys, bindVar)
+	return buf.String(), nil
+}
+
+// Agrs 通过记录 records依次生成每条记录的键列参数
+func (d *DeleteParam) Agrs(records []element.Record) (valuers []interface{}, err error) {
+	var keys []int
+	if keys, _, err = KeyFieldIndexes(d.Table()); err != nil {
+		return
+	}
+	for _, r := range records {
+		if valuers, err = appendValues(valuers, d.Table(), r, keys); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// NeedFlushStmt 逐条删除在执行完所有记录后无需再次执行
+func (d *DeleteParam) NeedFlushStmt() bool {
+	return false
+}
+
+// writeKeyCondition 写入单条记录的键列条件 k1 = ? and k2 = ?
+func writeKeyCondition(buf *bytes.Buffer, fields []Field, keys []int, bindVar func(Field) string) {
+	for ki, k := range keys {
+		if ki > 0 {
+			buf.WriteString(" and ")
+		}
+		buf.WriteString(fields[k].Quoted() + " = " + bindVar(fields[k]))
+	}
+}
+
+// OnConflictUpsertParam insert into ... on conflict do update参数，
+// 适用于postgres和sqlite等支持on conflict子句的数据库
+type OnConflictUpsertParam struct {
+	*InsertParam
+}
+
+// NewOnConflictUpsertParam 通过表table和事务参数txOps生成on conflict upsert参数
+func NewOnConflictUpsertParam(t Table, txOps *sql.TxOptions) *OnConflictUpsertParam {
+	return &OnConflictUpsertParam{
+		InsertParam: NewInsertParam(t, txOps),
+	}
+}
+
+// Query 通过多条记录 records生成批量insert into ... on conflict do update语句，
+// 键列冲突时更新非键列，没有非键列时不做任何操作，键列相同的记录只保留最后一条
+func (up *OnConflictUpsertParam) Query(records []element.Record) (query string, err error) {
+	var keys, others []int
+	if keys, others, err = KeyFieldIndexes(up.Table()); err != nil {
+		return
+	}
+	if records, err = DistinctKeyRecords(up.Table(), records); err != nil {
+		return
+	}
+	if query, err = up.InsertParam.Query(records); err != nil {
+		return
+	}
+	fields := up.Table().Fields()
+	buf := bytes.NewBufferString(query)
+	buf.WriteString(" on conflict (")
+	for i, fi := range keys {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(fields[fi].Quoted())
+	}
+	buf.WriteString(")")
+	if len(others) == 0 {
+		buf.WriteString(" do nothing")
+		return buf.String(), nil
+	}
+	buf.WriteString(" do update set ")
+	for i, fi := range others {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(fields[fi].Quoted() + "=excluded." + fields[fi].Quoted())
+	}
+	return buf.String(), nil
+}
+
+// Agrs 通过多条记录 records生成批量upsert参数，键列相同的记录只保留最后一条
+func (up *OnConflictUpsertParam) Agrs(records []element.Record) (valuers []interface{}, err error) {
+	if records, err = DistinctKeyRecords(up.Table(), records); err != nil {
+		return
+	}
+	return up.InsertParam.Agrs(records)
+}
+
+// ValuesMergeParam merge into ... using (values ...)参数，
+// 适用于sqlserver和db2等支持以values列表作为merge源的数据库
+type ValuesMergeParam struct {
+	*InsertParam
+}
+
+// NewValuesMergeParam 通过表table和事务参数txOps生成values merge参数
+func NewValuesMergeParam(t Table, txOps *sql.TxOptions) *ValuesMergeParam {
+	return &ValuesMergeParam{
+		InsertParam: NewInsertParam(t, txOps),
+	}
+}
+
+// Query 通过多条记录 records生成批量merge into语句，键列匹配时更新非键列，否则插入，
+// 键列相同的记录只保留最后一条
+func (mp *ValuesMergeParam) Query(records []element.Record) (query string, err error) {
+	var keys, others []int
+	if keys, others, err = KeyFieldIndexes(mp.Table()); err != nil {
+		return
+	}
+	if records, err = DistinctKeyRecords(mp.Table(), records); err != nil {
+		return
+	}
+	buf := bytes.NewBufferString("merge into ")
+	buf.WriteString(mp.Table().Quoted())
+	buf.WriteString(" as t using ")
+	WriteValuesMergeSource(buf, mp.Table(), records)
+	WriteMergeClause(buf, mp.Table(), keys, others)
+	return buf.String(), nil
+}
+
+// Agrs 通过多条记录 records生成批量merge参数，键列相同的记录只保留最后一条
+func (mp *ValuesMergeParam) Agrs(records []element.Record) (valuers []interface{}, err error) {
+	if records, err = DistinctKeyRecords(mp.Table(), records); err != nil {
+		return
+	}
+	return mp.InsertParam.Agrs(records)
+}
+
+// WriteValuesMergeSource 向buf写入merge语句中由记录records的占位符组成的values列表源，
+// 源别名为s，列名为表table的列名，如(values(?,?),(?,?)) as s(f1,f2)
+func WriteValuesMergeSource(buf *bytes.Buffer, table Table, records []element.Record) {
+	fields := table.Fields()
+	buf.WriteString("(values")
+	for ri := range records {
+		if ri > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("(")
+		for fi, f := range fields {
+			if fi > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString(f.BindVar(ri*len(fields) + fi + 1))
+		}
+		buf.WriteString(")")
+	}
+	buf.WriteString(") as s(")
+	for fi, f := range fields {
+		if fi > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(f.Quoted())
+	}
+	buf.WriteString(")")
+}
+
+// WriteMergeClause 向buf写入merge语句的on条件以及匹配时更新非键列，不匹配时插入的子句，
+// 其中目标表别名为t，源别名为s，没有非键列时匹配后不做任何操作，键列keys和非键列others
+// 可以通过KeyFieldIndexes获取
+func WriteMergeClause(buf *bytes.Buffer, table Table, keys, others []int) {
+	fields := table.Fields()
+	buf.WriteString(" on (")
+	for i, fi := range keys {
+		if i > 0 {
+			buf.WriteString(" and ")
+		}
+		buf.WriteString("t." + fields[fi].Quoted() + "=s." + fields[fi].Quoted())
+	}
+	buf.WriteString(")")
+	if len(others) > 0 {
+		buf.WriteString(" when matched then update set ")
+		for i, fi := range others {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("t." + fields[fi].Quoted() + "=s." + fields[fi].Quoted())
+		}
+	}
+	buf.WriteString(" when not matched then insert(")
+	for fi, f := range fields {
+		if fi > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(f.Quoted())
+	}
+	buf.WriteString(") values(")
+	for fi, f := range fields {
+		if fi > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("s." + f.Quoted())
+	}
+	buf.WriteString(")")
+}
+
+// DistinctKeyRecords 按照表table的键列对记录records去重，键列值相同的记录只保留最后一条，
+// 保留的记录维持原有顺序，避免同一条upsert或者merge语句多次更新同一行而报错
+func DistinctKeyRecords(table Table, records []element.Record) (distinct []element.Record, err error) {
+	var keys []int
+	if keys, _, err = KeyFieldIndexes(table); err != nil {
+		return
+	}
+	recordKeys := make([]string, len(records))
+	last := make(map[string]int, len(records))
+	for ri, r := range records {
+		var values []interface{}
+		if values, err = appendValues(nil, table, r, keys); err != nil {
+			return nil, err
+		}
+		recordKeys[ri] = fmt.Sprintf("%#v", values)
+		last[recordKeys[ri]] = ri
+	}
+	if len(last) == len(records) {
+		return records, nil
+	}
+	for ri, r := range records {
+		if last[recordKeys[ri]] == ri {
+			distinct = append(distinct, r)
+		}
+	}
+	return
+}
+
+// appendValues 将记录r中序号为indexes的列转化为参数追加到valuers中
+func appendValues(valuers []interface{}, table Table, r element.Record, indexes []int) ([]interface{}, error) {
+	for _, fi := range indexes {
+		c, err := r.GetByIndex(fi)
+		if err != nil {
+			return nil, fmt.Errorf("GetByIndex(%v) err: %v", fi, err)
+		}
+		var v driver.Value
+		if v, err = table.Fields()[fi].Valuer(c).Value(); err != nil {
+			return nil, err
+		}
+		valuers = append(valuers, interface{}(v))
+	}
+	return valuers, nil
+}
+
 // TableQueryParam 表结构查询参数
 type TableQueryParam struct {
 	*BaseParam
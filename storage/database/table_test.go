@@ -15,6 +15,7 @@
 package database
 
 import (
+	"bytes"
 	"database/sql"
 	"reflect"
 	"testing"
@@ -489,3 +490,468 @@ func TestTableQueryParam_Agrs(t *testing.T) {
 		})
 	}
 }
+
+type mockTableWithKey struct {
+	*mockTable
+	BaseConfigSetter
+}
+
+func TestKeyFieldIndexes(t *testing.T) {
+	tests := []struct {
+		name       string
+		table      Table
+		keyColumn  string
+		wantKeys   []int
+		wantOthers []int
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			keyColumn:  `["F2"]`,
+			wantKeys:   []int{1},
+			wantOthers: []int{0, 2},
+		},
+		{
+			name:       "2",
+			keyColumn:  `["f3","f1","f3"]`,
+			wantKeys:   []int{2, 0},
+			wantOthers: []int{1},
+		},
+		{
+			name:      "3",
+			keyColumn: `["f1","f2","f3"]`,
+			wantKeys:  []int{0, 1, 2},
+		},
+		{
+			name:      "4",
+			keyColumn: `[]`,
+			wantErr:   true,
+		},
+		{
+			name:      "5",
+			keyColumn: `["f4"]`,
+			wantErr:   true,
+		},
+		{
+			name:    "6",
+			table:   newMockTable(NewBaseTable("db", "schema", "table")),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := tt.table
+			if table == nil {
+				m := &mockTableWithKey{
+					mockTable: newMockTable(NewBaseTable("db", "schema", "table")),
+				}
+				m.SetConfig(testJSONFromString(`{"keyColumn":` + tt.keyColumn + `}`))
+				for i, name := range []string{"f1", "f2", "f3"} {
+					m.AppendField(newMockField(NewBaseField(i, name, newMockFieldType(GoTypeInt64)),
+						newMockFieldType(GoTypeInt64)))
+				}
+				table = m
+			}
+			gotKeys, gotOthers, err := KeyFieldIndexes(table)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("KeyFieldIndexes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotKeys, tt.wantKeys) {
+				t.Errorf("KeyFieldIndexes() gotKeys = %v, want %v", gotKeys, tt.wantKeys)
+			}
+			if !reflect.DeepEqual(gotOthers, tt.wantOthers) {
+				t.Errorf("KeyFieldIndexes() gotOthers = %v, want %v", gotOthers, tt.wantOthers)
+			}
+		})
+	}
+}
+
+func testKeyTable(keyColumn string) *mockTableWithKey {
+	m := &mockTableWithKey{
+		mockTable: newMockTable(NewBaseTable("db", "schema", "table")),
+	}
+	m.SetConfig(testJSONFromString(`{"keyColumn":` + keyColumn + `}`))
+	for i, name := range []string{"f1", "f2", "f3"} {
+		m.AppendField(newMockField(NewBaseField(i, name, newMockFieldType(GoTypeInt64)),
+			newMockFieldType(GoTypeInt64)))
+	}
+	return m
+}
+
+func testKeyRecords(values ...[]int64) (records []element.Record) {
+	for _, v := range values {
+		r := element.NewDefaultRecord()
+		for i, name := range []string{"f1", "f2", "f3"} {
+			r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(v[i]), name, 0))
+		}
+		records = append(records, r)
+	}
+	return
+}
+
+func TestWriteValuesMergeSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		records []element.Record
+		want    string
+	}{
+		{
+			name:    "1",
+			records: testKeyRecords([]int64{1, 2, 3}),
+			want:    "(values($1,$2,$3)) as s(f1,f2,f3)",
+		},
+		{
+			name:    "2",
+			records: testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}),
+			want:    "(values($1,$2,$3),($4,$5,$6)) as s(f1,f2,f3)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			WriteValuesMergeSource(buf, testKeyTable(`["f1"]`), tt.records)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteValuesMergeSource() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValuesMergeParam(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyColumn string
+		records   []element.Record
+		wantQuery string
+		wantAgrs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			keyColumn: `["f1"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}),
+			wantQuery: "merge into db.schema.table as t using (values($1,$2,$3),($4,$5,$6)) as s(f1,f2,f3) on (t.f1=s.f1)" +
+				" when matched then update set t.f2=s.f2,t.f3=s.f3 when not matched then insert(f1,f2,f3) values(s.f1,s.f2,s.f3)",
+			wantAgrs: []interface{}{int64(1), int64(2), int64(3), int64(4), int64(5), int64(6)},
+		},
+		{
+			name:      "2",
+			keyColumn: `["F1","f2","f3","f1"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}),
+			wantQuery: "merge into db.schema.table as t using (values($1,$2,$3)) as s(f1,f2,f3) on (t.f1=s.f1 and t.f2=s.f2 and t.f3=s.f3)" +
+				" when not matched then insert(f1,f2,f3) values(s.f1,s.f2,s.f3)",
+			wantAgrs: []interface{}{int64(1), int64(2), int64(3)},
+		},
+		{
+			name:      "3",
+			keyColumn: `["f1"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}, []int64{1, 7, 8}),
+			wantQuery: "merge into db.schema.table as t using (values($1,$2,$3),($4,$5,$6)) as s(f1,f2,f3) on (t.f1=s.f1)" +
+				" when matched then update set t.f2=s.f2,t.f3=s.f3 when not matched then insert(f1,f2,f3) values(s.f1,s.f2,s.f3)",
+			wantAgrs: []interface{}{int64(4), int64(5), int64(6), int64(1), int64(7), int64(8)},
+		},
+		{
+			name:      "4",
+			keyColumn: `[]`,
+			records:   testKeyRecords([]int64{1, 2, 3}),
+			wantErr:   true,
+		},
+		{
+			name:      "5",
+			keyColumn: `["f4"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mp := NewValuesMergeParam(testKeyTable(tt.keyColumn), nil)
+			gotQuery, err := mp.Query(tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValuesMergeParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("ValuesMergeParam.Query() = %v, want %v", gotQuery, tt.wantQuery)
+			}
+			gotAgrs, err := mp.Agrs(tt.records)
+			if err != nil {
+				t.Fatalf("ValuesMergeParam.Agrs() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotAgrs, tt.wantAgrs) {
+				t.Errorf("ValuesMergeParam.Agrs() = %v, want %v", gotAgrs, tt.wantAgrs)
+			}
+		})
+	}
+}
+
+func TestWriteMergeClause(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyColumn string
+		want      string
+	}{
+		{
+			name:      "1",
+			keyColumn: `["f1"]`,
+			want: " on (t.f1=s.f1) when matched then update set t.f2=s.f2,t.f3=s.f3" +
+				" when not matched then insert(f1,f2,f3) values(s.f1,s.f2,s.f3)",
+		},
+		{
+			name:      "2",
+			keyColumn: `["f1","f2","f3"]`,
+			want:      " on (t.f1=s.f1 and t.f2=s.f2 and t.f3=s.f3) when not matched then insert(f1,f2,f3) values(s.f1,s.f2,s.f3)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := testKeyTable(tt.keyColumn)
+			keys, others, err := KeyFieldIndexes(table)
+			if err != nil {
+				t.Fatal(err)
+			}
+			buf := &bytes.Buffer{}
+			WriteMergeClause(buf, table, keys, others)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("WriteMergeClause() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistinctKeyRecords(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyColumn string
+		records   []element.Record
+		want      []element.Record
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			keyColumn: `["f1"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}),
+			want:      testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}),
+		},
+		{
+			name:      "2",
+			keyColumn: `["f1"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}, []int64{1, 7, 8}, []int64{4, 9, 9}),
+			want:      testKeyRecords([]int64{1, 7, 8}, []int64{4, 9, 9}),
+		},
+		{
+			name:      "3",
+			keyColumn: `["f1","f2"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}, []int64{1, 3, 4}, []int64{1, 2, 5}),
+			want:      testKeyRecords([]int64{1, 3, 4}, []int64{1, 2, 5}),
+		},
+		{
+			name:      "4",
+			keyColumn: `[]`,
+			records:   testKeyRecords([]int64{1, 2, 3}),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DistinctKeyRecords(testKeyTable(tt.keyColumn), tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DistinctKeyRecords() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DistinctKeyRecords() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOnConflictUpsertParam(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyColumn string
+		records   []element.Record
+		wantQuery string
+		wantAgrs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			keyColumn: `["f1"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}),
+			wantQuery: "insert into db.schema.table(f1,f2,f3) values($1,$2,$3),($4,$5,$6)" +
+				" on conflict (f1) do update set f2=excluded.f2,f3=excluded.f3",
+			wantAgrs: []interface{}{int64(1), int64(2), int64(3), int64(4), int64(5), int64(6)},
+		},
+		{
+			name:      "2",
+			keyColumn: `["f1","f2","f3"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}),
+			wantQuery: "insert into db.schema.table(f1,f2,f3) values($1,$2,$3) on conflict (f1,f2,f3) do nothing",
+			wantAgrs:  []interface{}{int64(1), int64(2), int64(3)},
+		},
+		{
+			name:      "3",
+			keyColumn: `["f1"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}, []int64{1, 7, 8}),
+			wantQuery: "insert into db.schema.table(f1,f2,f3) values($1,$2,$3),($4,$5,$6)" +
+				" on conflict (f1) do update set f2=excluded.f2,f3=excluded.f3",
+			wantAgrs: []interface{}{int64(4), int64(5), int64(6), int64(1), int64(7), int64(8)},
+		},
+		{
+			name:      "4",
+			keyColumn: `[]`,
+			records:   testKeyRecords([]int64{1, 2, 3}),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			up := NewOnConflictUpsertParam(testKeyTable(tt.keyColumn), nil)
+			gotQuery, err := up.Query(tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OnConflictUpsertParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("OnConflictUpsertParam.Query() = %v, want %v", gotQuery, tt.wantQuery)
+			}
+			gotAgrs, err := up.Agrs(tt.records)
+			if err != nil {
+				t.Fatalf("OnConflictUpsertParam.Agrs() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotAgrs, tt.wantAgrs) {
+				t.Errorf("OnConflictUpsertParam.Agrs() = %v, want %v", gotAgrs, tt.wantAgrs)
+			}
+		})
+	}
+}
+
+func TestUpdateParam(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyColumn string
+		records   []element.Record
+		wantQuery string
+		wantAgrs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			keyColumn: `["f1"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}),
+			wantQuery: "update db.schema.table set f2 = $1,f3 = $2 where f1 = $3",
+			wantAgrs:  []interface{}{int64(2), int64(3), int64(1), int64(5), int64(6), int64(4)},
+		},
+		{
+			name:      "2",
+			keyColumn: `["f1","f2"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}),
+			wantQuery: "update db.schema.table set f3 = $1 where f1 = $2 and f2 = $3",
+			wantAgrs:  []interface{}{int64(3), int64(1), int64(2)},
+		},
+		{
+			name:      "3",
+			keyColumn: `["f1","f2","f3"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}),
+			wantErr:   true,
+		},
+		{
+			name:      "4",
+			keyColumn: `[]`,
+			records:   testKeyRecords([]int64{1, 2, 3}),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewUpdateParam(testKeyTable(tt.keyColumn), nil)
+			gotQuery, err := u.Query(tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("UpdateParam.Query() = %v, want %v", gotQuery, tt.wantQuery)
+			}
+			gotAgrs, err := u.Agrs(tt.records)
+			if err != nil {
+				t.Fatalf("UpdateParam.Agrs() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotAgrs, tt.wantAgrs) {
+				t.Errorf("UpdateParam.Agrs() = %v, want %v", gotAgrs, tt.wantAgrs)
+			}
+			if u.NeedFlushStmt() {
+				t.Errorf("UpdateParam.NeedFlushStmt() = true, want false")
+			}
+		})
+	}
+}
+
+func TestDeleteParam(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyColumn string
+		records   []element.Record
+		wantQuery string
+		wantAgrs  []interface{}
+		wantErr   bool
+	}{
+		{
+			name:      "1",
+			keyColumn: `["f1"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}),
+			wantQuery: "delete from db.schema.table where f1 = $1",
+			wantAgrs:  []interface{}{int64(1), int64(4)},
+		},
+		{
+			name:      "2",
+			keyColumn: `["f3","f1"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}, []int64{4, 5, 6}),
+			wantQuery: "delete from db.schema.table where f3 = $1 and f1 = $2",
+			wantAgrs:  []interface{}{int64(3), int64(1), int64(6), int64(4)},
+		},
+		{
+			name:      "3",
+			keyColumn: `["f4"]`,
+			records:   testKeyRecords([]int64{1, 2, 3}),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDeleteParam(testKeyTable(tt.keyColumn), nil)
+			gotQuery, err := d.Query(tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeleteParam.Query() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("DeleteParam.Query() = %v, want %v", gotQuery, tt.wantQuery)
+			}
+			gotAgrs, err := d.Agrs(tt.records)
+			if err != nil {
+				t.Fatalf("DeleteParam.Agrs() error = %v", err)
+			}
+			if !reflect.DeepEqual(gotAgrs, tt.wantAgrs) {
+				t.Errorf("DeleteParam.Agrs() = %v, want %v", gotAgrs, tt.wantAgrs)
+			}
+			if d.NeedFlushStmt() {
+				t.Errorf("DeleteParam.NeedFlushStmt() = true, want false")
+			}
+		})
+	}
+}
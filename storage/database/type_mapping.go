@@ -0,0 +1,315 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/pingcap/errors"
+)
+
+// TypeMappingConfig 类型映射配置，用于在单个工作中覆盖数据库方言的类型映射
+type TypeMappingConfig struct {
+	Types   map[string]string `json:"types"`   //字段类型名对应的逻辑类型，类型名可以带有长度或者精度，如{"NUMBER(1)":"bool"}
+	Columns map[string]string `json:"columns"` //列名对应的逻辑类型，如{"is_active":"bool"}
+	DDL     map[string]string `json:"ddl"`     //逻辑类型对应的建表语句中的列类型，如{"string":"text"}
+}
+
+// TypeMapping 数据库方言的类型映射，将字段类型映射为规范的逻辑类型即列类别，
+// 并将列类别映射回建表语句中的列类型
+type TypeMapping struct {
+	Kinds    map[string]ColumnKind //字段类型名对应的列类别，类型名可以带有长度或者精度，如NUMBER(1,0)
+	DDLTypes DDLTypeMap            //列类别对应的建表语句中的列类型
+
+	overrideKinds   map[string]ColumnKind //工作覆盖的字段类型名对应的列类别
+	overrideColumns map[string]ColumnKind //工作覆盖的列名对应的列类别
+}
+
+var typeMappings = &typeMappingMap{
+	mappings: make(map[string]*TypeMapping),
+}
+
+// RegisterTypeMapping 注册数据库方言name的类型映射，当注册名称相同或者mapping为空时会panic
+func RegisterTypeMapping(name string, mapping *TypeMapping) {
+	if err := typeMappings.register(name, mapping); err != nil {
+		panic(err)
+	}
+}
+
+// GetTypeMapping 获取数据库方言name的类型映射，未注册时返回只使用通用类型名的类型映射
+func GetTypeMapping(name string) *TypeMapping {
+	if m, ok := typeMappings.mapping(name); ok {
+		return m
+	}
+	return &TypeMapping{}
+}
+
+// UnregisterAllTypeMappings 注销所有的类型映射
+func UnregisterAllTypeMappings() {
+	typeMappings.unregisterAll()
+}
+
+type typeMappingMap struct {
+	sync.RWMutex
+	mappings map[string]*TypeMapping
+}
+
+func (t *typeMappingMap) register(name string, mapping *TypeMapping) error {
+	if mapping == nil {
+		return fmt.Errorf("type mapping %v is nil", name)
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	if _, ok := t.mappings[name]; ok {
+		return fmt.Errorf("type mapping %v exists", name)
+	}
+
+	t.mappings[name] = mapping
+	return nil
+}
+
+func (t *typeMappingMap) mapping(name string) (mapping *TypeMapping, ok bool) {
+	t.RLock()
+	defer t.RUnlock()
+	mapping, ok = t.mappings[name]
+	return
+}
+
+func (t *typeMappingMap) unregisterAll() {
+	t.Lock()
+	defer t.Unlock()
+	t.mappings = make(map[string]*TypeMapping)
+}
+
+// ParseColumnKind 通过逻辑类型字符串s获取列类别，如bool，bigInt，timestamp，忽略大小写
+func ParseColumnKind(s string) (ColumnKind, error) {
+	for k, v := range columnKindMap {
+		if k != ColumnKindUnknown && strings.EqualFold(v, s) {
+			return k, nil
+		}
+	}
+	return ColumnKindUnknown, errors.Errorf("logical type %v is not supported", s)
+}
+
+// Override 根据类型映射配置conf生成覆盖后的类型映射，不会修改原有的类型映射，conf为空时返回自身
+func (t *TypeMapping) Override(conf *TypeMappingConfig) (*TypeMapping, error) {
+	if conf == nil {
+		return t, nil
+	}
+	m := &TypeMapping{
+		Kinds:           t.Kinds,
+		DDLTypes:        make(DDLTypeMap),
+		overrideKinds:   make(map[string]ColumnKind),
+		overrideColumns: make(map[string]ColumnKind),
+	}
+	for k, v := range t.DDLTypes {
+		m.DDLTypes[k] = v
+	}
+	for k, v := range t.overrideKinds {
+		m.overrideKinds[k] = v
+	}
+	for k, v := range t.overrideColumns {
+		m.overrideColumns[k] = v
+	}
+
+	for name, s := range conf.Types {
+		kind, err := ParseColumnKind(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "type %v", name)
+		}
+		m.overrideKinds[normalizeTypeName(name)] = kind
+	}
+	for name, s := range conf.Columns {
+		kind, err := ParseColumnKind(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %v", name)
+		}
+		m.overrideColumns[name] = kind
+	}
+	for s, typ := range conf.DDL {
+		kind, err := ParseColumnKind(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, "ddl")
+		}
+		m.DDLTypes[kind] = DDLType(typ)
+	}
+	return m, nil
+}
+
+// FieldKind 获取列名为name，字段类型为typ的列类别，
+// 依次使用工作覆盖的列名，工作覆盖的字段类型名，数据库方言的字段类型名以及通用的类型名
+func (t *TypeMapping) FieldKind(name string, typ FieldType) ColumnKind {
+	if kind, ok := t.OverrideKind(name, typ); ok {
+		return kind
+	}
+	if kind, ok := lookupTypeKind(t.Kinds, typ); ok {
+		return kind
+	}
+	return FieldColumnKind(typ)
+}
+
+// OverrideKind 获取工作覆盖的列名为name，字段类型为typ的列类别，没有覆盖时ok为false
+func (t *TypeMapping) OverrideKind(name string, typ FieldType) (kind ColumnKind, ok bool) {
+	if kind, ok = t.overrideColumns[name]; ok {
+		return
+	}
+	return lookupTypeKind(t.overrideKinds, typ)
+}
+
+// HasOverride 是否存在工作覆盖的列类别
+func (t *TypeMapping) HasOverride() bool {
+	return len(t.overrideColumns) != 0 || len(t.overrideKinds) != 0
+}
+
+// lookupTypeKind 在类型名对应列类别的映射kinds中查找字段类型typ的列类别，
+// 依次使用声明的完整类型名，带有精度和标度的类型名，带有精度或者长度的类型名以及类型名
+func lookupTypeKind(kinds map[string]ColumnKind, typ FieldType) (kind ColumnKind, ok bool) {
+	if len(kinds) == 0 {
+		return
+	}
+	full := normalizeTypeName(typ.DatabaseTypeName())
+	name := full
+	if i := strings.Index(full, "("); i >= 0 {
+		if kind, ok = kinds[full]; ok {
+			return
+		}
+		name = full[:i]
+	}
+	if precision, scale, has := typ.DecimalSize(); has {
+		if kind, ok = kinds[fmt.Sprintf("%s(%d,%d)", name, precision, scale)]; ok {
+			return
+		}
+		if scale == 0 {
+			if kind, ok = kinds[fmt.Sprintf("%s(%d)", name, precision)]; ok {
+				return
+			}
+		}
+	}
+	if length, has := typ.Length(); has {
+		if kind, ok = kinds[fmt.Sprintf("%s(%d)", name, length)]; ok {
+			return
+		}
+	}
+	kind, ok = kinds[name]
+	return
+}
+
+// normalizeTypeName 将类型名转化为大写并去除括号内外多余的空格，如varchar ( 20 )转化为VARCHAR(20)
+func normalizeTypeName(name string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	i := strings.Index(name, "(")
+	if i < 0 {
+		return name
+	}
+	return strings.TrimSpace(name[:i]) + strings.Join(strings.Fields(name[i:]), "")
+}
+
+// 列类别对应的列类型
+var columnKindTypes = map[ColumnKind]element.ColumnType{
+	ColumnKindBool:      element.TypeBool,
+	ColumnKindInt:       element.TypeBigInt,
+	ColumnKindBigInt:    element.TypeBigInt,
+	ColumnKindDecimal:   element.TypeDecimal,
+	ColumnKindFloat:     element.TypeDecimal,
+	ColumnKindString:    element.TypeString,
+	ColumnKindBytes:     element.TypeBytes,
+	ColumnKindDate:      element.TypeTime,
+	ColumnKindTime:      element.TypeTime,
+	ColumnKindTimestamp: element.TypeTime,
+}
+
+// ColumnType 列类别对应的列类型，未知类别时为未知类型
+func (k ColumnKind) ColumnType() element.ColumnType {
+	if t, ok := columnKindTypes[k]; ok {
+		return t
+	}
+	return element.TypeUnknown
+}
+
+// ConvertColumn 将列c转化为列类别kind对应列类型的列，列类别未知或者列类型相同时返回c
+func ConvertColumn(c element.Column, kind ColumnKind) (element.Column, error) {
+	typ := kind.ColumnType()
+	if typ == element.TypeUnknown || c.Type() == typ {
+		return c, nil
+	}
+	var v element.ColumnValue
+	switch typ {
+	case element.TypeBool:
+		if c.IsNil() {
+			v = element.NewNilBoolColumnValue()
+			break
+		}
+		b, err := c.AsBool()
+		if err != nil {
+			return nil, err
+		}
+		v = element.NewBoolColumnValue(b)
+	case element.TypeBigInt:
+		if c.IsNil() {
+			v = element.NewNilBigIntColumnValue()
+			break
+		}
+		bi, err := c.AsBigInt()
+		if err != nil {
+			return nil, err
+		}
+		v = element.NewBigIntColumnValue(bi.AsBigInt())
+	case element.TypeDecimal:
+		if c.IsNil() {
+			v = element.NewNilDecimalColumnValue()
+			break
+		}
+		d, err := c.AsDecimal()
+		if err != nil {
+			return nil, err
+		}
+		v = element.NewDecimalColumnValue(d.AsDecimal())
+	case element.TypeString:
+		if c.IsNil() {
+			v = element.NewNilStringColumnValue()
+			break
+		}
+		s, err := c.AsString()
+		if err != nil {
+			return nil, err
+		}
+		v = element.NewStringColumnValue(s)
+	case element.TypeBytes:
+		if c.IsNil() {
+			v = element.NewNilBytesColumnValue()
+			break
+		}
+		b, err := c.AsBytes()
+		if err != nil {
+			return nil, err
+		}
+		v = element.NewBytesColumnValue(b)
+	case element.TypeTime:
+		if c.IsNil() {
+			v = element.NewNilTimeColumnValue()
+			break
+		}
+		tm, err := c.AsTime()
+		if err != nil {
+			return nil, err
+		}
+		v = element.NewTimeColumnValue(tm)
+	}
+	return element.NewDefaultColumn(v, c.Name(), int(c.ByteSize())), nil
+}
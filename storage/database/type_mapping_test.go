@@ -0,0 +1,376 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func TestRegisterTypeMapping(t *testing.T) {
+	UnregisterAllTypeMappings()
+	defer UnregisterAllTypeMappings()
+	m := &TypeMapping{}
+	tests := []struct {
+		name    string
+		mapping *TypeMapping
+		wantErr bool
+	}{
+		{
+			name:    "1",
+			mapping: m,
+		},
+		{
+			name:    "1",
+			mapping: &TypeMapping{},
+			wantErr: true,
+		},
+		{
+			name:    "2",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		run := func() (err error) {
+			defer func() {
+				if perr := recover(); perr != nil {
+					err = perr.(error)
+				}
+			}()
+			RegisterTypeMapping(tt.name, tt.mapping)
+			return
+		}
+		if err := run(); (err != nil) != tt.wantErr {
+			t.Errorf("RegisterTypeMapping() error = %v, wantErr %v", err, tt.wantErr)
+		}
+	}
+	if got := GetTypeMapping("1"); got != m {
+		t.Errorf("GetTypeMapping() = %p, want %p", got, m)
+	}
+	if got := GetTypeMapping("3"); got == nil {
+		t.Errorf("GetTypeMapping() = nil")
+	}
+}
+
+func TestParseColumnKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    ColumnKind
+		wantErr bool
+	}{
+		{
+			name: "1",
+			s:    "bigInt",
+			want: ColumnKindBigInt,
+		},
+		{
+			name: "2",
+			s:    "BOOL",
+			want: ColumnKindBool,
+		},
+		{
+			name:    "3",
+			s:       "unknown",
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			s:       "geometry",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseColumnKind(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseColumnKind() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseColumnKind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeMapping_Override(t *testing.T) {
+	m := &TypeMapping{
+		Kinds: map[string]ColumnKind{
+			"NUMBER(5,0)": ColumnKindInt,
+		},
+		DDLTypes: DDLTypeMap{
+			ColumnKindString: DDLType("varchar(255)"),
+		},
+	}
+	tests := []struct {
+		name    string
+		conf    *TypeMappingConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: &TypeMappingConfig{
+				Types:   map[string]string{"number ( 1 )": "bool"},
+				Columns: map[string]string{"c1": "string"},
+				DDL:     map[string]string{"string": "text"},
+			},
+		},
+		{
+			name: "2",
+			conf: &TypeMappingConfig{
+				Types: map[string]string{"NUMBER(1)": "boolean"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "3",
+			conf: &TypeMappingConfig{
+				Columns: map[string]string{"c1": "boolean"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "4",
+			conf: &TypeMappingConfig{
+				DDL: map[string]string{"boolean": "bit"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := m.Override(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TypeMapping.Override() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got, _ := m.Override(nil); got != m {
+		t.Errorf("TypeMapping.Override() = %p, want %p", got, m)
+	}
+
+	o, err := m.Override(&TypeMappingConfig{
+		Types:   map[string]string{"number ( 1 )": "bool"},
+		Columns: map[string]string{"c1": "string"},
+		DDL:     map[string]string{"string": "text"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !o.HasOverride() || m.HasOverride() {
+		t.Errorf("TypeMapping.HasOverride() = %v %v", o.HasOverride(), m.HasOverride())
+	}
+	if got := o.DDLTypes[ColumnKindString](nil); got != "text" {
+		t.Errorf("TypeMapping.Override() ddl = %v, want text", got)
+	}
+	if got := m.DDLTypes[ColumnKindString](nil); got != "varchar(255)" {
+		t.Errorf("TypeMapping.Override() modifies ddl = %v", got)
+	}
+}
+
+func TestTypeMapping_FieldKind(t *testing.T) {
+	m := &TypeMapping{
+		Kinds: map[string]ColumnKind{
+			"NUMBER(5,0)": ColumnKindInt,
+			"DATE":        ColumnKindTimestamp,
+		},
+	}
+	o, err := m.Override(&TypeMappingConfig{
+		Types:   map[string]string{"NUMBER(1)": "bool", "VARCHAR(36)": "bytes", "DECIMAL(20,6)": "float"},
+		Columns: map[string]string{"c1": "string"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		m            *TypeMapping
+		column       string
+		typ          FieldType
+		want         ColumnKind
+		wantOverride bool
+	}{
+		{
+			name:   "1",
+			m:      m,
+			column: "c1",
+			typ:    &mockDDLFieldType{name: "NUMBER", precision: 5},
+			want:   ColumnKindInt,
+		},
+		{
+			name:   "2",
+			m:      m,
+			column: "c1",
+			typ:    &mockDDLFieldType{name: "DATE"},
+			want:   ColumnKindTimestamp,
+		},
+		{
+			name:   "3",
+			m:      m,
+			column: "c1",
+			typ:    &mockDDLFieldType{name: "NUMBER", precision: 1},
+			want:   ColumnKindDecimal,
+		},
+		{
+			name:         "4",
+			m:            o,
+			column:       "c1",
+			typ:          &mockDDLFieldType{name: "NUMBER", precision: 1},
+			want:         ColumnKindString,
+			wantOverride: true,
+		},
+		{
+			name:         "5",
+			m:            o,
+			column:       "c2",
+			typ:          &mockDDLFieldType{name: "NUMBER", precision: 1},
+			want:         ColumnKindBool,
+			wantOverride: true,
+		},
+		{
+			name:         "6",
+			m:            o,
+			column:       "c2",
+			typ:          &mockDDLFieldType{name: "VARCHAR", length: 36},
+			want:         ColumnKindBytes,
+			wantOverride: true,
+		},
+		{
+			name:         "7",
+			m:            o,
+			column:       "c2",
+			typ:          &mockDDLFieldType{name: "decimal(20, 6)"},
+			want:         ColumnKindFloat,
+			wantOverride: true,
+		},
+		{
+			name:   "8",
+			m:      o,
+			column: "c2",
+			typ:    &mockDDLFieldType{name: "NUMBER", precision: 5},
+			want:   ColumnKindInt,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.FieldKind(tt.column, tt.typ); got != tt.want {
+				t.Errorf("TypeMapping.FieldKind() = %v, want %v", got, tt.want)
+			}
+			if _, ok := tt.m.OverrideKind(tt.column, tt.typ); ok != tt.wantOverride {
+				t.Errorf("TypeMapping.OverrideKind() ok = %v, want %v", ok, tt.wantOverride)
+			}
+		})
+	}
+}
+
+func TestConvertColumn(t *testing.T) {
+	now := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		c       element.Column
+		kind    ColumnKind
+		want    element.Column
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    element.NewDefaultColumn(element.NewStringColumnValue("1"), "c1", 1),
+			kind: ColumnKindBool,
+			want: element.NewDefaultColumn(element.NewBoolColumnValue(true), "c1", 1),
+		},
+		{
+			name: "2",
+			c:    element.NewDefaultColumn(element.NewStringColumnValue("12"), "c1", 2),
+			kind: ColumnKindInt,
+			want: element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(12), "c1", 2),
+		},
+		{
+			name: "3",
+			c:    element.NewDefaultColumn(element.NewStringColumnValue("1.5"), "c1", 3),
+			kind: ColumnKindFloat,
+			want: element.NewDefaultColumn(element.NewDecimalColumnValueFromFloat(1.5), "c1", 3),
+		},
+		{
+			name: "4",
+			c:    element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(12), "c1", 8),
+			kind: ColumnKindString,
+			want: element.NewDefaultColumn(element.NewStringColumnValue("12"), "c1", 8),
+		},
+		{
+			name: "5",
+			c:    element.NewDefaultColumn(element.NewStringColumnValue("ab"), "c1", 2),
+			kind: ColumnKindBytes,
+			want: element.NewDefaultColumn(element.NewBytesColumnValue([]byte("ab")), "c1", 2),
+		},
+		{
+			name: "6",
+			c:    element.NewDefaultColumn(element.NewTimeColumnValue(now), "c1", 8),
+			kind: ColumnKindTimestamp,
+			want: element.NewDefaultColumn(element.NewTimeColumnValue(now), "c1", 8),
+		},
+		{
+			name: "7",
+			c:    element.NewDefaultColumn(element.NewNilStringColumnValue(), "c1", 0),
+			kind: ColumnKindBool,
+			want: element.NewDefaultColumn(element.NewNilBoolColumnValue(), "c1", 0),
+		},
+		{
+			name: "8",
+			c:    element.NewDefaultColumn(element.NewStringColumnValue("abc"), "c1", 3),
+			kind: ColumnKindUnknown,
+			want: element.NewDefaultColumn(element.NewStringColumnValue("abc"), "c1", 3),
+		},
+		{
+			name:    "9",
+			c:       element.NewDefaultColumn(element.NewStringColumnValue("abc"), "c1", 3),
+			kind:    ColumnKindBigInt,
+			wantErr: true,
+		},
+		{
+			name:    "10",
+			c:       element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "c1", 3),
+			kind:    ColumnKindDate,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertColumn(tt.c, tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConvertColumn() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Type() != tt.want.Type() || got.String() != tt.want.String() || got.Name() != tt.want.Name() {
+				t.Errorf("ConvertColumn() = %v(%v), want %v(%v)", got, got.Type(), tt.want, tt.want.Type())
+			}
+		})
+	}
+}
+
+func TestColumnKind_ColumnType(t *testing.T) {
+	if got := ColumnKindFloat.ColumnType(); got != element.TypeDecimal {
+		t.Errorf("ColumnKind.ColumnType() = %v, want %v", got, element.TypeDecimal)
+	}
+	if got := ColumnKindUnknown.ColumnType(); got != element.TypeUnknown {
+		t.Errorf("ColumnKind.ColumnType() = %v, want %v", got, element.TypeUnknown)
+	}
+}
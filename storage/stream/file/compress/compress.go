@@ -15,10 +15,15 @@
 package compress
 
 import (
+	"compress/bzip2"
 	"compress/gzip"
 	"fmt"
 	"io"
 	"os"
+
+	dsbzip2 "github.com/dsnet/compress/bzip2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
 )
 
 // Type 压缩类型
@@ -31,6 +36,9 @@ const (
 	TypeTar     Type = "tar"
 	TypeZip     Type = "zip"
 	TypeGzip    Type = "gz"
+	TypeZstd    Type = "zstd"
+	TypeBzip2   Type = "bz2"
+	TypeXz      Type = "xz"
 )
 
 // ReadCloser 获取读取关闭器
@@ -45,6 +53,21 @@ func (c Type) ReadCloser(f *os.File) (r io.ReadCloser, err error) {
 	case TypeGzip:
 		r, err = NewGzipReadCloser(f)
 		return
+	case TypeTar:
+		r = NewTarReadCloser(f)
+		return
+	case TypeTarGzip:
+		r, err = NewTarGzipReadCloser(f)
+		return
+	case TypeZstd:
+		r, err = NewZstdReadCloser(f)
+		return
+	case TypeBzip2:
+		r = NewBzip2ReadCloser(f)
+		return
+	case TypeXz:
+		r, err = NewXzReadCloser(f)
+		return
 	}
 	err = fmt.Errorf("unsupported type %v", c)
 	return
@@ -62,14 +85,45 @@ func (c Type) WriteCloser(f *os.File) (w io.WriteCloser, err error) {
 	case TypeGzip:
 		w = NewGzipWriter(f)
 		return
+	case TypeTar:
+		w, err = NewTarWriter(f)
+		return
+	case TypeTarGzip:
+		w, err = NewTarGzipWriter(f)
+		return
+	case TypeZstd:
+		w, err = NewZstdWriter(f)
+		return
+	case TypeBzip2:
+		w, err = NewBzip2Writer(f)
+		return
+	case TypeXz:
+		w, err = NewXzWriter(f)
+		return
 	}
 	err = fmt.Errorf("unsupported type %v", c)
 	return
 }
 
+// WrittenSize 获取以压缩方式c写入的文件f中已经写入的字节数，
+// tar和targz在关闭时才把内容写入文件，无法获取
+func (c Type) WrittenSize(f *os.File) (int64, error) {
+	switch c {
+	case TypeTar, TypeTarGzip:
+		return 0, fmt.Errorf("written size of compress type %v is unknown before closing", c)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return fi.Size(), nil
+}
+
 // ReadCloser 读取关闭器
 type ReadCloser struct {
 	io.Reader
+
+	closer io.Closer // 解压器需要释放资源时使用，不会关闭文件本身
 }
 
 // Read 读取p
@@ -79,6 +133,9 @@ func (r *ReadCloser) Read(p []byte) (n int, err error) {
 
 // Close 关闭
 func (r *ReadCloser) Close() error {
+	if r.closer != nil {
+		return r.closer.Close()
+	}
 	return nil
 }
 
@@ -108,6 +165,53 @@ func NewGzipReadCloser(f *os.File) (r *ReadCloser, err error) {
 	return
 }
 
+// NewTarReadCloser 获取tar读取关闭器
+func NewTarReadCloser(f *os.File) *ReadCloser {
+	return &ReadCloser{
+		Reader: NewTarReader(f),
+	}
+}
+
+// NewTarGzipReadCloser 获取tar.gz读取关闭器
+func NewTarGzipReadCloser(f *os.File) (r *ReadCloser, err error) {
+	var gr *gzip.Reader
+	if gr, err = gzip.NewReader(f); err != nil {
+		return nil, err
+	}
+	return &ReadCloser{
+		Reader: NewTarReader(gr),
+	}, nil
+}
+
+// NewZstdReadCloser 获取zstd压缩读取关闭器
+func NewZstdReadCloser(f *os.File) (r *ReadCloser, err error) {
+	var d *zstd.Decoder
+	if d, err = zstd.NewReader(f); err != nil {
+		return nil, err
+	}
+	rc := d.IOReadCloser()
+	return &ReadCloser{
+		Reader: rc,
+		closer: rc,
+	}, nil
+}
+
+// NewBzip2ReadCloser 获取bzip2压缩读取关闭器
+func NewBzip2ReadCloser(f *os.File) *ReadCloser {
+	return &ReadCloser{
+		Reader: bzip2.NewReader(f),
+	}
+}
+
+// NewXzReadCloser 获取xz压缩读取关闭器
+func NewXzReadCloser(f *os.File) (r *ReadCloser, err error) {
+	r = &ReadCloser{}
+	if r.Reader, err = xz.NewReader(f); err != nil {
+		return nil, err
+	}
+	return
+}
+
 // NoneWriter 无压缩写入器
 type NoneWriter struct {
 	file *os.File
@@ -152,3 +256,32 @@ func (g *GzipWriter) Write(p []byte) (n int, err error) {
 func (g *GzipWriter) Close() error {
 	return g.writer.Close()
 }
+
+// NewZstdWriter 创建zstd压缩写入器
+func NewZstdWriter(f *os.File) (w io.WriteCloser, err error) {
+	var e *zstd.Encoder
+	if e, err = zstd.NewWriter(f); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// NewBzip2Writer 创建bzip2压缩写入器
+func NewBzip2Writer(f *os.File) (w io.WriteCloser, err error) {
+	var bw *dsbzip2.Writer
+	if bw, err = dsbzip2.NewWriter(f, &dsbzip2.WriterConfig{
+		Level: dsbzip2.DefaultCompression,
+	}); err != nil {
+		return nil, err
+	}
+	return bw, nil
+}
+
+// NewXzWriter 创建xz压缩写入器
+func NewXzWriter(f *os.File) (w io.WriteCloser, err error) {
+	var xw *xz.Writer
+	if xw, err = xz.NewWriter(f); err != nil {
+		return nil, err
+	}
+	return xw, nil
+}
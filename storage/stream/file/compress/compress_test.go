@@ -60,6 +60,51 @@ func TestType_ReadWrite(t *testing.T) {
 			},
 			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
 		},
+		{
+			name: "4",
+			c:    TypeTar,
+			args: args{
+				filename: "a.tar",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "5",
+			c:    TypeTarGzip,
+			args: args{
+				filename: "a.tar.gz",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "6",
+			c:    TypeZstd,
+			args: args{
+				filename: "a.zst",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "7",
+			c:    TypeBzip2,
+			args: args{
+				filename: "a.bz2",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name: "8",
+			c:    TypeXz,
+			args: args{
+				filename: "a.xz",
+				p:        make([]byte, 36),
+			},
+			wantP: []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -127,6 +172,33 @@ func TestType_ReadCloser(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "2",
+			c:    TypeTar,
+			args: args{
+				filename: "a.tar",
+				p:        make([]byte, 38),
+			},
+			wantP: []byte("abcdef\nghijklmnopqrstuvwxyz\n1234567890"),
+		},
+		{
+			name: "3",
+			c:    TypeTarGzip,
+			args: args{
+				filename: "a.tar",
+				p:        make([]byte, 36),
+			},
+			wantErr: true,
+		},
+		{
+			name: "4",
+			c:    TypeXz,
+			args: args{
+				filename: "a.tar",
+				p:        make([]byte, 36),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -184,3 +256,52 @@ func TestType_WriteCloser(t *testing.T) {
 		})
 	}
 }
+
+func TestType_WrittenSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		c       Type
+		data    string
+		want    int64
+		wantErr bool
+	}{
+		{
+			name: "1",
+			c:    TypeNone,
+			data: "abcdef",
+			want: 6,
+		},
+		{
+			name:    "2",
+			c:       TypeTar,
+			data:    "abcdef",
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			c:       TypeTarGzip,
+			data:    "abcdef",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Create(filepath.Join(t.TempDir(), "a.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			if _, err = f.WriteString(tt.data); err != nil {
+				t.Fatal(err)
+			}
+			got, err := tt.c.WrittenSize(f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Type.WrittenSize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Type.WrittenSize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"archive/tar"
+	"io"
+)
+
+// MemberReader 由多个成员文件组成的读取器，如tar，用于逐个读取其中的成员文件
+type MemberReader interface {
+	NextMember() (io.Reader, error) //跳到下一个成员文件并返回其读取器，没有更多成员文件时返回io.EOF
+}
+
+// AsMemberReader 获取r中由多个成员文件组成的读取器，r不由多个成员文件组成时ok为false
+func AsMemberReader(r io.Reader) (m MemberReader, ok bool) {
+	if rc, isReadCloser := r.(*ReadCloser); isReadCloser {
+		r = rc.Reader
+	}
+	m, ok = r.(MemberReader)
+	return
+}
+
+// TarReader tar的读取器，依次读取其中所有的普通文件，
+// 普通文件不以换行结尾时会在下一个普通文件前补充换行
+type TarReader struct {
+	reader    *tar.Reader
+	hasFile   bool
+	lineEnded bool //当前普通文件已读取的内容为空或者以换行结尾
+	newline   bool //在下一个普通文件前需要补充换行
+}
+
+// NewTarReader 通过读取器r创建tar读取器
+func NewTarReader(r io.Reader) *TarReader {
+	return &TarReader{
+		reader: tar.NewReader(r),
+	}
+}
+
+// Read 传入p读取
+func (t *TarReader) Read(p []byte) (n int, err error) {
+	if !t.hasFile {
+		if err = t.next(); err != nil {
+			return
+		}
+	}
+
+	for readBytes := 0; n < len(p); {
+		if t.newline {
+			p[n] = '\n'
+			n++
+			t.newline = false
+			continue
+		}
+		readBytes, err = t.reader.Read(p[n:])
+		if readBytes > 0 {
+			t.lineEnded = p[n+readBytes-1] == '\n'
+		}
+		n += readBytes
+		if err == io.EOF {
+			if n == len(p) {
+				return n, nil
+			}
+			lineEnded := t.lineEnded
+			if err = t.next(); err != nil {
+				return
+			}
+			t.newline = !lineEnded
+			continue
+		}
+
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// NextMember 跳到下一个普通文件并返回其读取器，没有更多普通文件时返回io.EOF，
+// 与Read不能混用
+func (t *TarReader) NextMember() (io.Reader, error) {
+	if err := t.next(); err != nil {
+		return nil, err
+	}
+	return t.reader, nil
+}
+
+// next 跳到下一个普通文件，跳过目录等其他类型的成员
+func (t *TarReader) next() (err error) {
+	var header *tar.Header
+	for {
+		if header, err = t.reader.Next(); err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg || header.Typeflag == tar.TypeRegA {
+			t.hasFile = true
+			t.lineEnded = true
+			return nil
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTarReader_Read(t *testing.T) {
+	type args struct {
+		filename string
+		p        []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantN   int
+		wantP   []byte
+		wantErr bool
+	}{
+		{
+			name: "1",
+			args: args{
+				filename: "a.tar",
+				p:        make([]byte, 38),
+			},
+			wantN: 38,
+			wantP: []byte("abcdef\nghijklmnopqrstuvwxyz\n1234567890"),
+		},
+		{
+			name: "2",
+			args: args{
+				filename: "a.tar",
+				p:        make([]byte, 33),
+			},
+			wantN: 33,
+			wantP: []byte("abcdef\nghijklmnopqrstuvwxyz\n12345"),
+		},
+		{
+			name: "3",
+			args: args{
+				filename: "a.tar",
+				p:        make([]byte, 39),
+			},
+			wantN:   38,
+			wantP:   []byte("abcdef\nghijklmnopqrstuvwxyz\n1234567890"),
+			wantErr: true,
+		},
+		{
+			name: "4",
+			args: args{
+				filename: "a.tar",
+				p:        make([]byte, 6),
+			},
+			wantN: 6,
+			wantP: []byte("abcdef"),
+		},
+		{
+			name: "5",
+			args: args{
+				filename: "a.zip",
+				p:        make([]byte, 6),
+			},
+			wantP:   []byte{},
+			wantErr: true,
+		},
+		{
+			name: "6",
+			args: args{
+				filename: "a.tar",
+				p:        make([]byte, 7),
+			},
+			wantN: 7,
+			wantP: []byte("abcdef\n"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.args.filename))
+			if err != nil {
+				t.Errorf("Open fail. err: %v", err)
+				return
+			}
+			defer f.Close()
+			gotN, err := NewTarReader(f).Read(tt.args.p)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TarReader.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotN != tt.wantN {
+				t.Errorf("TarReader.Read() = %v, want %v", gotN, tt.wantN)
+				return
+			}
+			if !reflect.DeepEqual(tt.args.p[:gotN], tt.wantP) {
+				t.Errorf("TarReader.Read() = %v, want %v", tt.args.p, tt.wantP)
+			}
+		})
+	}
+}
+
+func TestTarReader_NextMember(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "a.tar"))
+	if err != nil {
+		t.Fatalf("Open fail. err: %v", err)
+	}
+	defer f.Close()
+	m, ok := AsMemberReader(NewTarReadCloser(f))
+	if !ok {
+		t.Fatalf("AsMemberReader() ok = %v", ok)
+	}
+	var got []string
+	for {
+		r, err := m.NextMember()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("TarReader.NextMember() error = %v", err)
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		got = append(got, string(data))
+	}
+	want := []string{"abcdef", "ghijklmnopqrstuvwxyz", "1234567890"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("TarReader.NextMember() = %v, want %v", got, want)
+	}
+	if _, ok = AsMemberReader(&ReadCloser{Reader: f}); ok {
+		t.Errorf("AsMemberReader() ok = %v", ok)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"archive/tar"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+)
+
+// TarWriter tar写入器，由于tar需要预先知道文件大小，
+// 写入内容会先缓存在临时文件中，关闭时再写入tar文件
+type TarWriter struct {
+	writer *tar.Writer
+	closer io.Closer
+	name   string
+	temp   *os.File
+}
+
+// NewTarWriter 创建tar写入器
+func NewTarWriter(f *os.File) (tw *TarWriter, err error) {
+	return newTarWriter(f, false)
+}
+
+// NewTarGzipWriter 创建tar.gz写入器
+func NewTarGzipWriter(f *os.File) (tw *TarWriter, err error) {
+	return newTarWriter(f, true)
+}
+
+func newTarWriter(f *os.File, gzipped bool) (tw *TarWriter, err error) {
+	var fi os.FileInfo
+	if fi, err = f.Stat(); err != nil {
+		return nil, err
+	}
+
+	tw = &TarWriter{
+		name: tarMemberName(fi.Name()),
+	}
+	if gzipped {
+		gw := NewGzipWriter(f)
+		tw.closer = gw
+		tw.writer = tar.NewWriter(gw.writer)
+	} else {
+		tw.writer = tar.NewWriter(f)
+	}
+
+	if tw.temp, err = ioutil.TempFile("", "go-etl-tar-*"); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Write 写入p
+func (t *TarWriter) Write(p []byte) (n int, err error) {
+	return t.temp.Write(p)
+}
+
+// Close 关闭，将缓存内容作为一个文件写入tar中
+func (t *TarWriter) Close() (err error) {
+	defer func() {
+		t.temp.Close()
+		os.Remove(t.temp.Name())
+	}()
+
+	var fi os.FileInfo
+	if fi, err = t.temp.Stat(); err != nil {
+		return
+	}
+
+	if err = t.writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     t.name,
+		Size:     fi.Size(),
+		Mode:     0644,
+		ModTime:  time.Now(),
+	}); err != nil {
+		return
+	}
+
+	if _, err = t.temp.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+
+	if _, err = io.Copy(t.writer, t.temp); err != nil {
+		return
+	}
+
+	if err = t.writer.Close(); err != nil {
+		return
+	}
+
+	if t.closer != nil {
+		return t.closer.Close()
+	}
+	return
+}
+
+// tarMemberName 去掉文件名的归档后缀作为tar中的文件名
+func tarMemberName(name string) string {
+	for _, suffix := range []string{".tar.gz", ".tgz", ".tar"} {
+		if strings.HasSuffix(name, suffix) && len(name) > len(suffix) {
+			return strings.TrimSuffix(name, suffix)
+		}
+	}
+	return name
+}
@@ -0,0 +1,166 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compress
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTarWriter_WriteRead(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		gzipped  bool
+		wantName string
+		wantP    []byte
+	}{
+		{
+			name:     "1",
+			filename: "test.csv.tar",
+			wantName: "test.csv",
+			wantP:    []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name:     "2",
+			filename: "test.csv.tar.gz",
+			gzipped:  true,
+			wantName: "test.csv",
+			wantP:    []byte("abcdefghijklmnopqrstuvwxyz1234567890"),
+		},
+		{
+			name:     "3",
+			filename: "test.tgz",
+			gzipped:  true,
+			wantName: "test",
+			wantP:    []byte{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(os.TempDir(), tt.filename)
+			os.Remove(filename)
+			defer os.Remove(filename)
+			write := func() {
+				f, err := os.Create(filename)
+				if err != nil {
+					t.Errorf("Create fail. err: %v", err)
+					return
+				}
+				defer f.Close()
+				var w *TarWriter
+				if tt.gzipped {
+					w, err = NewTarGzipWriter(f)
+				} else {
+					w, err = NewTarWriter(f)
+				}
+				if err != nil {
+					t.Errorf("NewTarWriter fail. err: %v", err)
+					return
+				}
+				if _, err = w.Write(tt.wantP); err != nil {
+					t.Errorf("TarWriter.Write() error = %v", err)
+				}
+				if err = w.Close(); err != nil {
+					t.Errorf("TarWriter.Close() error = %v", err)
+				}
+			}
+
+			read := func() {
+				f, err := os.Open(filename)
+				if err != nil {
+					t.Errorf("Open fail. err: %v", err)
+					return
+				}
+				defer f.Close()
+				var r io.Reader = f
+				if tt.gzipped {
+					if r, err = gzip.NewReader(f); err != nil {
+						t.Errorf("gzip.NewReader fail. err: %v", err)
+						return
+					}
+				}
+				tr := tar.NewReader(r)
+				header, err := tr.Next()
+				if err != nil {
+					t.Errorf("tar.Reader.Next() error = %v", err)
+					return
+				}
+				if header.Name != tt.wantName {
+					t.Errorf("tar.Header.Name = %v, want %v", header.Name, tt.wantName)
+				}
+				gotP, err := ioutil.ReadAll(tr)
+				if err != nil {
+					t.Errorf("ReadAll() error = %v", err)
+					return
+				}
+				if string(gotP) != string(tt.wantP) {
+					t.Errorf("ReadAll() = %v, want %v", gotP, tt.wantP)
+				}
+				if _, err = tr.Next(); err != io.EOF {
+					t.Errorf("tar.Reader.Next() error = %v, want %v", err, io.EOF)
+				}
+			}
+			write()
+			read()
+		})
+	}
+}
+
+func Test_tarMemberName(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want string
+	}{
+		{
+			name: "1",
+			file: "a.csv.tar.gz",
+			want: "a.csv",
+		},
+		{
+			name: "2",
+			file: "a.csv.tgz",
+			want: "a.csv",
+		},
+		{
+			name: "3",
+			file: "a.csv.tar",
+			want: "a.csv",
+		},
+		{
+			name: "4",
+			file: "a.csv",
+			want: "a.csv",
+		},
+		{
+			name: "5",
+			file: ".tar",
+			want: ".tar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tarMemberName(tt.file); got != tt.want {
+				t.Errorf("tarMemberName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -63,9 +63,11 @@ func NewInConfig(conf *config.JSON) (c *InConfig, err error) {
 	}
 
 	switch compress.Type(c.Compress) {
-	case compress.TypeNone, compress.TypeGzip, compress.TypeZip:
+	case compress.TypeNone, compress.TypeGzip, compress.TypeZip,
+		compress.TypeTar, compress.TypeTarGzip, compress.TypeZstd,
+		compress.TypeBzip2, compress.TypeXz:
 	default:
-		return nil, fmt.Errorf("compress %v does not support", c.Encoding)
+		return nil, fmt.Errorf("compress %v does not support", c.Compress)
 	}
 
 	for _, v := range c.Columns {
@@ -134,9 +136,11 @@ func NewOutConfig(conf *config.JSON) (c *OutConfig, err error) {
 	}
 
 	switch compress.Type(c.Compress) {
-	case compress.TypeNone, compress.TypeGzip, compress.TypeZip:
+	case compress.TypeNone, compress.TypeGzip, compress.TypeZip,
+		compress.TypeTar, compress.TypeTarGzip, compress.TypeZstd,
+		compress.TypeBzip2, compress.TypeXz:
 	default:
-		return nil, fmt.Errorf("compress %v does not support", c.Encoding)
+		return nil, fmt.Errorf("compress %v does not support", c.Compress)
 	}
 	for _, v := range c.Columns {
 		if err = v.validate(); err != nil {
@@ -249,6 +249,15 @@ func TestNewInConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "11",
+			args: args{
+				conf: testJSONFromString(`{"compress":"targz"}`),
+			},
+			wantC: &InConfig{
+				Compress: "targz",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -466,6 +475,15 @@ func TestNewOutConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "11",
+			args: args{
+				conf: testJSONFromString(`{"compress":"tar"}`),
+			},
+			wantC: &OutConfig{
+				Compress: "tar",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
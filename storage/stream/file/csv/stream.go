@@ -99,6 +99,7 @@ func (s *Stream) Close() (err error) {
 type Rows struct {
 	columns map[int]Column
 	rc      io.ReadCloser
+	members compress.MemberReader //由多个成员文件组成时逐个读取，如tar
 	reader  *csv.Reader
 	record  []string
 	conf    *InConfig
@@ -110,6 +111,7 @@ type Rows struct {
 func NewRows(f *os.File, c *config.JSON) (file.Rows, error) {
 	var conf *InConfig
 	var err error
+	var ok bool
 	if conf, err = NewInConfig(c); err != nil {
 		return nil, err
 	}
@@ -121,9 +123,10 @@ func NewRows(f *os.File, c *config.JSON) (file.Rows, error) {
 		return nil, err
 	}
 
-	rows.reader = csv.NewReader(f)
-	rows.reader.Comma = conf.comma()
-	rows.reader.Comment = conf.comment()
+	//由多个成员文件组成时每个成员文件都有各自的表头，在读取每个成员文件时再创建csv读取器
+	if rows.members, ok = compress.AsMemberReader(rows.rc); !ok {
+		rows.reader = rows.newReader(rows.rc)
+	}
 
 	for _, v := range conf.Columns {
 		rows.columns[v.index()] = v
@@ -133,15 +136,44 @@ func NewRows(f *os.File, c *config.JSON) (file.Rows, error) {
 
 // Next 是否有下一行
 func (r *Rows) Next() bool {
-	if r.record, r.err = r.reader.Read(); r.err != nil {
+	for {
+		if r.reader != nil {
+			if r.record, r.err = r.reader.Read(); r.err != io.EOF {
+				return r.err == nil
+			}
+			r.err = nil
+		}
+		if !r.nextMember() {
+			return false
+		}
+	}
+}
+
+// nextMember 由多个成员文件组成时切换到下一个成员文件，并重新计数行数以跳过该成员文件的表头
+func (r *Rows) nextMember() bool {
+	if r.members == nil {
+		return false
+	}
+	var member io.Reader
+	if member, r.err = r.members.NextMember(); r.err != nil {
 		if r.err == io.EOF {
 			r.err = nil
 		}
 		return false
 	}
+	r.reader = r.newReader(member)
+	r.row = 0
 	return true
 }
 
+// newReader 通过读取器rd创建csv读取器
+func (r *Rows) newReader(rd io.Reader) *csv.Reader {
+	reader := csv.NewReader(rd)
+	reader.Comma = r.conf.comma()
+	reader.Comment = r.conf.comment()
+	return reader
+}
+
 // Scan 扫描成列
 func (r *Rows) Scan() (columns []element.Column, err error) {
 	r.row++
@@ -200,6 +232,7 @@ func (r *Rows) getColum(index int, s string) (element.Column, error) {
 
 // Writer csv流写入器
 type Writer struct {
+	file    *os.File
 	writer  *csv.Writer
 	wc      io.WriteCloser
 	columns map[int]Column
@@ -215,6 +248,7 @@ func NewWriter(f *os.File, c *config.JSON) (file.StreamWriter, error) {
 	}
 
 	w := &Writer{
+		file:    f,
 		columns: make(map[int]Column),
 		conf:    conf,
 	}
@@ -236,6 +270,11 @@ func (w *Writer) Flush() (err error) {
 	return
 }
 
+// WrittenSize 刷新后已经写入文件的字节数，压缩时为压缩后的字节数
+func (w *Writer) WrittenSize() (int64, error) {
+	return compress.Type(w.conf.Compress).WrittenSize(w.file)
+}
+
 // Close 关闭
 func (w *Writer) Close() (err error) {
 	w.writer.Flush()
@@ -15,12 +15,15 @@
 package csv
 
 import (
+	"archive/tar"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Breeze0806/go-etl/config"
 	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
 )
 
 func Test_ReadWrite(t *testing.T) {
@@ -94,6 +97,90 @@ func Test_ReadWrite(t *testing.T) {
 			},
 			wantStr: "0=<nil> 1=abc",
 		},
+		{
+			name: "5",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewNilTimeColumnValue(), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("abc"),
+						"2", 0),
+				},
+				in:       testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","startRow":2,"compress":"gz"}`),
+				out:      testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","hasHeader":true,"compress":"gz"}`),
+				filename: filepath.Join(tmpDir, "5.csv.gz"),
+			},
+			wantStr: "0=<nil> 1=abc",
+		},
+		{
+			name: "6",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewNilTimeColumnValue(), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("abc"),
+						"2", 0),
+				},
+				in:       testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","startRow":2,"compress":"tar"}`),
+				out:      testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","hasHeader":true,"compress":"tar"}`),
+				filename: filepath.Join(tmpDir, "6.csv.tar"),
+			},
+			wantStr: "0=<nil> 1=abc",
+		},
+		{
+			name: "7",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewNilTimeColumnValue(), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("abc"),
+						"2", 0),
+				},
+				in:       testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","startRow":2,"compress":"targz"}`),
+				out:      testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","hasHeader":true,"compress":"targz"}`),
+				filename: filepath.Join(tmpDir, "7.csv.tar.gz"),
+			},
+			wantStr: "0=<nil> 1=abc",
+		},
+		{
+			name: "8",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewNilTimeColumnValue(), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("abc"),
+						"2", 0),
+				},
+				in:       testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","startRow":2,"compress":"zstd"}`),
+				out:      testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","hasHeader":true,"compress":"zstd"}`),
+				filename: filepath.Join(tmpDir, "8.csv.zst"),
+			},
+			wantStr: "0=<nil> 1=abc",
+		},
+		{
+			name: "9",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewNilTimeColumnValue(), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("abc"),
+						"2", 0),
+				},
+				in:       testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","startRow":2,"compress":"bz2"}`),
+				out:      testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","hasHeader":true,"compress":"bz2"}`),
+				filename: filepath.Join(tmpDir, "9.csv.bz2"),
+			},
+			wantStr: "0=<nil> 1=abc",
+		},
+		{
+			name: "10",
+			args: args{
+				columns: []element.Column{
+					element.NewDefaultColumn(element.NewNilTimeColumnValue(), "1", 0),
+					element.NewDefaultColumn(element.NewStringColumnValue("abc"),
+						"2", 0),
+				},
+				in:       testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","startRow":2,"compress":"xz"}`),
+				out:      testJSONFromString(`{"column":[{"index":"1","type":"time","format":"yyyy-MM-dd"}],"nullFormat":"\u0010","hasHeader":true,"compress":"xz"}`),
+				filename: filepath.Join(tmpDir, "10.csv.xz"),
+			},
+			wantStr: "0=<nil> 1=abc",
+		},
 	}
 
 	for _, tt := range tests {
@@ -162,3 +249,92 @@ func Test_ReadWrite(t *testing.T) {
 		})
 	}
 }
+
+func TestRows_TarMembers(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "members.csv.tar")
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tw := tar.NewWriter(f)
+	//第一个成员文件没有以换行结尾
+	for _, v := range []string{"a,b\n1,x", "a,b\n2,y\n3,z\n"} {
+		if err = tw.WriteHeader(&tar.Header{
+			Name:     "member.csv",
+			Mode:     0644,
+			Size:     int64(len(v)),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tw.Write([]byte(v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	in, err := NewInStream(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	rows, err := in.Rows(testJSONFromString(`{"startRow":2,"compress":"tar"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		cols, err := rows.Scan()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cols) > 0 {
+			r := element.NewDefaultRecord()
+			for _, v := range cols {
+				r.Add(v)
+			}
+			got = append(got, r.String())
+		}
+	}
+	if err = rows.Error(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"0=1 1=x", "0=2 1=y", "0=3 1=z"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got: %v want: %v", got, want)
+	}
+}
+
+func TestWriter_WrittenSize(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "size.csv")
+	out, err := NewOutStream(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	w, err := out.Writer(testJSONFromString(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	record := element.NewDefaultRecord()
+	record.Add(element.NewDefaultColumn(element.NewStringColumnValue("abc"), "a", 3))
+	record.Add(element.NewDefaultColumn(element.NewStringColumnValue("de"), "b", 2))
+	if err = w.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := w.(file.WrittenSizer).WrittenSize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int64(len("abc,de\n")); got != want {
+		t.Errorf("WrittenSize() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file/compress"
+	"github.com/Breeze0806/jodaTime"
+)
+
+// InConfig jsonl输入配置
+type InConfig struct {
+	Columns  []Column `json:"column"`   // 列信息
+	Compress string   `json:"compress"` // 压缩
+}
+
+// NewInConfig 通过conf获取jsonl输入配置
+func NewInConfig(conf *config.JSON) (c *InConfig, err error) {
+	c = &InConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.Columns) == 0 {
+		return nil, fmt.Errorf("column is empty")
+	}
+
+	if err = validateCompress(c.Compress); err != nil {
+		return nil, err
+	}
+
+	for _, v := range c.Columns {
+		if err = v.validate(); err != nil {
+			return nil, err
+		}
+		if element.ColumnType(v.Type) == element.TypeTime && v.Format == "" {
+			return nil, fmt.Errorf("type %v format %v is empty", v.Type, v.Format)
+		}
+	}
+	return
+}
+
+// OutConfig jsonl配置
+type OutConfig struct {
+	Columns    []Column `json:"column"`     // 列信息，为空时以列名为键
+	TimeFormat string   `json:"timeFormat"` // joda时间格式
+	Compress   string   `json:"compress"`   // 压缩
+}
+
+// NewOutConfig 通过conf获取jsonl配置
+func NewOutConfig(conf *config.JSON) (c *OutConfig, err error) {
+	c = &OutConfig{}
+	err = json.Unmarshal([]byte(conf.String()), c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateCompress(c.Compress); err != nil {
+		return nil, err
+	}
+
+	for _, v := range c.Columns {
+		if err = v.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// layout go时间格式
+func (c *OutConfig) layout() string {
+	if c.TimeFormat == "" {
+		return element.DefaultTimeFormat
+	}
+	return jodaTime.GetLayout(c.TimeFormat)
+}
+
+func validateCompress(c string) error {
+	switch compress.Type(c) {
+	case compress.TypeNone, compress.TypeGzip, compress.TypeZip,
+		compress.TypeTar, compress.TypeTarGzip, compress.TypeZstd,
+		compress.TypeBzip2, compress.TypeXz:
+	default:
+		return fmt.Errorf("compress %v does not support", c)
+	}
+	return nil
+}
+
+// Column 列信息
+type Column struct {
+	Path     string `json:"path"`   // json路径，如a.b.0.c代表a对象中b数组第一个对象的c
+	Name     string `json:"name"`   // 列名，为空时使用path，仅用于读取
+	Type     string `json:"type"`   // 类型 bool bigInt decimal string time，为空时按照json值推断
+	Format   string `json:"format"` // joda时间格式
+	goLayout string
+}
+
+// validate 校验
+func (c *Column) validate() (err error) {
+	if c.Path == "" {
+		return fmt.Errorf("path is empty")
+	}
+	switch element.ColumnType(c.Type) {
+	case "", element.TypeBool, element.TypeBigInt,
+		element.TypeDecimal, element.TypeString, element.TypeTime:
+	default:
+		return fmt.Errorf("type %v is not valid", c.Type)
+	}
+	return
+}
+
+// name 列名
+func (c *Column) name() string {
+	if c.Name == "" {
+		return c.Path
+	}
+	return c.Name
+}
+
+// layout 变为golang 时间格式
+func (c *Column) layout() string {
+	if c.goLayout != "" {
+		return c.goLayout
+	}
+	c.goLayout = jodaTime.GetLayout(c.Format)
+	return c.goLayout
+}
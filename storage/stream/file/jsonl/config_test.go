@@ -0,0 +1,161 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+)
+
+func testJSONFromString(json string) *config.JSON {
+	conf, err := config.NewJSONFromString(json)
+	if err != nil {
+		panic(err)
+	}
+	return conf
+}
+
+func TestNewOutConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		conf       *config.JSON
+		wantLayout string
+		wantErr    bool
+	}{
+		{
+			name:       "1",
+			conf:       testJSONFromString(`{}`),
+			wantLayout: element.DefaultTimeFormat,
+		},
+		{
+			name:       "2",
+			conf:       testJSONFromString(`{"timeFormat":"yyyy-MM-dd","compress":"gz"}`),
+			wantLayout: "2006-01-02",
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"compress":"rar"}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"timeFormat":1}`),
+			wantErr: true,
+		},
+		{
+			name:       "5",
+			conf:       testJSONFromString(`{"compress":"zstd"}`),
+			wantLayout: element.DefaultTimeFormat,
+		},
+		{
+			name:       "6",
+			conf:       testJSONFromString(`{"column":[{"path":"a.b","type":"time"}]}`),
+			wantLayout: element.DefaultTimeFormat,
+		},
+		{
+			name:    "7",
+			conf:    testJSONFromString(`{"column":[{"path":"","type":"string"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "8",
+			conf:    testJSONFromString(`{"column":[{"path":"a","type":"bytes"}]}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewOutConfig(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewOutConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got := c.layout(); got != tt.wantLayout {
+				t.Errorf("layout() = %v, want %v", got, tt.wantLayout)
+			}
+		})
+	}
+}
+
+func TestNewInConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *config.JSON
+		want    *InConfig
+		wantErr bool
+	}{
+		{
+			name: "1",
+			conf: testJSONFromString(`{"column":[{"path":"a.b"},{"path":"c","name":"c1","type":"time","format":"yyyy-MM-dd"}],"compress":"gz"}`),
+			want: &InConfig{
+				Columns: []Column{
+					{
+						Path: "a.b",
+					},
+					{
+						Path:   "c",
+						Name:   "c1",
+						Type:   "time",
+						Format: "yyyy-MM-dd",
+					},
+				},
+				Compress: "gz",
+			},
+		},
+		{
+			name:    "2",
+			conf:    testJSONFromString(`{}`),
+			wantErr: true,
+		},
+		{
+			name:    "3",
+			conf:    testJSONFromString(`{"column":[{"path":"a"}],"compress":"rar"}`),
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			conf:    testJSONFromString(`{"column":[{"path":"a","type":"time"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			conf:    testJSONFromString(`{"column":[{"type":"string"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "6",
+			conf:    testJSONFromString(`{"column":1}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewInConfig(tt.conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewInConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewInConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
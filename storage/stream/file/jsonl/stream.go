@@ -0,0 +1,427 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+	"github.com/Breeze0806/go-etl/storage/stream/file/compress"
+	"github.com/pingcap/errors"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+func init() {
+	var opener Opener
+	file.RegisterOpener("jsonl", &opener)
+	var creator Creator
+	file.RegisterCreator("jsonl", &creator)
+}
+
+// Opener jsonl输入流打开器
+type Opener struct {
+}
+
+// Open 打开一个名为filename的jsonl输入流
+func (o *Opener) Open(filename string) (file.InStream, error) {
+	return NewInStream(filename)
+}
+
+// Creator jsonl输出流创建器
+type Creator struct {
+}
+
+// Create 创建一个名为filename的jsonl输出流
+func (c *Creator) Create(filename string) (file.OutStream, error) {
+	return NewOutStream(filename)
+}
+
+// Stream jsonl文件流
+type Stream struct {
+	file *os.File
+}
+
+// NewInStream 创建一个名为filename的jsonl输入流
+func NewInStream(filename string) (file.InStream, error) {
+	stream := &Stream{}
+	var err error
+	stream.file, err = os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// NewOutStream 创建一个名为filename的jsonl输出流
+func NewOutStream(filename string) (file.OutStream, error) {
+	stream := &Stream{}
+	var err error
+	stream.file, err = os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Writer 新建一个配置为conf的jsonl流写入器
+func (s *Stream) Writer(conf *config.JSON) (file.StreamWriter, error) {
+	return NewWriter(s.file, conf)
+}
+
+// Rows 新建一个配置为conf的jsonl行读取器
+func (s *Stream) Rows(conf *config.JSON) (rows file.Rows, err error) {
+	return NewRows(s.file, conf)
+}
+
+// Close 关闭文件流
+func (s *Stream) Close() (err error) {
+	return s.file.Close()
+}
+
+// Rows 行读取器，每一行为一个json对象，空行会被跳过
+type Rows struct {
+	rc     io.ReadCloser
+	reader *bufio.Reader
+	conf   *InConfig
+	line   []byte
+	row    int
+	err    error
+}
+
+// NewRows 通过文件句柄f，和配置文件c 创建行读取器
+func NewRows(f *os.File, c *config.JSON) (file.Rows, error) {
+	var conf *InConfig
+	var err error
+	if conf, err = NewInConfig(c); err != nil {
+		return nil, err
+	}
+	rows := &Rows{
+		conf: conf,
+	}
+	if rows.rc, err = compress.Type(conf.Compress).ReadCloser(f); err != nil {
+		return nil, err
+	}
+	rows.reader = bufio.NewReader(rows.rc)
+	return rows, nil
+}
+
+// Next 是否有下一行
+func (r *Rows) Next() bool {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		if len(line) > 0 {
+			r.row++
+		}
+		if r.line = bytes.TrimSpace(line); len(r.line) > 0 {
+			return true
+		}
+		if err != nil {
+			if err != io.EOF {
+				r.err = err
+			}
+			return false
+		}
+	}
+}
+
+// Scan 扫描成列
+func (r *Rows) Scan() (columns []element.Column, err error) {
+	if !gjson.ValidBytes(r.line) {
+		return nil, errors.Errorf("line %v is not valid json", r.row)
+	}
+	res := gjson.ParseBytes(r.line)
+	for i := range r.conf.Columns {
+		var c element.Column
+		if c, err = r.getColumn(&r.conf.Columns[i], res.Get(r.conf.Columns[i].Path)); err != nil {
+			return nil, errors.Wrapf(err, "line %v path %v", r.row, r.conf.Columns[i].Path)
+		}
+		columns = append(columns, c)
+	}
+	return
+}
+
+// Error 读取中的错误
+func (r *Rows) Error() error {
+	return r.err
+}
+
+// Close 关闭读文件流
+func (r *Rows) Close() error {
+	return r.rc.Close()
+}
+
+// getColumn 将json值v按照列信息c转化为列
+func (r *Rows) getColumn(c *Column, v gjson.Result) (element.Column, error) {
+	var cv element.ColumnValue
+	var err error
+	if v.Type == gjson.Null {
+		cv = nilColumnValue(element.ColumnType(c.Type))
+		return element.NewDefaultColumn(cv, c.name(), 0), nil
+	}
+
+	switch element.ColumnType(c.Type) {
+	case element.TypeBool:
+		var b bool
+		switch v.Type {
+		case gjson.True, gjson.False:
+			b = v.Bool()
+		case gjson.String:
+			if b, err = strconv.ParseBool(v.Str); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.Errorf("%v is not bool", v.Raw)
+		}
+		cv = element.NewBoolColumnValue(b)
+	case element.TypeBigInt:
+		cv, err = element.NewBigIntColumnValueFromString(numberString(v))
+	case element.TypeDecimal:
+		cv, err = element.NewDecimalColumnValueFromString(numberString(v))
+	case element.TypeString:
+		if v.Type == gjson.String {
+			cv = element.NewStringColumnValue(v.Str)
+		} else {
+			cv = element.NewStringColumnValue(v.Raw)
+		}
+	case element.TypeTime:
+		if v.Type != gjson.String {
+			return nil, errors.Errorf("%v is not time string", v.Raw)
+		}
+		layout := c.layout()
+		var t time.Time
+		if t, err = time.Parse(layout, v.Str); err != nil {
+			return nil, errors.Wrapf(err, "Parse time fail. layout: %v", layout)
+		}
+		cv = element.NewTimeColumnValueWithDecoder(t, element.NewStringTimeDecoder(layout))
+	default:
+		cv, err = inferColumnValue(v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return element.NewDefaultColumn(cv, c.name(), len(v.Raw)), nil
+}
+
+// nilColumnValue 获取类型为typ的空值
+func nilColumnValue(typ element.ColumnType) element.ColumnValue {
+	switch typ {
+	case element.TypeBool:
+		return element.NewNilBoolColumnValue()
+	case element.TypeBigInt:
+		return element.NewNilBigIntColumnValue()
+	case element.TypeDecimal:
+		return element.NewNilDecimalColumnValue()
+	case element.TypeTime:
+		return element.NewNilTimeColumnValue()
+	}
+	return element.NewNilStringColumnValue()
+}
+
+// numberString 获取数值或者字符串形式的数值
+func numberString(v gjson.Result) string {
+	if v.Type == gjson.String {
+		return v.Str
+	}
+	return v.Raw
+}
+
+// inferColumnValue 在未配置类型时按照json值推断列值，
+// 整数为bigInt，其他数值为decimal，对象和数组为其json字符串
+func inferColumnValue(v gjson.Result) (element.ColumnValue, error) {
+	switch v.Type {
+	case gjson.True, gjson.False:
+		return element.NewBoolColumnValue(v.Bool()), nil
+	case gjson.Number:
+		if strings.ContainsAny(v.Raw, ".eE") {
+			return element.NewDecimalColumnValueFromString(v.Raw)
+		}
+		return element.NewBigIntColumnValueFromString(v.Raw)
+	case gjson.String:
+		return element.NewStringColumnValue(v.Str), nil
+	}
+	return element.NewStringColumnValue(v.Raw), nil
+}
+
+// Writer jsonl流写入器
+type Writer struct {
+	file   *os.File
+	writer *bufio.Writer
+	wc     io.WriteCloser
+	conf   *OutConfig
+	layout string
+}
+
+// NewWriter 通过文件句柄f，和配置文件c 创建jsonl流写入器
+func NewWriter(f *os.File, c *config.JSON) (file.StreamWriter, error) {
+	var conf *OutConfig
+	var err error
+	if conf, err = NewOutConfig(c); err != nil {
+		return nil, err
+	}
+
+	w := &Writer{
+		file:   f,
+		conf:   conf,
+		layout: conf.layout(),
+	}
+
+	if w.wc, err = compress.Type(conf.Compress).WriteCloser(f); err != nil {
+		return nil, err
+	}
+	w.writer = bufio.NewWriter(w.wc)
+	return w, nil
+}
+
+// Flush 刷新至磁盘
+func (w *Writer) Flush() (err error) {
+	return w.writer.Flush()
+}
+
+// WrittenSize 刷新后已经写入文件的字节数，压缩时为压缩后的字节数
+func (w *Writer) WrittenSize() (int64, error) {
+	return compress.Type(w.conf.Compress).WrittenSize(w.file)
+}
+
+// Close 关闭
+func (w *Writer) Close() (err error) {
+	if err = w.writer.Flush(); err != nil {
+		return
+	}
+	return w.wc.Close()
+}
+
+// Write 将记录record按列的顺序写成一行json对象，
+// 配置了列信息时按照列信息中的json路径写入
+func (w *Writer) Write(record element.Record) (err error) {
+	if len(w.conf.Columns) > 0 {
+		return w.writeWithColumns(record)
+	}
+
+	if err = w.writer.WriteByte('{'); err != nil {
+		return
+	}
+	for i := 0; i < record.ColumnNumber(); i++ {
+		var col element.Column
+		if col, err = record.GetByIndex(i); err != nil {
+			return
+		}
+		if i > 0 {
+			if err = w.writer.WriteByte(','); err != nil {
+				return
+			}
+		}
+		var b []byte
+		if b, err = json.Marshal(col.Name()); err != nil {
+			return
+		}
+		if _, err = w.writer.Write(b); err != nil {
+			return
+		}
+		if err = w.writer.WriteByte(':'); err != nil {
+			return
+		}
+		if b, err = w.value(col, nil); err != nil {
+			return
+		}
+		if _, err = w.writer.Write(b); err != nil {
+			return
+		}
+	}
+	_, err = w.writer.WriteString("}\n")
+	return
+}
+
+// writeWithColumns 将记录record的第i列写入第i个列信息的json路径，
+// 超出列信息的列以列名为键
+func (w *Writer) writeWithColumns(record element.Record) (err error) {
+	line := []byte("{}")
+	for i := 0; i < record.ColumnNumber(); i++ {
+		var col element.Column
+		if col, err = record.GetByIndex(i); err != nil {
+			return
+		}
+		var c *Column
+		path := col.Name()
+		if i < len(w.conf.Columns) {
+			c = &w.conf.Columns[i]
+			path = c.Path
+		}
+		var b []byte
+		if b, err = w.value(col, c); err != nil {
+			return
+		}
+		if line, err = sjson.SetRawBytes(line, path, b); err != nil {
+			return errors.Wrapf(err, "set path %v fail", path)
+		}
+	}
+	if _, err = w.writer.Write(line); err != nil {
+		return
+	}
+	return w.writer.WriteByte('\n')
+}
+
+// value 将列col按照列信息c转化为json值，c为空时按照列的类型转化
+func (w *Writer) value(col element.Column, c *Column) ([]byte, error) {
+	if col.IsNil() {
+		return []byte("null"), nil
+	}
+	typ := col.Type()
+	layout := w.layout
+	if c != nil {
+		if c.Type != "" {
+			typ = element.ColumnType(c.Type)
+		}
+		if c.Format != "" {
+			layout = c.layout()
+		}
+	}
+	switch typ {
+	case element.TypeBool:
+		v, err := col.AsBool()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(v)
+	case element.TypeBigInt:
+		v, err := col.AsBigInt()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(v.String()), nil
+	case element.TypeDecimal:
+		v, err := col.AsDecimal()
+		if err != nil {
+			return nil, err
+		}
+		return []byte(v.String()), nil
+	case element.TypeTime:
+		t, err := col.AsTime()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(t.Format(layout))
+	}
+	return json.Marshal(col.String())
+}
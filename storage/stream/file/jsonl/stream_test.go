@@ -0,0 +1,254 @@
+// Copyright 2020 the go-etl Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonl
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Breeze0806/go-etl/config"
+	"github.com/Breeze0806/go-etl/element"
+	"github.com/Breeze0806/go-etl/storage/stream/file"
+)
+
+func TestWriter_Write(t *testing.T) {
+	tmpDir := os.TempDir()
+	tests := []struct {
+		name     string
+		columns  []element.Column
+		conf     *config.JSON
+		filename string
+		want     string
+	}{
+		{
+			name: "1",
+			columns: []element.Column{
+				element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0),
+				element.NewDefaultColumn(element.NewStringColumnValue(`a"b`), "name", 0),
+				element.NewDefaultColumn(element.NewBoolColumnValue(true), "ok", 0),
+				element.NewDefaultColumn(element.NewDecimalColumnValueFromFloat(1.5), "amount", 0),
+				element.NewDefaultColumn(element.NewTimeColumnValue(
+					time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)), "dt", 0),
+				element.NewDefaultColumn(element.NewNilStringColumnValue(), "nil", 0),
+			},
+			conf:     testJSONFromString(`{"timeFormat":"yyyy-MM-dd"}`),
+			filename: filepath.Join(tmpDir, "1.jsonl"),
+			want:     `{"id":1,"name":"a\"b","ok":true,"amount":1.5,"dt":"2022-01-02","nil":null}` + "\n",
+		},
+		{
+			name: "2",
+			columns: []element.Column{
+				element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "id", 0),
+				element.NewDefaultColumn(element.NewStringColumnValue("2"), "amount", 0),
+				element.NewDefaultColumn(element.NewTimeColumnValue(
+					time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)), "dt", 0),
+				element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(1), "flag", 0),
+				element.NewDefaultColumn(element.NewStringColumnValue("x"), "extra", 0),
+			},
+			conf:     testJSONFromString(`{"column":[{"path":"user.id","type":"string"},{"path":"order.amount","type":"decimal"},{"path":"order.dt","format":"yyyyMMdd"},{"path":"flag","type":"bool"}]}`),
+			filename: filepath.Join(tmpDir, "2.jsonl"),
+			want:     `{"user":{"id":"1"},"order":{"amount":2,"dt":"20220102"},"flag":true,"extra":"x"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer os.Remove(tt.filename)
+			out, err := file.NewOutStreamer("jsonl", tt.filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			w, err := out.Writer(tt.conf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := element.NewDefaultRecord()
+			for _, c := range tt.columns {
+				r.Add(c)
+			}
+			if err = w.Write(r); err != nil {
+				t.Fatal(err)
+			}
+			if err = w.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err = out.Close(); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ioutil.ReadFile(tt.filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Write() = %v, want %v", string(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestRows(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "jsonl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	tests := []struct {
+		name    string
+		data    string
+		conf    *config.JSON
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "1",
+			data: `{"id":1,"user":{"name":"a","tags":["x","y"]},"amount":1.5,"ok":true,"dt":"2022-01-02"}` + "\n\n" +
+				`{"id":2,"user":null,"amount":"2","ok":"false","dt":null}`,
+			conf: testJSONFromString(`{"column":[{"path":"id"},{"path":"user.name","name":"name"},{"path":"user.tags","type":"string"},` +
+				`{"path":"amount","type":"decimal"},{"path":"ok","type":"bool"},{"path":"dt","type":"time","format":"yyyy-MM-dd"},{"path":"none"}]}`),
+			want: []string{
+				`id=1 name=a user.tags=["x","y"] amount=1.5 ok=true dt=2022-01-02 00:00:00Z none=<nil>`,
+				`id=2 name=<nil> user.tags=<nil> amount=2 ok=false dt=<nil> none=<nil>`,
+			},
+		},
+		{
+			name: "2",
+			data: `{"a":1.25e2,"b":{"c":1},"c":"s"}` + "\r\n",
+			conf: testJSONFromString(`{"column":[{"path":"a"},{"path":"b"},{"path":"c","type":"bigInt"}]}`),
+			want: []string{
+				`a=125 b={"c":1} c=<nil>`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "3",
+			data: `{"a":1}` + "\n" + `{"a":`,
+			conf: testJSONFromString(`{"column":[{"path":"a"}]}`),
+			want: []string{
+				`a=1`,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "4",
+			data:    `{"a":1}`,
+			conf:    testJSONFromString(`{"column":[{"path":"a","type":"time","format":"yyyy"}]}`),
+			wantErr: true,
+		},
+		{
+			name:    "5",
+			data:    `{"a":[1]}`,
+			conf:    testJSONFromString(`{"column":[{"path":"a","type":"bool"}]}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(tmpDir, tt.name+".jsonl")
+			if err := ioutil.WriteFile(filename, []byte(tt.data), 0644); err != nil {
+				t.Fatal(err)
+			}
+			var opener Opener
+			in, err := opener.Open(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer in.Close()
+			rows, err := in.Rows(tt.conf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer rows.Close()
+			var got []string
+			for rows.Next() {
+				var columns []element.Column
+				if columns, err = rows.Scan(); err != nil {
+					break
+				}
+				r := element.NewDefaultRecord()
+				for _, c := range columns {
+					r.Add(c)
+				}
+				got = append(got, r.String())
+			}
+			if err == nil {
+				err = rows.Error()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Rows error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Rows = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadWrite(t *testing.T) {
+	filename := filepath.Join(os.TempDir(), "rw.jsonl.zst")
+	defer os.Remove(filename)
+
+	out, err := file.NewOutStreamer("jsonl", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := out.Writer(testJSONFromString(`{"compress":"zstd","column":[{"path":"a.b"},{"path":"a.c","format":"yyyy-MM-dd"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := element.NewDefaultRecord()
+	r.Add(element.NewDefaultColumn(element.NewBigIntColumnValueFromInt64(7), "b", 0))
+	r.Add(element.NewDefaultColumn(element.NewTimeColumnValue(
+		time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)), "c", 0))
+	if err = w.Write(r); err != nil {
+		t.Fatal(err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = out.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	in, err := file.NewInStreamer("jsonl", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+	handler := &mockHandler{}
+	if err = in.Read(context.TODO(), testJSONFromString(`{"compress":"zstd","column":[{"path":"a.b","type":"bigInt"},{"path":"a.c","type":"time","format":"yyyy-MM-dd"}]}`), handler); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a.b=7 a.c=2022-01-02 00:00:00Z"}
+	if !reflect.DeepEqual(handler.records, want) {
+		t.Errorf("Read() = %v, want %v", handler.records, want)
+	}
+}
+
+type mockHandler struct {
+	records []string
+}
+
+func (m *mockHandler) OnRecord(r element.Record) error {
+	m.records = append(m.records, r.String())
+	return nil
+}
+
+func (m *mockHandler) CreateRecord() (element.Record, error) {
+	return element.NewDefaultRecord(), nil
+}
@@ -41,6 +41,11 @@ type StreamWriter interface {
 	Close() (err error)                      //关闭输出流写入器
 }
 
+// WrittenSizer 输出流写入器的补充方法，用于获取刷新后已经写入文件的字节数
+type WrittenSizer interface {
+	WrittenSize() (int64, error) //已经写入文件的字节数
+}
+
 // RegisterCreator 通过创建器名称name注册输出流创建器creator
 func RegisterCreator(name string, creator Creator) {
 	if err := creators.register(name, creator); err != nil {